@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/infra/adapters/service"
 	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/infra/httpx"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator"
 	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog/sqlite"
 	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
 	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
@@ -76,8 +78,37 @@ func main() {
 
 	orderService := service.NewGRPCOrderClient(orderClient)
 
-	handler := httpx.NewHandler(orderService, orderClient, payClient, invClient, sagaRepo)
-	router := httpx.NewRouter(handler)
+	// Failed compensations are persisted in the saga log DB and retried by
+	// the compensation worker instead of being silently lost. The saga
+	// timeout guarantees a hung downstream call cannot pin a saga forever.
+	sagaOpts := []coordinator.Option{
+		coordinator.WithCompensationQueue(sagaRepo),
+		coordinator.WithSagaTimeout(getEnvDuration("SAGA_TIMEOUT", 30*time.Second)),
+	}
+
+	handler := httpx.NewHandler(orderService, orderClient, payClient, invClient, sagaRepo, sagaOpts...)
+	router := httpx.NewRouter(handler, httpx.NewAdminHandler(sagaRepo))
+
+	registry := coordinator.NewOrderSagaRegistry(orderClient, payClient, invClient)
+
+	// Drive sagas interrupted by a previous crash or restart to a terminal
+	// state (releasing stock, refunding payments) before they are forgotten.
+	recoverer := coordinator.NewRecoverer(sagaRepo, registry.Rebuild, coordinator.ResumeInFlight,
+		append(handler.SagaHooks(), sagaOpts...)...,
+	)
+	go func() {
+		n, err := recoverer.Recover(ctx)
+		if err != nil {
+			slog.Error("saga recovery failed", "error", err)
+			return
+		}
+		slog.Info("saga recovery finished", "recovered", n)
+	}()
+
+	compensationPolicy := coordinator.DefaultCompensationPolicy
+	compensationPolicy.MaxAttempts = getEnvInt("COMPENSATION_MAX_ATTEMPTS", compensationPolicy.MaxAttempts)
+	worker := coordinator.NewCompensationWorker(sagaRepo, registry.Rebuild, compensationPolicy, 5*time.Second)
+	go worker.Run(ctx)
 
 	httpAddr := getEnv("HTTP_ADDR", ":8080")
 	slog.Info("API Gateway (Orchestrator) running", "addr", httpAddr)
@@ -95,6 +126,22 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 // mustDial creates a gRPC client connection or exits the process on failure.
 func mustDial(addr string, extraOpts ...grpc.DialOption) *grpc.ClientConn {
 	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, extraOpts...)
@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,6 +19,8 @@ import (
 
 	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
 	inventoryservice "github.com/jcmexdev/ecommerce-sagas/internal/inventory-service"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/adapters/storage/sqlite"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/telemetry"
@@ -52,9 +59,44 @@ func main() {
 
 	redisAddr := getEnv("REDIS_ADDR", "redis-cache:6379")
 	redisCache := cache.NewRedisCache(redisAddr, "inventory")
-	inventorySrv := inventoryservice.NewClient(redisCache)
+
+	dbPath := getEnv("INVENTORY_DB_PATH", "./data/inventory.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		slog.Error("failed to create data directory", "error", err)
+		os.Exit(1)
+	}
+
+	inventoryRepo, err := sqlite.Open(dbPath)
+	if err != nil {
+		slog.Error("failed to open inventory DB", "path", dbPath, "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := inventoryRepo.Close(); err != nil {
+			slog.Error("inventory DB close error", "error", err)
+		}
+	}()
+
+	if err := seedProducts(ctx, inventoryRepo, getEnv("INVENTORY_SEED_PRODUCTS", defaultSeed)); err != nil {
+		slog.Error("failed to seed products", "error", err)
+		os.Exit(1)
+	}
+
+	strategy, err := domain.StrategyByName(getEnv("INVENTORY_ALLOCATION_STRATEGY", domain.StrategyFewestSplits))
+	if err != nil {
+		slog.Error("invalid INVENTORY_ALLOCATION_STRATEGY", "error", err)
+		os.Exit(1)
+	}
+
+	reservationTTL := getEnvDuration("INVENTORY_RESERVATION_TTL", 15*time.Minute)
+	inventorySrv := inventoryservice.NewClient(inventoryRepo, redisCache, reservationTTL, strategy)
 	inventoryv1.RegisterInventoryServer(grpcServer, inventorySrv)
 
+	// Returns stock held by reservations that were never committed, e.g.
+	// because the gateway crashed mid-saga.
+	reaper := inventoryservice.NewReaper(inventoryRepo, getEnvDuration("INVENTORY_REAPER_INTERVAL", 30*time.Second))
+	go reaper.Run(ctx)
+
 	slog.Info("inventory service gRPC running", "addr", addr)
 
 	if err := grpcServer.Serve(lis); err != nil {
@@ -69,3 +111,43 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// defaultSeed is the demo catalogue used by the README examples.
+const defaultSeed = "prod_1=15,prod_2=10,prod_3=0"
+
+// seedProducts creates the products listed in spec ("id=stock,...") that do
+// not exist yet. Existing products keep their stock, so restarts never undo
+// changes made through the catalogue RPCs. An empty spec seeds nothing.
+func seedProducts(ctx context.Context, repo domain.InventoryRepository, spec string) error {
+	now := time.Now().UTC()
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, rawStock, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid seed entry %q, want id=stock", entry)
+		}
+		stock, err := strconv.ParseInt(rawStock, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid stock in seed entry %q: %w", entry, err)
+		}
+		p, err := domain.NewProduct(id, id, int32(stock), now)
+		if err != nil {
+			return fmt.Errorf("invalid seed entry %q: %w", entry, err)
+		}
+		if err := repo.CreateProduct(ctx, p); err != nil && !errors.Is(err, domain.ErrProductExists) {
+			return err
+		}
+	}
+	return nil
+}
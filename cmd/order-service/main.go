@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/grpc"
 
 	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/adapters/storage/sqlite"
 	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/app"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors"
@@ -52,7 +54,25 @@ func main() {
 
 	redisAddr := getEnv("REDIS_ADDR", "redis-cache:6379")
 	redisCache := cache.NewRedisCache(redisAddr, "order")
-	orderSrv := app.NewOrderServer(redisCache)
+
+	dbPath := getEnv("ORDER_DB_PATH", "./data/orders.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		slog.Error("failed to create data directory", "error", err)
+		os.Exit(1)
+	}
+
+	orderRepo, err := sqlite.Open(dbPath)
+	if err != nil {
+		slog.Error("failed to open order DB", "path", dbPath, "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := orderRepo.Close(); err != nil {
+			slog.Error("order DB close error", "error", err)
+		}
+	}()
+
+	orderSrv := app.NewOrderServer(orderRepo, redisCache)
 	orderv1.RegisterOrderServer(grpcServer, orderSrv)
 
 	slog.Info("order service gRPC running", "addr", addr)
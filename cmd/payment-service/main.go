@@ -6,6 +6,8 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,9 +15,12 @@ import (
 	"google.golang.org/grpc"
 
 	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/adapters/provider/simulator"
 	paymentservice "github.com/jcmexdev/ecommerce-sagas/internal/payment-service/app"
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/telemetry"
 )
 
@@ -52,7 +57,14 @@ func main() {
 
 	redisAddr := getEnv("REDIS_ADDR", "redis-cache:6379")
 	redisCache := cache.NewRedisCache(redisAddr, "payment")
-	paymentSrv := paymentservice.NewClient(redisCache)
+	rates, err := money.NewStaticRates("USD", money.DefaultRates)
+	if err != nil {
+		slog.Error("failed to load exchange rates", "error", err)
+		os.Exit(1)
+	}
+	provider := simulator.New(simulatorConfig(), rates)
+	paymentSrv := paymentservice.NewClient(redisCache, provider)
+	provider.OnWebhook(paymentSrv.HandleWebhook)
 	paymentv1.RegisterPaymentServer(grpcServer, paymentSrv)
 
 	slog.Info("payment service gRPC running", "addr", addr)
@@ -63,9 +75,44 @@ func main() {
 	}
 }
 
+// simulatorConfig reads the PAYMENT_SIM_* variables on top of
+// simulator.DefaultConfig, so a local stack can rehearse a flaky provider.
+func simulatorConfig() simulator.Config {
+	cfg := simulator.DefaultConfig()
+	cfg.Latency = getEnvDuration("PAYMENT_SIM_LATENCY", cfg.Latency)
+	cfg.Jitter = getEnvDuration("PAYMENT_SIM_JITTER", cfg.Jitter)
+	cfg.DeclineRate = getEnvFloat("PAYMENT_SIM_DECLINE_RATE", cfg.DeclineRate)
+	cfg.ForceDeclineCode = domain.DeclineCode(getEnv("PAYMENT_SIM_FORCE_DECLINE_CODE", ""))
+	cfg.TimeoutRate = getEnvFloat("PAYMENT_SIM_TIMEOUT_RATE", cfg.TimeoutRate)
+	cfg.Timeout = getEnvDuration("PAYMENT_SIM_TIMEOUT", cfg.Timeout)
+	cfg.DuplicateWebhookRate = getEnvFloat("PAYMENT_SIM_DUPLICATE_WEBHOOK_RATE", cfg.DuplicateWebhookRate)
+	if codes := getEnv("PAYMENT_SIM_DECLINE_CODES", ""); codes != "" {
+		for _, c := range strings.Split(codes, ",") {
+			cfg.DeclineCodes = append(cfg.DeclineCodes, domain.DeclineCode(strings.TrimSpace(c)))
+		}
+	}
+	return cfg
+}
+
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return fallback
 }
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
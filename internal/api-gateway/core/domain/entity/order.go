@@ -1,18 +1,64 @@
 package entity
 
+import (
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
 type CreateOrderItem struct {
 	ProductID string
 	Quantity  int
-	Price     float64
+	Price     money.Money
+
+	// Reserved and Backordered are the units that will ship, now or once
+	// restocked; zero until the stock is reserved.
+	Reserved    int
+	Backordered int
 }
 
 type Order struct {
 	ID         string
 	CustomerID string
 	Status     string
-	Total      float64
+	Total      money.Money
 	Reason     string
 	Items      []CreateOrderItem
 	CreatedAt  string
 	UpdatedAt  string
+
+	// FulfillmentPolicy is ALL_OR_NOTHING, PARTIAL or ALLOW_BACKORDER.
+	FulfillmentPolicy string
+	// Fulfillment is COMPLETE, BACKORDERED or PARTIAL once the stock is
+	// reserved, "" before.
+	Fulfillment string
+	// FulfilledTotal is what the customer pays for the units that ship.
+	FulfilledTotal money.Money
+}
+
+// StatusChange is one entry of an order's status history.
+type StatusChange struct {
+	From      string
+	To        string
+	Reason    string
+	Actor     string
+	RequestID string
+	ChangedAt string
+}
+
+// OrderFilter selects orders to list. Zero values mean "no constraint".
+type OrderFilter struct {
+	CustomerID    string
+	Statuses      []string
+	CreatedAfter  time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+	OldestFirst   bool
+	PageSize      int
+	PageToken     string
+}
+
+// OrderPage is one page of listed orders.
+type OrderPage struct {
+	Orders        []*Order
+	NextPageToken string
 }
@@ -7,6 +7,7 @@ import (
 )
 
 type OrderService interface {
-	CreateOrder(ctx context.Context, customerID, idempotencyKey string, items []entity.CreateOrderItem) (*entity.Order, error)
 	GetOrder(ctx context.Context, id string) (*entity.Order, error)
+	GetOrderHistory(ctx context.Context, id string) ([]entity.StatusChange, error)
+	ListOrders(ctx context.Context, filter entity.OrderFilter) (*entity.OrderPage, error)
 }
@@ -3,8 +3,14 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	moneyv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/money/v1"
 	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
 
 	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/domain/entity"
 	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/ports"
@@ -23,55 +29,77 @@ func NewGRPCOrderClient(client orderv1.OrderClient) ports.OrderService {
 // Aseguramos en compile-time que implementa la interfaz
 var _ ports.OrderService = (*GRPCOrderService)(nil)
 
-// CreateOrder implementa el puerto usando gRPC por debajo.
-func (s *GRPCOrderService) CreateOrder(
-	ctx context.Context,
-	customerID, idempotencyKey string,
-	items []entity.CreateOrderItem,
-) (*entity.Order, error) {
-	protoItems := make([]*orderv1.OrderItem, 0, len(items))
-
-	for _, it := range items {
-		protoItems = append(protoItems, &orderv1.OrderItem{
-			ProductId: it.ProductID,
-			Quantity:  int32(it.Quantity),
-			UnitPrice: it.Price,
-		})
-	}
-
-	req := &orderv1.CreateOrderRequest{
-		CustomerId: customerID,
-		Items:      protoItems,
-	}
+// GetOrder implementa el puerto usando gRPC
+func (s *GRPCOrderService) GetOrder(ctx context.Context, id string) (*entity.Order, error) {
+	req := &orderv1.GetOrderRequest{Id: id}
 
-	res, err := s.client.CreateOrder(ctx, req)
+	res, err := s.client.GetOrder(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("grpc CreateOrder: %w", err)
+		return nil, fmt.Errorf("grpc GetOrder: %w", err)
 	}
 
 	po := res.GetOrder()
 	if po == nil {
-		return nil, fmt.Errorf("grpc CreateOrder: empty order in response")
+		return nil, fmt.Errorf("grpc GetOrder: empty order in response")
 	}
 
 	return mapProtoOrderToEntity(po), nil
 }
 
-// GetOrder implementa el puerto usando gRPC
-func (s *GRPCOrderService) GetOrder(ctx context.Context, id string) (*entity.Order, error) {
-	req := &orderv1.GetOrderRequest{Id: id}
-
-	res, err := s.client.GetOrder(ctx, req)
+// GetOrderHistory devuelve los cambios de estado de la orden, del más antiguo al más reciente.
+func (s *GRPCOrderService) GetOrderHistory(ctx context.Context, id string) ([]entity.StatusChange, error) {
+	res, err := s.client.GetOrderHistory(ctx, &orderv1.GetOrderHistoryRequest{Id: id})
 	if err != nil {
-		return nil, fmt.Errorf("grpc GetOrder: %w", err)
+		return nil, fmt.Errorf("grpc GetOrderHistory: %w", err)
 	}
 
-	po := res.GetOrder()
-	if po == nil {
-		return nil, fmt.Errorf("grpc GetOrder: empty order in response")
+	changes := make([]entity.StatusChange, 0, len(res.GetChanges()))
+	for _, c := range res.GetChanges() {
+		changes = append(changes, entity.StatusChange{
+			From:      c.GetFrom().String(),
+			To:        c.GetTo().String(),
+			Reason:    c.GetReason(),
+			Actor:     c.GetActor(),
+			RequestID: c.GetRequestId(),
+			ChangedAt: formatTimestamp(c.GetChangedAt()),
+		})
 	}
+	return changes, nil
+}
 
-	return mapProtoOrderToEntity(po), nil
+// ListOrders devuelve una página de órdenes que cumplen el filtro.
+func (s *GRPCOrderService) ListOrders(ctx context.Context, filter entity.OrderFilter) (*entity.OrderPage, error) {
+	req := &orderv1.ListOrdersRequest{
+		CustomerId: filter.CustomerID,
+		PageSize:   int32(filter.PageSize),
+		PageToken:  filter.PageToken,
+	}
+	for _, st := range filter.Statuses {
+		req.Statuses = append(req.Statuses, orderv1.Status(orderv1.Status_value[st]))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		req.CreatedAfter = timestamppb.New(filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		req.CreatedBefore = timestamppb.New(filter.CreatedBefore)
+	}
+	if filter.OldestFirst {
+		req.Sort = orderv1.ListOrdersSort_OLDEST_FIRST
+	}
+
+	res, err := s.client.ListOrders(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("grpc ListOrders: %w", err)
+	}
+
+	page := &entity.OrderPage{
+		Orders:        make([]*entity.Order, 0, len(res.GetOrders())),
+		NextPageToken: res.GetNextPageToken(),
+	}
+	for _, po := range res.GetOrders() {
+		page.Orders = append(page.Orders, mapProtoOrderToEntity(po))
+	}
+	return page, nil
 }
 
 func mapProtoOrderToEntity(po *orderv1.OrderInfo) *entity.Order {
@@ -79,12 +107,42 @@ func mapProtoOrderToEntity(po *orderv1.OrderInfo) *entity.Order {
 		ID:         po.GetId(),
 		CustomerID: po.GetCustomerId(),
 		Status:     po.GetStatus().String(),
-		Total:      po.GetTotalAmount(),
-		Reason:     "",
+		Total:      protoMoney(po.GetTotalMoney(), po.GetTotalAmount()),
+		Reason:     po.GetReason(),
 		Items:      mapProtoItemsToEntity(po.GetItems()),
-		CreatedAt:  "",
-		UpdatedAt:  "",
+		CreatedAt:  formatTimestamp(po.GetCreatedAt()),
+		UpdatedAt:  formatTimestamp(po.GetUpdatedAt()),
+
+		FulfillmentPolicy: po.GetFulfillmentPolicy().String(),
+		Fulfillment:       mapProtoFulfillment(po.GetFulfillment()),
+		FulfilledTotal:    protoMoney(po.GetFulfilledTotalMoney(), po.GetTotalAmount()),
+	}
+}
+
+// mapProtoFulfillment devuelve COMPLETE, BACKORDERED o PARTIAL, o "" si la
+// reserva de stock aún no se ha registrado.
+func mapProtoFulfillment(f orderv1.Fulfillment) string {
+	if f == orderv1.Fulfillment_FULFILLMENT_UNSPECIFIED {
+		return ""
 	}
+	return strings.TrimPrefix(f.String(), "FULFILLMENT_")
+}
+
+// formatTimestamp renders a protobuf timestamp as RFC3339, or "" if unset.
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// protoMoney lee un campo Money y, si el servidor es antiguo y no lo envía,
+// usa el campo double obsoleto en la moneda por defecto.
+func protoMoney(m *moneyv1.Money, legacy float64) money.Money {
+	if m == nil {
+		return money.FromMajor(legacy, money.DefaultCurrency)
+	}
+	return money.New(m.GetMinorUnits(), m.GetCurrencyCode())
 }
 
 func mapProtoItemsToEntity(items []*orderv1.OrderItem) []entity.CreateOrderItem {
@@ -93,7 +151,10 @@ func mapProtoItemsToEntity(items []*orderv1.OrderItem) []entity.CreateOrderItem
 		out = append(out, entity.CreateOrderItem{
 			ProductID: it.GetProductId(),
 			Quantity:  int(it.GetQuantity()),
-			Price:     it.GetUnitPrice(),
+			Price:     protoMoney(it.GetUnitPriceMoney(), it.GetUnitPrice()),
+
+			Reserved:    int(it.GetReservedQuantity()),
+			Backordered: int(it.GetBackorderedQuantity()),
 		})
 	}
 	return out
@@ -0,0 +1,66 @@
+package httpx
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+// AdminHandler exposes operator endpoints for the compensation dead-letter table.
+type AdminHandler struct {
+	queue sagalog.CompensationQueue
+}
+
+// NewAdminHandler initializes the operator endpoints.
+func NewAdminHandler(queue sagalog.CompensationQueue) *AdminHandler {
+	return &AdminHandler{queue: queue}
+}
+
+// ListDeadLetters returns every compensation that exhausted its retries.
+func (h *AdminHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	dead, err := h.queue.ListDeadLetters(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "dead_letters_unavailable", err.Error())
+		return
+	}
+
+	out := make([]DeadLetterResponse, len(dead))
+	for i, c := range dead {
+		out[i] = DeadLetterResponse{
+			ID:        c.ID,
+			SagaID:    c.SagaID,
+			Step:      c.Step,
+			Attempts:  c.Attempts,
+			LastError: c.LastError,
+			CreatedAt: c.CreatedAt.Format(time.RFC3339),
+			DeadAt:    c.DeadAt.Format(time.RFC3339),
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// RedriveDeadLetter moves a dead-lettered compensation back into the retry queue.
+func (h *AdminHandler) RedriveDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", err.Error())
+		return
+	}
+
+	if err := h.queue.Redrive(r.Context(), id); err != nil {
+		if errors.Is(err, sagalog.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "dead_letter_not_found", err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "redrive_failed", err.Error())
+		return
+	}
+
+	slog.InfoContext(r.Context(), "dead-lettered compensation re-driven", "compensation_id", id)
+	w.WriteHeader(http.StatusAccepted)
+}
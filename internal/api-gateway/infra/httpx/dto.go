@@ -3,32 +3,148 @@ package httpx
 type CreateOrderRequest struct {
 	CustomerID string               `json:"customer_id"`
 	Items      []CreateOrderItemDTO `json:"items"`
+
+	// CurrencyCode is the ISO 4217 currency of the order. Optional: it
+	// defaults to the currency of the first item's unit_price, or USD.
+	CurrencyCode string `json:"currency_code,omitempty"`
+
+	// FulfillmentPolicy says what to do with items short of stock:
+	// ALL_OR_NOTHING (default), PARTIAL or ALLOW_BACKORDER.
+	FulfillmentPolicy string `json:"fulfillment_policy,omitempty"`
 }
 
 type CreateOrderItemDTO struct {
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ProductID string    `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice *MoneyDTO `json:"unit_price,omitempty"`
+
+	// Deprecated: Price is a float amount in the order's currency; send
+	// UnitPrice instead. It is only read when UnitPrice is absent.
+	Price float64 `json:"price,omitempty"`
+}
+
+// MoneyDTO is an exact amount: minor units (e.g. cents) of an ISO 4217 currency.
+type MoneyDTO struct {
+	MinorUnits   int64  `json:"minor_units"`
+	CurrencyCode string `json:"currency_code"`
 }
 
 type OrderResponse struct {
 	ID         string              `json:"id"`
 	CustomerID string              `json:"customer_id"`
 	Status     string              `json:"status"`
-	Total      float64             `json:"total"`
+	Total      float64             `json:"total"` // Deprecated: use TotalMoney.
+	TotalMoney MoneyDTO            `json:"total_money"`
 	Reason     string              `json:"reason,omitempty"`
 	Items      []OrderItemResponse `json:"items"`
 	CreatedAt  string              `json:"created_at"`
 	UpdatedAt  string              `json:"updated_at"`
+
+	FulfillmentPolicy string `json:"fulfillment_policy"`
+	// Fulfillment is COMPLETE, BACKORDERED or PARTIAL once the stock is
+	// reserved.
+	Fulfillment string `json:"fulfillment,omitempty"`
+	// FulfilledTotal is what the customer pays for the units that ship.
+	FulfilledTotal MoneyDTO `json:"fulfilled_total"`
+
+	History []StatusChangeResponse `json:"history,omitempty"`
+}
+
+type StatusChangeResponse struct {
+	From      string `json:"from,omitempty"`
+	To        string `json:"to"`
+	Reason    string `json:"reason,omitempty"`
+	Actor     string `json:"actor,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	ChangedAt string `json:"changed_at"`
 }
 
 type OrderItemResponse struct {
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ProductID string   `json:"product_id"`
+	Quantity  int      `json:"quantity"`
+	Price     float64  `json:"price"` // Deprecated: use UnitPrice.
+	UnitPrice MoneyDTO `json:"unit_price"`
+
+	// Units that ship now and once restocked; the rest of Quantity does
+	// not ship. Zero until the stock is reserved.
+	Reserved    int `json:"reserved"`
+	Backordered int `json:"backordered"`
+}
+
+type DeadLetterResponse struct {
+	ID        int64  `json:"id"`
+	SagaID    string `json:"saga_id"`
+	Step      string `json:"step"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
+	CreatedAt string `json:"created_at"`
+	DeadAt    string `json:"dead_at"`
 }
 
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message,omitempty"`
 }
+
+type SagaResponse struct {
+	SagaID      string                   `json:"saga_id"`
+	Status      string                   `json:"status"`
+	CurrentStep string                   `json:"current_step"`
+	Errors      []string                 `json:"errors"`
+	TraceID     string                   `json:"trace_id"`
+	StartedAt   string                   `json:"started_at"`
+	UpdatedAt   string                   `json:"updated_at"`
+	Transitions []SagaTransitionResponse `json:"transitions"`
+}
+
+type SagaTransitionResponse struct {
+	Status  string `json:"status"`
+	Step    string `json:"step,omitempty"`
+	TraceID string `json:"trace_id"`
+	At      string `json:"at"`
+}
+
+// OrderEvent is one Server-Sent Event on GET /orders/{id}/events. Type is
+// "saga" for saga transitions and "order" for order status changes.
+type OrderEvent struct {
+	Type    string   `json:"type"`
+	OrderID string   `json:"order_id"`
+	Status  string   `json:"status"`
+	Step    string   `json:"step,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+	At      string   `json:"at"`
+
+	terminal bool // the stream closes after this event
+}
+
+type ListOrdersResponse struct {
+	Orders        []OrderResponse `json:"orders"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+// RefundRequest is the body of POST /orders/{id}/refunds. A nil Amount
+// refunds everything not refunded yet.
+type RefundRequest struct {
+	Amount *MoneyDTO `json:"amount,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+type RefundResponse struct {
+	RefundID  string   `json:"refund_id,omitempty"`
+	Amount    MoneyDTO `json:"amount"`
+	Remaining MoneyDTO `json:"remaining"`
+}
+
+type ListRefundsResponse struct {
+	Captured MoneyDTO               `json:"captured"`
+	Refunded MoneyDTO               `json:"refunded"`
+	Refunds  []RefundRecordResponse `json:"refunds"`
+}
+
+type RefundRecordResponse struct {
+	RefundID  string   `json:"refund_id"`
+	Amount    MoneyDTO `json:"amount"`
+	Reason    string   `json:"reason,omitempty"`
+	CreatedAt string   `json:"created_at"`
+}
@@ -0,0 +1,154 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+const (
+	// eventBuffer is how many events a slow stream may fall behind before
+	// it starts missing them.
+	eventBuffer = 32
+
+	// heartbeatInterval keeps idle streams from being closed by proxies.
+	heartbeatInterval = 15 * time.Second
+
+	eventTypeSaga  = "saga"
+	eventTypeOrder = "order"
+)
+
+// StreamOrderEvents streams saga transitions and order status changes for
+// one order as Server-Sent Events. The stream opens with the order's current
+// status and saga state, and closes once the saga reaches a terminal state.
+func (h *Handler) StreamOrderEvents(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+	if orderID == "" {
+		writeError(w, http.StatusBadRequest, "order_id_required", "")
+		return
+	}
+
+	// Subscribe before reading the current state so that no transition
+	// can slip in between the snapshot and the live events.
+	events, cancel := h.events.Subscribe(orderID)
+	defer cancel()
+
+	order, err := h.orderService.GetOrder(r.Context(), orderID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "order_not_found", err.Error())
+		return
+	}
+
+	var latest *sagalog.SagaLog
+	if h.sagaLogRepo != nil {
+		latest, err = h.sagaLogRepo.GetLatest(r.Context(), orderID)
+		if err != nil && !errors.Is(err, sagalog.ErrNotFound) {
+			writeError(w, http.StatusInternalServerError, "saga_log_unavailable", err.Error())
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(e OrderEvent) bool {
+		if err := writeEvent(w, e); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	if !send(OrderEvent{
+		Type:    eventTypeOrder,
+		OrderID: order.ID,
+		Status:  order.Status,
+		Reason:  order.Reason,
+		At:      order.UpdatedAt,
+	}) {
+		return
+	}
+	if latest != nil {
+		snapshot := mapSagaLogToEvent(latest)
+		if !send(snapshot) || snapshot.terminal {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok || !send(e) || e.terminal {
+				return
+			}
+		}
+	}
+}
+
+// PublishSagaEvent is a coordinator.EventHandler that forwards saga
+// transitions to the order's event streams.
+func (h *Handler) PublishSagaEvent(_ context.Context, e coordinator.Event) {
+	h.events.Publish(e.SagaID, OrderEvent{
+		Type:     eventTypeSaga,
+		OrderID:  e.SagaID,
+		Status:   string(e.Status),
+		Step:     e.Step,
+		Errors:   e.Errors,
+		At:       e.At.Format(time.RFC3339Nano),
+		terminal: e.Terminal(),
+	})
+}
+
+// publishOrderStatus tells the order's event streams about a status change.
+func (h *Handler) publishOrderStatus(orderID, status, reason string) {
+	h.events.Publish(orderID, OrderEvent{
+		Type:    eventTypeOrder,
+		OrderID: orderID,
+		Status:  status,
+		Reason:  reason,
+		At:      time.Now().UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// mapSagaLogToEvent turns the latest saga log entry into a snapshot event.
+func mapSagaLogToEvent(entry *sagalog.SagaLog) OrderEvent {
+	return OrderEvent{
+		Type:     eventTypeSaga,
+		OrderID:  entry.SagaID,
+		Status:   string(entry.Status),
+		Step:     entry.CurrentStep,
+		Errors:   decodeErrors(entry.ErrorMessages),
+		At:       entry.UpdatedAt.Format(time.RFC3339Nano),
+		terminal: !entry.Status.IsInFlight(),
+	}
+}
+
+// writeEvent writes e in the SSE wire format, using its type as event name.
+func writeEvent(w http.ResponseWriter, e OrderEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		slog.Error("failed to encode order event", "order_id", e.OrderID, "error", err)
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
@@ -0,0 +1,158 @@
+package httpx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/domain/entity"
+	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/ports"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog/sqlite"
+)
+
+// fakeOrderService serves the orders and histories it holds. Without a
+// history, GetOrderHistory fails.
+type fakeOrderService struct {
+	ports.OrderService
+	orders  map[string]*entity.Order
+	history map[string][]entity.StatusChange
+}
+
+func (s *fakeOrderService) GetOrder(_ context.Context, id string) (*entity.Order, error) {
+	if o, ok := s.orders[id]; ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("order %s not found", id)
+}
+
+func (s *fakeOrderService) GetOrderHistory(_ context.Context, id string) ([]entity.StatusChange, error) {
+	if h, ok := s.history[id]; ok {
+		return h, nil
+	}
+	return nil, fmt.Errorf("history of order %s unavailable", id)
+}
+
+// readEvents returns a function that reads the next SSE event of body as
+// "type:status", or "" at the end of the stream.
+func readEvents(t *testing.T, res *http.Response) func() string {
+	t.Helper()
+	sc := bufio.NewScanner(res.Body)
+	return func() string {
+		for sc.Scan() {
+			data, ok := strings.CutPrefix(sc.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var e OrderEvent
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				t.Fatal(err)
+			}
+			return e.Type + ":" + e.Status
+		}
+		return ""
+	}
+}
+
+func TestStreamOrderEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		// logged are the saga transitions recorded before the stream opens.
+		logged []sagalog.Status
+		// published are the transitions published once it is open, as
+		// "saga ID:status".
+		published []string
+		want      []string
+	}{
+		{
+			name:   "finished saga closes after the snapshot",
+			logged: []sagalog.Status{sagalog.StatusStarted, sagalog.StatusFailed},
+			want:   []string{"order:CANCELLED", "saga:FAILED"},
+		},
+		{
+			name:      "running saga streams until it completes",
+			logged:    []sagalog.Status{sagalog.StatusStarted},
+			published: []string{"o1:STEP_DONE", "o2:FAILED", "o1:COMPLETED", "o1:STEP_DONE"},
+			want:      []string{"order:CANCELLED", "saga:STARTED", "saga:STEP_DONE", "saga:COMPLETED"},
+		},
+		{
+			name:      "timed out saga streams until it has failed",
+			published: []string{"o1:TIMED_OUT", "o1:COMPENSATING", "o1:FAILED"},
+			want:      []string{"order:CANCELLED", "saga:TIMED_OUT", "saga:COMPENSATING", "saga:FAILED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := sqlite.Open(filepath.Join(t.TempDir(), "saga.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer repo.Close()
+			for _, st := range tt.logged {
+				if err := repo.Save(context.Background(), sagalog.NewEntry(context.Background(), "o1", st, "", "", nil)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			orders := &fakeOrderService{orders: map[string]*entity.Order{"o1": {ID: "o1", Status: "CANCELLED"}}}
+			h := NewHandler(orders, nil, nil, nil, repo)
+			r := chi.NewRouter()
+			r.Get("/orders/{id}/events", h.StreamOrderEvents)
+			srv := httptest.NewServer(r)
+			defer srv.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/orders/o1/events", nil)
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+				t.Fatalf("Content-Type %q, want text/event-stream", ct)
+			}
+
+			next := readEvents(t, res)
+			// The order snapshot is sent after subscribing, so everything
+			// published from here on reaches the stream.
+			got := []string{next()}
+			for _, p := range tt.published {
+				sagaID, st, _ := strings.Cut(p, ":")
+				h.PublishSagaEvent(ctx, coordinator.Event{SagaID: sagaID, Status: sagalog.Status(st), At: time.Now()})
+			}
+			for e := next(); e != ""; e = next() {
+				got = append(got, e)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("events %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamOrderEvents_UnknownOrder(t *testing.T) {
+	h := NewHandler(&fakeOrderService{}, nil, nil, nil, nil)
+	r := chi.NewRouter()
+	r.Get("/orders/{id}/events", h.StreamOrderEvents)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/missing/events", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status %d, want 404", rec.Code)
+	}
+	if got := h.events.Publish("missing", OrderEvent{}); got != 0 {
+		t.Errorf("subscription kept after the request ended: delivered to %d", got)
+	}
+}
@@ -3,10 +3,17 @@ package httpx
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/domain/entity"
 	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/ports"
 	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator"
@@ -15,6 +22,8 @@ import (
 	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
 	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/pubsub"
 )
 
 // Handler handles incoming HTTP requests for the Order domain and coordinates Sagas.
@@ -24,16 +33,22 @@ type Handler struct {
 	paymentClient   paymentv1.PaymentClient
 	inventoryClient inventoryv1.InventoryClient
 	sagaLogRepo     sagalog.Repository // nil-safe: logging skipped if nil
+	sagaRegistry    *coordinator.Registry
+	sagaOpts        []coordinator.Option       // applied to every order saga
+	events          *pubsub.Broker[OrderEvent] // per-order event streams, keyed by order ID
+	running         sync.Map                   // IDs of the order sagas started by this handler and not yet finished
 }
 
 // NewHandler initializes the handler with its required domain services and gRPC clients.
 // sagaRepo may be nil — in that case saga state transitions are not persisted to the log.
+// sagaOpts are passed to every orchestrator the handler starts (e.g. a compensation queue).
 func NewHandler(
 	os ports.OrderService,
 	oc orderv1.OrderClient,
 	pc paymentv1.PaymentClient,
 	ic inventoryv1.InventoryClient,
 	sagaRepo sagalog.Repository,
+	sagaOpts ...coordinator.Option,
 ) *Handler {
 	return &Handler{
 		orderService:    os,
@@ -41,10 +56,14 @@ func NewHandler(
 		paymentClient:   pc,
 		inventoryClient: ic,
 		sagaLogRepo:     sagaRepo,
+		sagaRegistry:    coordinator.NewOrderSagaRegistry(oc, pc, ic),
+		sagaOpts:        sagaOpts,
+		events:          pubsub.NewBroker[OrderEvent](eventBuffer),
 	}
 }
 
-// CreateOrder receives the request, persists a PENDING order, and triggers the Saga.
+// CreateOrder receives the request and starts the order saga, whose first
+// step persists the PENDING order. It answers once that order exists.
 func (h *Handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var req CreateOrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -57,16 +76,47 @@ func (h *Handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items := make([]entity.CreateOrderItem, 0, len(req.Items))
+	currency := orderCurrency(req)
+	if err := money.ValidateCurrency(currency); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_currency", err.Error())
+		return
+	}
+
+	policy := req.FulfillmentPolicy
+	if policy == "" {
+		policy = orderv1.FulfillmentPolicy_ALL_OR_NOTHING.String()
+	}
+	if _, ok := orderv1.FulfillmentPolicy_value[policy]; !ok {
+		writeError(w, http.StatusBadRequest, "invalid_fulfillment_policy",
+			"fulfillment_policy must be ALL_OR_NOTHING, PARTIAL or ALLOW_BACKORDER")
+		return
+	}
+
+	total := money.Zero(currency)
+	items := make([]coordinator.PayloadItem, 0, len(req.Items))
 	for _, it := range req.Items {
-		if it.ProductID == "" || it.Quantity <= 0 || it.Price <= 0 {
+		price := mapItemPrice(it, currency)
+		if it.ProductID == "" || it.Quantity <= 0 || !price.IsPositive() || price.Validate() != nil {
 			writeError(w, http.StatusBadRequest, "invalid_item", "product_id, quantity, and price must be valid")
 			return
 		}
-		items = append(items, entity.CreateOrderItem{
+		if price.CurrencyCode != currency {
+			writeError(w, http.StatusBadRequest, "currency_mismatch",
+				"item "+it.ProductID+" is priced in "+price.CurrencyCode+", order is in "+currency)
+			return
+		}
+		subtotal, err := price.Mul(int64(it.Quantity))
+		if err == nil {
+			total, err = total.Add(subtotal)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_item", err.Error())
+			return
+		}
+		items = append(items, coordinator.PayloadItem{
 			ProductID: it.ProductID,
-			Quantity:  it.Quantity,
-			Price:     it.Price,
+			Quantity:  int32(it.Quantity),
+			UnitPrice: price,
 		})
 	}
 
@@ -74,22 +124,110 @@ func (h *Handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	idempKey, _ := r.Context().Value(constants.ContextKeyIdempotencyKey).(string)
 	requestID, _ := r.Context().Value(constants.ContextKeyRequestID).(string)
 
-	slog.InfoContext(r.Context(), "creating order", "request_id", requestID, "customer_id", req.CustomerID)
+	orderID := newOrderID(req.CustomerID, idempKey)
+	payload := &coordinator.Payload{
+		OrderID:    orderID,
+		CustomerID: req.CustomerID,
+		Items:      items,
+		Total:      total,
+		Steps:      orderSagaSteps,
+		DependsOn:  orderSagaDependencies,
 
-	order, err := h.orderService.CreateOrder(r.Context(), req.CustomerID, idempKey, items)
-	if err != nil {
-		writeError(w, http.StatusBadGateway, "order_service_error", err.Error())
+		FulfillmentPolicy: policy,
+		IdempotencyKey:    idempKey,
+	}
+	if payload.IdempotencyKey == "" {
+		// The order ID is unique to this request and the same for every
+		// attempt of the saga's create step, which is all a key needs.
+		payload.IdempotencyKey = orderID
+	}
+
+	// A retried request maps to the order ID of the first one: answer with
+	// that order instead of running its saga a second time. The ID is
+	// claimed before the order is looked up, so a retry that races the
+	// first attempt cannot start a second saga.
+	if !h.claimOrder(r.Context(), orderID) {
+		if order, err := h.orderService.GetOrder(r.Context(), orderID); err == nil {
+			writeJSON(w, http.StatusCreated, mapOrderToResponse(order))
+			return
+		}
+		writeError(w, http.StatusConflict, "order_in_progress",
+			"a request with the same idempotency key is still being processed")
 		return
 	}
+	if idempKey != "" {
+		if order, err := h.orderService.GetOrder(r.Context(), orderID); err == nil {
+			h.running.Delete(orderID)
+			writeJSON(w, http.StatusCreated, mapOrderToResponse(order))
+			return
+		}
+	}
+
+	slog.InfoContext(r.Context(), "creating order", "request_id", requestID, "customer_id", req.CustomerID, "order_id", orderID)
 
 	// Detach from the HTTP request context so the saga is not cancelled when
 	// the HTTP response is sent, while still propagating tracing metadata.
 	sagaCtx := context.WithoutCancel(r.Context())
-	go h.runOrderSaga(sagaCtx, order)
+	created, err := h.startOrderSaga(sagaCtx, payload)
+	if err != nil {
+		h.running.Delete(orderID)
+		writeError(w, http.StatusInternalServerError, "saga_error", err.Error())
+		return
+	}
 
+	var res orderCreation
+	select {
+	case res = <-created:
+	case <-r.Context().Done():
+		// The client is gone; the saga carries on without it.
+		return
+	}
+	if res.err != nil {
+		writeError(w, http.StatusBadGateway, "order_service_error", res.err.Error())
+		return
+	}
+
+	order, err := h.orderService.GetOrder(r.Context(), res.orderID)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "order_service_error", err.Error())
+		return
+	}
 	writeJSON(w, http.StatusCreated, mapOrderToResponse(order))
 }
 
+// orderIDNamespace scopes the order IDs derived from idempotency keys.
+var orderIDNamespace = uuid.MustParse("5b0f3c1e-8d4a-4e6b-9f27-1c2d3e4f5a6b")
+
+// newOrderID returns the ID of the order a request creates. Requests of a
+// customer that share an idempotency key share the ID, so a retry finds the
+// order (and the saga) of the first attempt; the same key sent by another
+// customer names another order.
+func newOrderID(customerID, idempotencyKey string) string {
+	if idempotencyKey == "" {
+		return uuid.NewString()
+	}
+	customer := uuid.NewSHA1(orderIDNamespace, []byte(customerID))
+	return uuid.NewSHA1(customer, []byte(idempotencyKey)).String()
+}
+
+// claimOrder reserves orderID for a new saga. It fails while a saga with
+// that ID is running, whether started by this handler or resumed by the
+// Recoverer after a restart. startOrderSaga releases the claim once the
+// saga ends.
+func (h *Handler) claimOrder(ctx context.Context, orderID string) bool {
+	if _, taken := h.running.LoadOrStore(orderID, struct{}{}); taken {
+		return false
+	}
+	if h.sagaLogRepo == nil {
+		return true
+	}
+	if latest, err := h.sagaLogRepo.GetLatest(ctx, orderID); err == nil && latest.Status.IsInFlight() {
+		h.running.Delete(orderID)
+		return false
+	}
+	return true
+}
+
 // GetOrderByID retrieves a single order status by its ID.
 func (h *Handler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "id")
@@ -104,46 +242,165 @@ func (h *Handler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, mapOrderToResponse(order))
+	resp := mapOrderToResponse(order)
+
+	// The history is supplementary: a failure to load it must not hide
+	// the order itself.
+	history, err := h.orderService.GetOrderHistory(r.Context(), orderID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "failed to load order history", "order_id", orderID, "error", err)
+	} else {
+		resp.History = mapHistory(history)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// orderSagaSteps lists the steps of the order saga in declaration order.
+var orderSagaSteps = []string{
+	coordinator.CreateOrderStepName,
+	coordinator.InventoryStepName,
+	coordinator.PaymentAuthorizeStepName,
+	coordinator.ConfirmOrderStepName,
+	coordinator.PaymentCaptureStepName,
+	coordinator.InventoryCommitStepName,
+}
+
+// orderSagaDependencies wires the order saga graph: the order is created
+// first; stock reservation and payment authorization are independent and
+// run in parallel; the order is confirmed once both have succeeded, and only
+// then is the payment captured. The reservation is committed last, so that
+// it expires if the saga stops before the money is taken.
+var orderSagaDependencies = map[string][]string{
+	coordinator.InventoryStepName:        {coordinator.CreateOrderStepName},
+	coordinator.PaymentAuthorizeStepName: {coordinator.CreateOrderStepName},
+	coordinator.ConfirmOrderStepName:     {coordinator.InventoryStepName, coordinator.PaymentAuthorizeStepName},
+	coordinator.PaymentCaptureStepName:   {coordinator.ConfirmOrderStepName},
+	coordinator.InventoryCommitStepName:  {coordinator.PaymentCaptureStepName},
 }
 
-// runOrderSaga manages the distributed transaction across multiple microservices.
-func (h *Handler) runOrderSaga(ctx context.Context, order *entity.Order) {
-	steps := []coordinator.Step{
-		coordinator.NewInventoryStep(h.inventoryClient, order.ID, mapToProtoItems(order.Items)),
-		coordinator.NewPaymentStep(h.paymentClient, order.ID, order.Total),
-		coordinator.NewConfirmOrderStep(h.orderGrpcClient, order.ID),
+// orderCreation is the outcome of the first step of an order saga: the ID
+// of the order it stored, or why the saga stopped before storing one.
+type orderCreation struct {
+	orderID string
+	err     error
+}
+
+// startOrderSaga runs the order saga in the background. The returned
+// channel receives once, as soon as the saga has created the order or has
+// failed without doing so. The claim on the order ID is released when the
+// saga ends.
+func (h *Handler) startOrderSaga(ctx context.Context, payload *coordinator.Payload) (<-chan orderCreation, error) {
+	// The payload is stored on the STARTED log row so the saga can be
+	// rebuilt from the registry if the gateway restarts mid-flight.
+	nodes, err := h.sagaRegistry.BuildNodes(payload)
+	if err != nil {
+		return nil, fmt.Errorf("build order saga: %w", err)
 	}
 
+	data := coordinator.NewData()
+	created := make(chan orderCreation, 1)
+	var once sync.Once
+	report := func(c orderCreation) { once.Do(func() { created <- c }) }
+
 	// The order ID is used as the saga ID so the log can be joined with
-	// business data and correlated with the OTel trace.
-	saga := coordinator.NewOrchestrator(order.ID, steps, h.sagaLogRepo)
-
-	if err := saga.Start(ctx); err != nil {
-		slog.ErrorContext(ctx, "saga failed, cancelling order", "order_id", order.ID, "error", err)
-		if _, updateErr := h.orderGrpcClient.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
-			Id:     order.ID,
-			Status: orderv1.Status_CANCELLED,
-		}); updateErr != nil {
-			slog.ErrorContext(ctx, "CRITICAL: failed to cancel order after saga failure",
-				"order_id", order.ID,
-				"saga_error", err,
-				"cancel_error", updateErr,
-			)
+	// business data and correlated with the OTel trace. The event handler
+	// replaces the one of SagaHooks to also watch for the order creation.
+	opts := append([]coordinator.Option{coordinator.WithPayload(payload), coordinator.WithData(data)}, h.SagaHooks()...)
+	opts = append(opts, h.sagaOpts...)
+	opts = append(opts, coordinator.WithEventHandler(func(ctx context.Context, e coordinator.Event) {
+		h.PublishSagaEvent(ctx, e)
+		switch e.Status {
+		case sagalog.StatusStepDone:
+			if e.Step == coordinator.CreateOrderStepName {
+				id, _ := coordinator.OrderIDKey.Get(data)
+				report(orderCreation{orderID: id})
+			}
+		case sagalog.StatusTimedOut, sagalog.StatusCompensating, sagalog.StatusFailed:
+			report(orderCreation{err: fmt.Errorf("order saga failed: %s", strings.Join(e.Errors, "; "))})
 		}
+	}))
+	saga, err := coordinator.NewGraphOrchestrator(payload.OrderID, nodes, h.sagaLogRepo, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order saga graph: %w", err)
 	}
-}
 
-// mapToProtoItems converts domain items to Inventory Protobuf items.
-func mapToProtoItems(items []entity.CreateOrderItem) []*inventoryv1.StockItem {
-	protoItems := make([]*inventoryv1.StockItem, len(items))
-	for i, it := range items {
-		protoItems[i] = &inventoryv1.StockItem{
-			ProductId: it.ProductID,
-			Quantity:  int32(it.Quantity),
+	go func() {
+		defer h.running.Delete(payload.OrderID)
+		err := saga.Start(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "saga failed", "order_id", payload.OrderID, "error", err)
 		}
+		// Only reached without a report if the saga stopped before its
+		// first transition; the request must not wait for it forever.
+		report(orderCreation{err: fmt.Errorf("order saga stopped before creating the order: %v", err)})
+	}()
+	return created, nil
+}
+
+// SagaHooks returns the orchestrator options that tie an order saga back to
+// this handler: order cancellation on failure and event streaming. Sagas
+// started elsewhere (e.g. by the Recoverer) must use them too.
+func (h *Handler) SagaHooks() []coordinator.Option {
+	return []coordinator.Option{
+		coordinator.WithFailureHandler(h.CancelOrder),
+		coordinator.WithCompletionHandler(h.OrderConfirmed),
+		coordinator.WithEventHandler(h.PublishSagaEvent),
 	}
-	return protoItems
+}
+
+// CancelOrder is a coordinator.FailureHandler: once a saga has been
+// compensated it marks the order (whose ID is the saga ID) as CANCELLED.
+func (h *Handler) CancelOrder(ctx context.Context, orderID string, sagaErr error) {
+	slog.ErrorContext(ctx, "saga failed, cancelling order", "order_id", orderID, "error", sagaErr)
+	_, err := h.orderGrpcClient.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
+		Id:     orderID,
+		Status: orderv1.Status_CANCELLED,
+		Reason: sagaErr.Error(),
+		Actor:  coordinator.OrderActor,
+	})
+	if status.Code(err) == codes.NotFound {
+		// The saga failed at its first step: there is no order to cancel.
+		slog.WarnContext(ctx, "saga failed before creating the order", "order_id", orderID)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "CRITICAL: failed to cancel order after saga failure",
+			"order_id", orderID,
+			"saga_error", sagaErr,
+			"cancel_error", err,
+		)
+		return
+	}
+	h.publishOrderStatus(orderID, orderv1.Status_CANCELLED.String(), sagaErr.Error())
+}
+
+// OrderConfirmed is a coordinator.CompletionHandler: a completed order saga
+// has confirmed the order and captured its payment, so subscribers are told
+// about it here.
+func (h *Handler) OrderConfirmed(_ context.Context, orderID string) {
+	h.publishOrderStatus(orderID, orderv1.Status_CONFIRMED.String(), "")
+}
+
+// orderCurrency returns the requested currency_code or, if absent, the
+// currency of the first item's unit_price, or money.DefaultCurrency.
+func orderCurrency(req CreateOrderRequest) string {
+	if req.CurrencyCode != "" {
+		return strings.ToUpper(req.CurrencyCode)
+	}
+	if len(req.Items) > 0 && req.Items[0].UnitPrice != nil {
+		return strings.ToUpper(req.Items[0].UnitPrice.CurrencyCode)
+	}
+	return money.DefaultCurrency
+}
+
+// mapItemPrice reads the item's exact unit_price, falling back to the
+// deprecated float price in the order's currency.
+func mapItemPrice(it CreateOrderItemDTO, currency string) money.Money {
+	if it.UnitPrice != nil {
+		return money.New(it.UnitPrice.MinorUnits, it.UnitPrice.CurrencyCode)
+	}
+	return money.FromMajor(it.Price, currency)
 }
 
 // mapOrderToResponse converts the internal order entity to the HTTP response format.
@@ -152,11 +409,16 @@ func mapOrderToResponse(order *entity.Order) OrderResponse {
 		ID:         order.ID,
 		CustomerID: order.CustomerID,
 		Status:     order.Status,
-		Total:      order.Total,
+		Total:      order.Total.Major(),
+		TotalMoney: mapMoney(order.Total),
 		Reason:     order.Reason,
 		Items:      mapItems(order.Items),
 		CreatedAt:  order.CreatedAt,
 		UpdatedAt:  order.UpdatedAt,
+
+		FulfillmentPolicy: order.FulfillmentPolicy,
+		Fulfillment:       order.Fulfillment,
+		FulfilledTotal:    mapMoney(order.FulfilledTotal),
 	}
 }
 
@@ -166,7 +428,30 @@ func mapItems(items []entity.CreateOrderItem) []OrderItemResponse {
 		out[i] = OrderItemResponse{
 			ProductID: it.ProductID,
 			Quantity:  it.Quantity,
-			Price:     it.Price,
+			Price:     it.Price.Major(),
+			UnitPrice: mapMoney(it.Price),
+
+			Reserved:    it.Reserved,
+			Backordered: it.Backordered,
+		}
+	}
+	return out
+}
+
+func mapMoney(m money.Money) MoneyDTO {
+	return MoneyDTO{MinorUnits: m.MinorUnits, CurrencyCode: m.CurrencyCode}
+}
+
+func mapHistory(changes []entity.StatusChange) []StatusChangeResponse {
+	out := make([]StatusChangeResponse, len(changes))
+	for i, c := range changes {
+		out[i] = StatusChangeResponse{
+			From:      c.From,
+			To:        c.To,
+			Reason:    c.Reason,
+			Actor:     c.Actor,
+			RequestID: c.RequestID,
+			ChangedAt: c.ChangedAt,
 		}
 	}
 	return out
@@ -0,0 +1,155 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/domain/entity"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog/sqlite"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+)
+
+func TestNewOrderID(t *testing.T) {
+	if a, b := newOrderID("c1", "key-1"), newOrderID("c1", "key-1"); a != b {
+		t.Errorf("retry of the same request got order %s, then %s", a, b)
+	}
+	if a, b := newOrderID("c1", "key-1"), newOrderID("c2", "key-1"); a == b {
+		t.Errorf("customers c1 and c2 sharing a key both got order %s", a)
+	}
+	if a, b := newOrderID("c1", ""), newOrderID("c1", ""); a == b {
+		t.Errorf("requests without a key both got order %s", a)
+	}
+}
+
+func TestClaimOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		// logged is the saga log of o1 before the claim.
+		logged []sagalog.Status
+		want   bool
+	}{
+		{"new order", nil, true},
+		{"saga running after a restart", []sagalog.Status{sagalog.StatusStarted, sagalog.StatusStepDone}, false},
+		{"finished saga", []sagalog.Status{sagalog.StatusStarted, sagalog.StatusFailed}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := sqlite.Open(filepath.Join(t.TempDir(), "saga.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer repo.Close()
+			for _, st := range tt.logged {
+				if err := repo.Save(context.Background(), sagalog.NewEntry(context.Background(), "o1", st, "", "", nil)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			h := NewHandler(&fakeOrderService{}, nil, nil, nil, repo)
+			if got := h.claimOrder(context.Background(), "o1"); got != tt.want {
+				t.Fatalf("claimOrder = %v, want %v", got, tt.want)
+			}
+			if tt.want && h.claimOrder(context.Background(), "o1") {
+				t.Error("claimed twice while the first saga runs")
+			}
+			h.running.Delete("o1")
+			if got := h.claimOrder(context.Background(), "o1"); got != tt.want {
+				t.Errorf("claimOrder after release = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateOrder_RetryWhileTheFirstAttemptRuns(t *testing.T) {
+	const body = `{"customer_id":"c1","items":[{"product_id":"p1","quantity":1,"unit_price":{"minor_units":1000,"currency_code":"USD"}}]}`
+	orderID := newOrderID("c1", "key-1")
+
+	tests := []struct {
+		name     string
+		orders   map[string]*entity.Order
+		wantCode int
+	}{
+		{"order not stored yet", nil, http.StatusConflict},
+		{"order stored", map[string]*entity.Order{orderID: {ID: orderID, Status: "PENDING"}}, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&fakeOrderService{orders: tt.orders}, nil, nil, nil, nil)
+			// The first attempt holds the claim until its saga ends.
+			if !h.claimOrder(context.Background(), orderID) {
+				t.Fatal("first attempt could not claim the order")
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), constants.ContextKeyIdempotencyKey, "key-1"))
+			rec := httptest.NewRecorder()
+			h.CreateOrder(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("retry answered %d %s, want %d", rec.Code, rec.Body, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetOrderByID(t *testing.T) {
+	history := []entity.StatusChange{
+		{To: "PENDING", Reason: "order created", Actor: "c1", RequestID: "req-1", ChangedAt: "2026-01-02T03:04:05Z"},
+		{From: "PENDING", To: "CANCELLED", Reason: "payment declined", Actor: "saga-orchestrator", ChangedAt: "2026-01-02T03:04:06Z"},
+	}
+	tests := []struct {
+		name        string
+		history     map[string][]entity.StatusChange
+		id          string
+		wantCode    int
+		wantHistory []StatusChangeResponse
+	}{
+		{
+			name:     "order with its history",
+			history:  map[string][]entity.StatusChange{"o1": history},
+			id:       "o1",
+			wantCode: http.StatusOK,
+			wantHistory: []StatusChangeResponse{
+				{To: "PENDING", Reason: "order created", Actor: "c1", RequestID: "req-1", ChangedAt: "2026-01-02T03:04:05Z"},
+				{From: "PENDING", To: "CANCELLED", Reason: "payment declined", Actor: "saga-orchestrator", ChangedAt: "2026-01-02T03:04:06Z"},
+			},
+		},
+		{name: "history unavailable", id: "o1", wantCode: http.StatusOK},
+		{name: "unknown order", id: "missing", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := &fakeOrderService{
+				orders:  map[string]*entity.Order{"o1": {ID: "o1", Status: "CANCELLED"}},
+				history: tt.history,
+			}
+			r := chi.NewRouter()
+			r.Get("/orders/{id}", NewHandler(orders, nil, nil, nil, nil).GetOrderByID)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/"+tt.id, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status %d %s, want %d", rec.Code, rec.Body, tt.wantCode)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var res OrderResponse
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if res.ID != "o1" || !slices.Equal(res.History, tt.wantHistory) {
+				t.Errorf("order %s with history %+v, want o1 with %+v", res.ID, res.History, tt.wantHistory)
+			}
+		})
+	}
+}
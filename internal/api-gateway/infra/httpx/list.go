@@ -0,0 +1,107 @@
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/domain/entity"
+	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
+)
+
+// ListOrders returns a page of orders.
+//
+// Query parameters (all optional):
+//   - customer_id
+//   - status: repeatable or comma-separated, e.g. status=PENDING,CANCELLED
+//   - created_after, created_before: RFC3339 timestamps
+//   - sort: "newest" (default) or "oldest"
+//   - page_size: 1-100, default 20
+//   - page_token: next_page_token from the previous page
+func (h *Handler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseOrderFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+
+	page, err := h.orderService.ListOrders(r.Context(), filter)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			writeError(w, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+		writeError(w, http.StatusBadGateway, "order_service_error", err.Error())
+		return
+	}
+
+	resp := ListOrdersResponse{
+		Orders:        make([]OrderResponse, len(page.Orders)),
+		NextPageToken: page.NextPageToken,
+	}
+	for i, o := range page.Orders {
+		resp.Orders[i] = mapOrderToResponse(o)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseOrderFilter validates the ListOrders query parameters.
+func parseOrderFilter(q url.Values) (entity.OrderFilter, error) {
+	filter := entity.OrderFilter{
+		CustomerID: q.Get("customer_id"),
+		PageToken:  q.Get("page_token"),
+	}
+
+	for _, raw := range q["status"] {
+		for _, st := range strings.Split(raw, ",") {
+			st = strings.ToUpper(strings.TrimSpace(st))
+			if _, ok := orderv1.Status_value[st]; !ok {
+				return filter, fmt.Errorf("unknown status %q", st)
+			}
+			filter.Statuses = append(filter.Statuses, st)
+		}
+	}
+
+	var err error
+	if filter.CreatedAfter, err = parseTimeParam(q, "created_after"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = parseTimeParam(q, "created_before"); err != nil {
+		return filter, err
+	}
+
+	switch q.Get("sort") {
+	case "", "newest":
+	case "oldest":
+		filter.OldestFirst = true
+	default:
+		return filter, fmt.Errorf("sort must be \"newest\" or \"oldest\"")
+	}
+
+	if raw := q.Get("page_size"); raw != "" {
+		filter.PageSize, err = strconv.Atoi(raw)
+		if err != nil || filter.PageSize < 1 {
+			return filter, fmt.Errorf("page_size must be a positive integer")
+		}
+	}
+	return filter, nil
+}
+
+// parseTimeParam parses an optional RFC3339 query parameter.
+func parseTimeParam(q url.Values, key string) (time.Time, error) {
+	raw := q.Get(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp", key)
+	}
+	return t, nil
+}
@@ -0,0 +1,68 @@
+package httpx
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/api-gateway/core/domain/entity"
+)
+
+func TestParseOrderFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    entity.OrderFilter
+		wantErr string
+	}{
+		{name: "no parameters", query: ""},
+		{
+			name:  "every parameter",
+			query: "customer_id=c1&status=pending,Cancelled&status=CONFIRMED&created_after=2026-01-02T03:04:05Z&created_before=2026-01-03T00:00:00%2B01:00&sort=oldest&page_size=5&page_token=tok",
+			want: entity.OrderFilter{
+				CustomerID:    "c1",
+				Statuses:      []string{"PENDING", "CANCELLED", "CONFIRMED"},
+				CreatedAfter:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				CreatedBefore: time.Date(2026, 1, 2, 23, 0, 0, 0, time.UTC),
+				OldestFirst:   true,
+				PageSize:      5,
+				PageToken:     "tok",
+			},
+		},
+		{name: "newest first", query: "sort=newest"},
+		{name: "unknown status", query: "status=PENDING,LOST", wantErr: `unknown status "LOST"`},
+		{name: "empty status", query: "status=PENDING,", wantErr: `unknown status ""`},
+		{name: "created_after not RFC3339", query: "created_after=2026-01-02", wantErr: "created_after must be an RFC3339 timestamp"},
+		{name: "created_before not RFC3339", query: "created_before=yesterday", wantErr: "created_before must be an RFC3339 timestamp"},
+		{name: "unknown sort", query: "sort=cheapest", wantErr: "sort must be"},
+		{name: "page_size zero", query: "page_size=0", wantErr: "page_size must be a positive integer"},
+		{name: "page_size not a number", query: "page_size=ten", wantErr: "page_size must be a positive integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseOrderFilter(q)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.CreatedAfter.Equal(tt.want.CreatedAfter) || !got.CreatedBefore.Equal(tt.want.CreatedBefore) {
+				t.Errorf("created range %v - %v, want %v - %v", got.CreatedAfter, got.CreatedBefore, tt.want.CreatedAfter, tt.want.CreatedBefore)
+			}
+			got.CreatedAfter, got.CreatedBefore = tt.want.CreatedAfter, tt.want.CreatedBefore
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseOrderFilter\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	moneyv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/money/v1"
+	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// RefundOrder refunds all or part of an order's captured payment, e.g. for
+// a customer return. Without an amount everything left is refunded. Partial
+// refunds require an X-Idempotency-Key so that retries do not refund twice.
+func (h *Handler) RefundOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+	if orderID == "" {
+		writeError(w, http.StatusBadRequest, "order_id_required", "")
+		return
+	}
+
+	var req RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_json", err.Error())
+		return
+	}
+
+	idempKey, _ := r.Context().Value(constants.ContextKeyIdempotencyKey).(string)
+	grpcReq := &paymentv1.RefundRequest{
+		OrderId:        orderID,
+		Reason:         req.Reason,
+		IdempotencyKey: idempKey,
+	}
+	if req.Amount != nil {
+		amount := money.New(req.Amount.MinorUnits, req.Amount.CurrencyCode)
+		if err := amount.Validate(); err != nil || !amount.IsPositive() {
+			writeError(w, http.StatusBadRequest, "invalid_amount", "amount must be positive with a valid currency_code")
+			return
+		}
+		if idempKey == "" {
+			writeError(w, http.StatusBadRequest, "idempotency_key_required",
+				"partial refunds require an "+constants.HeaderXIdempotencyKey+" header")
+			return
+		}
+		grpcReq.Amount = amount.ToProto()
+		grpcReq.CurrencyCode = amount.CurrencyCode
+	}
+
+	res, err := h.paymentClient.Refund(r.Context(), grpcReq)
+	if err != nil {
+		slog.WarnContext(r.Context(), "refund failed", "order_id", orderID, "error", err)
+		writePaymentError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RefundResponse{
+		RefundID:  res.GetRefundId(),
+		Amount:    mapProtoMoney(res.GetAmount()),
+		Remaining: mapProtoMoney(res.GetRemaining()),
+	})
+}
+
+// ListOrderRefunds returns every refund issued against an order's payment.
+func (h *Handler) ListOrderRefunds(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+	if orderID == "" {
+		writeError(w, http.StatusBadRequest, "order_id_required", "")
+		return
+	}
+
+	res, err := h.paymentClient.ListRefunds(r.Context(), &paymentv1.ListRefundsRequest{OrderId: orderID})
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	resp := ListRefundsResponse{
+		Captured: mapProtoMoney(res.GetCaptured()),
+		Refunded: mapProtoMoney(res.GetRefunded()),
+		Refunds:  make([]RefundRecordResponse, len(res.GetRefunds())),
+	}
+	for i, rf := range res.GetRefunds() {
+		resp.Refunds[i] = RefundRecordResponse{
+			RefundID:  rf.GetRefundId(),
+			Amount:    mapProtoMoney(rf.GetAmount()),
+			Reason:    rf.GetReason(),
+			CreatedAt: rf.GetCreatedAt().AsTime().Format(time.RFC3339Nano),
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writePaymentError maps a payment service error to an HTTP response.
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.NotFound:
+		writeError(w, http.StatusNotFound, "payment_not_found", status.Convert(err).Message())
+	case codes.InvalidArgument:
+		writeError(w, http.StatusBadRequest, "invalid_refund", status.Convert(err).Message())
+	case codes.FailedPrecondition, codes.Aborted:
+		writeError(w, http.StatusConflict, "refund_not_allowed", status.Convert(err).Message())
+	default:
+		writeError(w, http.StatusBadGateway, "payment_service_error", err.Error())
+	}
+}
+
+func mapProtoMoney(p *moneyv1.Money) MoneyDTO {
+	return MoneyDTO{MinorUnits: p.GetMinorUnits(), CurrencyCode: p.GetCurrencyCode()}
+}
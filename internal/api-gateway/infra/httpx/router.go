@@ -24,7 +24,9 @@ import (
 //  3. middlewares.AttachTracingMetadata — copies our custom x-request-id and
 //     x-idempotency-key into the context AND into the outgoing gRPC metadata,
 //     so they travel alongside the W3C trace headers to every microservice.
-func NewRouter(handler *Handler) http.Handler {
+//
+// admin may be nil, in which case the /admin routes are not mounted.
+func NewRouter(handler *Handler, admin *AdminHandler) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -33,7 +35,17 @@ func NewRouter(handler *Handler) http.Handler {
 	r.Use(middleware.Recoverer)
 
 	r.Post("/orders", handler.CreateOrder)
+	r.Get("/orders", handler.ListOrders)
 	r.Get("/orders/{id}", handler.GetOrderByID)
+	r.Get("/orders/{id}/saga", handler.GetOrderSaga)
+	r.Get("/orders/{id}/events", handler.StreamOrderEvents)
+	r.Post("/orders/{id}/refunds", handler.RefundOrder)
+	r.Get("/orders/{id}/refunds", handler.ListOrderRefunds)
+
+	if admin != nil {
+		r.Get("/admin/compensations/dead-letters", admin.ListDeadLetters)
+		r.Post("/admin/compensations/dead-letters/{id}/redrive", admin.RedriveDeadLetter)
+	}
 
 	// Wrap the whole mux with otelhttp so every route gets a root span.
 	// The span name is set to the matched route pattern (e.g. "POST /orders").
@@ -0,0 +1,84 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+// GetOrderSaga returns the progress of the saga driving an order, read from
+// the saga log. The order ID is the saga ID.
+func (h *Handler) GetOrderSaga(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+	if orderID == "" {
+		writeError(w, http.StatusBadRequest, "order_id_required", "")
+		return
+	}
+
+	if h.sagaLogRepo == nil {
+		writeError(w, http.StatusServiceUnavailable, "saga_log_disabled", "saga log is not configured")
+		return
+	}
+
+	history, err := h.sagaLogRepo.History(r.Context(), orderID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "saga_log_unavailable", err.Error())
+		return
+	}
+	if len(history) == 0 {
+		writeError(w, http.StatusNotFound, "saga_not_found", "no saga recorded for order "+orderID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapHistoryToSagaResponse(history))
+}
+
+// mapHistoryToSagaResponse folds a saga's log entries (oldest first) into
+// its current state plus the list of transitions.
+func mapHistoryToSagaResponse(history []*sagalog.SagaLog) SagaResponse {
+	latest := history[len(history)-1]
+	resp := SagaResponse{
+		SagaID:      latest.SagaID,
+		Status:      string(latest.Status),
+		CurrentStep: latest.CurrentStep,
+		Errors:      []string{},
+		TraceID:     latest.TraceID,
+		StartedAt:   history[0].UpdatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   latest.UpdatedAt.Format(time.RFC3339Nano),
+		Transitions: make([]SagaTransitionResponse, len(history)),
+	}
+
+	for i, entry := range history {
+		resp.Transitions[i] = SagaTransitionResponse{
+			Status:  string(entry.Status),
+			Step:    entry.CurrentStep,
+			TraceID: entry.TraceID,
+			At:      entry.UpdatedAt.Format(time.RFC3339Nano),
+		}
+
+		// Entries carry the errors accumulated so far, so the same message
+		// shows up on several rows.
+		for _, msg := range decodeErrors(entry.ErrorMessages) {
+			if !slices.Contains(resp.Errors, msg) {
+				resp.Errors = append(resp.Errors, msg)
+			}
+		}
+	}
+	return resp
+}
+
+// decodeErrors parses the JSON array stored in SagaLog.ErrorMessages.
+func decodeErrors(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	var errs []string
+	if err := json.Unmarshal([]byte(raw), &errs); err != nil {
+		return []string{raw}
+	}
+	return errs
+}
@@ -0,0 +1,112 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog/sqlite"
+)
+
+func TestMapHistoryToSagaResponse(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(sec int) time.Time { return start.Add(time.Duration(sec) * time.Second) }
+	entry := func(sec int, st sagalog.Status, step, errs string) *sagalog.SagaLog {
+		return &sagalog.SagaLog{SagaID: "o1", Status: st, CurrentStep: step, ErrorMessages: errs, TraceID: "trace-1", UpdatedAt: at(sec)}
+	}
+	const (
+		retry   = `attempt 1/3 failed: unavailable`
+		timeout = `saga timed out: context deadline exceeded`
+		comp    = `compensation of Inventory_Reservation_Step failed: unavailable`
+	)
+
+	history := []*sagalog.SagaLog{
+		entry(0, sagalog.StatusStarted, "", "[]"),
+		entry(1, sagalog.StatusStepDone, "Create_Order_Step", "[]"),
+		entry(2, sagalog.StatusStepRetrying, "Payment_Authorize_Step", `["`+retry+`"]`),
+		entry(3, sagalog.StatusTimedOut, "Payment_Authorize_Step", `["`+timeout+`"]`),
+		entry(4, sagalog.StatusCompensating, "Payment_Authorize_Step", `["`+timeout+`"]`),
+		entry(5, sagalog.StatusFailed, "Payment_Authorize_Step", `["`+timeout+`","`+comp+`"]`),
+	}
+	got := mapHistoryToSagaResponse(history)
+
+	want := SagaResponse{
+		SagaID:      "o1",
+		Status:      "FAILED",
+		CurrentStep: "Payment_Authorize_Step",
+		Errors:      []string{retry, timeout, comp},
+		TraceID:     "trace-1",
+		StartedAt:   "2026-01-02T03:04:05Z",
+		UpdatedAt:   "2026-01-02T03:04:10Z",
+		Transitions: []SagaTransitionResponse{
+			{Status: "STARTED", TraceID: "trace-1", At: "2026-01-02T03:04:05Z"},
+			{Status: "STEP_DONE", Step: "Create_Order_Step", TraceID: "trace-1", At: "2026-01-02T03:04:06Z"},
+			{Status: "STEP_RETRYING", Step: "Payment_Authorize_Step", TraceID: "trace-1", At: "2026-01-02T03:04:07Z"},
+			{Status: "TIMED_OUT", Step: "Payment_Authorize_Step", TraceID: "trace-1", At: "2026-01-02T03:04:08Z"},
+			{Status: "COMPENSATING", Step: "Payment_Authorize_Step", TraceID: "trace-1", At: "2026-01-02T03:04:09Z"},
+			{Status: "FAILED", Step: "Payment_Authorize_Step", TraceID: "trace-1", At: "2026-01-02T03:04:10Z"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mapHistoryToSagaResponse\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"", nil},
+		{"[]", []string{}},
+		{`["a","b"]`, []string{"a", "b"}},
+		{"not json", []string{"not json"}},
+	}
+	for _, tt := range tests {
+		if got := decodeErrors(tt.raw); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("decodeErrors(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestGetOrderSaga(t *testing.T) {
+	repo, err := sqlite.Open(filepath.Join(t.TempDir(), "saga.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	for _, st := range []sagalog.Status{sagalog.StatusStarted, sagalog.StatusStepDone} {
+		if err := repo.Save(context.Background(), sagalog.NewEntry(context.Background(), "o1", st, "", "", nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		repo     sagalog.Repository
+		id       string
+		wantCode int
+	}{
+		{"known order", repo, "o1", http.StatusOK},
+		{"unknown order", repo, "missing", http.StatusNotFound},
+		{"saga log disabled", nil, "o1", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			r.Get("/orders/{id}/saga", NewHandler(&fakeOrderService{}, nil, nil, nil, tt.repo).GetOrderSaga)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/"+tt.id+"/saga", nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status %d %s, want %d", rec.Code, rec.Body, tt.wantCode)
+			}
+		})
+	}
+}
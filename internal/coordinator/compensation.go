@@ -0,0 +1,140 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+// compensationBatchSize caps how many queued compensations one poll handles.
+const compensationBatchSize = 50
+
+// DefaultCompensationPolicy retries a failed compensation with exponential
+// backoff for roughly an hour before dead-lettering it. RetryableCodes is
+// ignored: every compensation error is retried.
+var DefaultCompensationPolicy = RetryPolicy{
+	MaxAttempts: 10,
+	BaseBackoff: 5 * time.Second,
+	MaxBackoff:  15 * time.Minute,
+	Jitter:      0.2,
+}
+
+// CompensationWorker drains the compensation queue in the background.
+// Each queued entry is rebuilt into its Step and Compensate is called again
+// until it succeeds or policy.MaxAttempts is reached, at which point the
+// entry is moved to the dead-letter table for an operator to re-drive.
+type CompensationWorker struct {
+	queue    sagalog.CompensationQueue
+	rebuild  RebuildFunc
+	policy   RetryPolicy
+	interval time.Duration
+}
+
+// NewCompensationWorker creates a worker that polls queue every interval.
+func NewCompensationWorker(queue sagalog.CompensationQueue, rebuild RebuildFunc, policy RetryPolicy, interval time.Duration) *CompensationWorker {
+	return &CompensationWorker{
+		queue:    queue,
+		rebuild:  rebuild,
+		policy:   policy,
+		interval: interval,
+	}
+}
+
+// Run polls the queue until ctx is cancelled.
+func (w *CompensationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.ProcessDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "compensation worker poll failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue retries every compensation that is currently due and returns
+// how many of them succeeded.
+func (w *CompensationWorker) ProcessDue(ctx context.Context) (int, error) {
+	due, err := w.queue.Due(ctx, time.Now(), compensationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for _, c := range due {
+		if w.process(ctx, c) {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// process makes one attempt and records its outcome in the queue.
+func (w *CompensationWorker) process(ctx context.Context, c *sagalog.Compensation) bool {
+	err := w.compensate(ctx, c)
+	if err == nil {
+		slog.InfoContext(ctx, "queued compensation succeeded",
+			"saga_id", c.SagaID,
+			"step", c.Step,
+			"attempts", c.Attempts+1,
+		)
+		if err := w.queue.Complete(ctx, c.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to remove completed compensation", "compensation_id", c.ID, "error", err)
+		}
+		return true
+	}
+
+	attempts := c.Attempts + 1
+	if attempts >= w.policy.MaxAttempts {
+		slog.ErrorContext(ctx, "CRITICAL: compensation exhausted its retries, moving to dead letters",
+			"saga_id", c.SagaID,
+			"step", c.Step,
+			"attempts", attempts,
+			"error", err,
+		)
+		if dlErr := w.queue.DeadLetter(ctx, c.ID, attempts, err.Error()); dlErr != nil {
+			slog.ErrorContext(ctx, "failed to dead-letter compensation", "compensation_id", c.ID, "error", dlErr)
+		}
+		return false
+	}
+
+	next := time.Now().Add(w.policy.backoff(attempts))
+	slog.WarnContext(ctx, "queued compensation failed, rescheduling",
+		"saga_id", c.SagaID,
+		"step", c.Step,
+		"attempts", attempts,
+		"next_attempt_at", next,
+		"error", err,
+	)
+	if err := w.queue.Reschedule(ctx, c.ID, attempts, err.Error(), next); err != nil {
+		slog.ErrorContext(ctx, "failed to reschedule compensation", "compensation_id", c.ID, "error", err)
+	}
+	return false
+}
+
+// compensate rebuilds the queued step, restores the saga data it saw when
+// it failed and runs its compensating action.
+func (w *CompensationWorker) compensate(ctx context.Context, c *sagalog.Compensation) error {
+	nodes, err := w.rebuild(ctx, c.SagaID, c.Payload)
+	if err != nil {
+		return fmt.Errorf("rebuild steps: %w", err)
+	}
+	data, err := DecodeData(c.Data)
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		if n.Step.Name() == c.Step {
+			return n.Step.Compensate(ContextWithData(ctx, data))
+		}
+	}
+	return fmt.Errorf("step %q not found in saga payload", c.Step)
+}
@@ -0,0 +1,174 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog/sqlite"
+)
+
+const refundIDKey Key[string] = "refund_id"
+
+// refundStep records a refund ID in the saga data and fails to compensate
+// while failures is positive. It reports the refund ID each compensation saw.
+type refundStep struct {
+	failures *int
+	seen     *[]string
+}
+
+func (s refundStep) Name() string { return "refund" }
+
+func (s refundStep) Execute(ctx context.Context) error {
+	return refundIDKey.Set(DataFromContext(ctx), "re_1")
+}
+
+func (s refundStep) Compensate(ctx context.Context) error {
+	id, _ := refundIDKey.Get(DataFromContext(ctx))
+	*s.seen = append(*s.seen, id)
+	if *s.failures > 0 {
+		*s.failures--
+		return errors.New("provider unavailable")
+	}
+	return nil
+}
+
+func openQueue(t *testing.T) *sqlite.Repository {
+	t.Helper()
+	repo, err := sqlite.Open(filepath.Join(t.TempDir(), "saga.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func TestCompensationWorker(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Hour}
+
+	tests := []struct {
+		name string
+		// failures is how many compensation attempts fail, the one made
+		// during the saga's rollback included.
+		failures int
+		// polls is how many times the worker runs, each time with every
+		// queued compensation due.
+		polls         int
+		wantQueued    int
+		wantAttempts  int
+		wantDead      bool
+		wantCompCalls int
+	}{
+		{"succeeds on the first retry", 1, 1, 0, 0, false, 2},
+		{"failed retry is rescheduled", 2, 1, 1, 2, false, 2},
+		{"succeeds on the last attempt", 2, 2, 0, 0, false, 3},
+		{"dead-lettered after the last attempt", 3, 3, 0, 3, true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := openQueue(t)
+			failures := tt.failures
+			var seen []string
+			newSteps := func() []Step {
+				return []Step{refundStep{failures: &failures, seen: &seen}, testStep{name: "charge", execute: func(context.Context) error {
+					return errors.New("declined")
+				}}}
+			}
+
+			err := NewOrchestrator("saga-1", newSteps(), queue, WithCompensationQueue(queue)).Start(context.Background())
+			if err == nil {
+				t.Fatal("saga succeeded, want an error")
+			}
+
+			rebuild := func(_ context.Context, sagaID, _ string) ([]Node, error) {
+				if sagaID != "saga-1" {
+					t.Errorf("rebuild of %q, want saga-1", sagaID)
+				}
+				return Chain(newSteps()...), nil
+			}
+			worker := NewCompensationWorker(queue, rebuild, policy, time.Minute)
+			for range tt.polls {
+				// Make every queued compensation due now.
+				due, err := queue.Due(context.Background(), time.Now().Add(24*time.Hour), 10)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, c := range due {
+					if err := queue.Reschedule(context.Background(), c.ID, c.Attempts, c.LastError, time.Now().Add(-time.Second)); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if _, err := worker.ProcessDue(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if len(seen) != tt.wantCompCalls {
+				t.Errorf("%d compensation calls, want %d", len(seen), tt.wantCompCalls)
+			}
+			for _, id := range seen {
+				if id != "re_1" {
+					t.Errorf("compensation saw refund ID %q, want the saga data restored", id)
+				}
+			}
+
+			queued, err := queue.Due(context.Background(), time.Now().Add(24*time.Hour), 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queued) != tt.wantQueued {
+				t.Fatalf("%d queued, want %d", len(queued), tt.wantQueued)
+			}
+			if tt.wantQueued > 0 {
+				if c := queued[0]; c.Attempts != tt.wantAttempts || c.Step != "refund" || !c.NextAttemptAt.After(time.Now()) {
+					t.Errorf("queued %+v, want %d attempts of refund due later", c, tt.wantAttempts)
+				}
+			}
+
+			dead, err := queue.ListDeadLetters(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (len(dead) == 1) != tt.wantDead {
+				t.Fatalf("%d dead letters, want dead %v", len(dead), tt.wantDead)
+			}
+			if tt.wantDead {
+				if dead[0].Attempts != tt.wantAttempts || dead[0].LastError == "" {
+					t.Errorf("dead letter %+v, want %d attempts and the last error", dead[0], tt.wantAttempts)
+				}
+				// A re-driven compensation is due again with a fresh counter.
+				if err := queue.Redrive(context.Background(), dead[0].ID); err != nil {
+					t.Fatal(err)
+				}
+				if n, err := worker.ProcessDue(context.Background()); err != nil || n != 1 {
+					t.Errorf("ProcessDue after redrive = %d, %v; want 1 success", n, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCompensationWorker_UnknownStep(t *testing.T) {
+	queue := openQueue(t)
+	c := &sagalog.Compensation{SagaID: "saga-1", Step: "gone", Attempts: 1}
+	if err := queue.Enqueue(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	rebuild := func(context.Context, string, string) ([]Node, error) {
+		return Chain(testStep{name: "other"}), nil
+	}
+	worker := NewCompensationWorker(queue, rebuild, RetryPolicy{MaxAttempts: 2}, time.Minute)
+	if n, err := worker.ProcessDue(context.Background()); err != nil || n != 0 {
+		t.Fatalf("ProcessDue = %d, %v; want 0 successes", n, err)
+	}
+	dead, err := queue.ListDeadLetters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || dead[0].LastError != `step "gone" not found in saga payload` {
+		t.Errorf("dead letters %+v, want the unknown step", dead)
+	}
+}
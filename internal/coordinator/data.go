@@ -0,0 +1,128 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// Data is the saga's shared execution context: a bag of values that steps
+// use to pass results to later steps (e.g. CreateOrderStep publishes the
+// order ID it was given by the order service).
+//
+// Values are stored as JSON so the bag can be snapshotted into the saga log
+// after every step and restored on recovery. It is safe for concurrent use
+// by parallel branches.
+type Data struct {
+	mu     sync.RWMutex
+	values map[string]json.RawMessage
+}
+
+// NewData returns an empty bag.
+func NewData() *Data {
+	return &Data{values: make(map[string]json.RawMessage)}
+}
+
+// DecodeData restores a bag from a snapshot produced by Encode.
+// An empty snapshot yields an empty bag.
+func DecodeData(s string) (*Data, error) {
+	d := NewData()
+	if s == "" {
+		return d, nil
+	}
+	if err := json.Unmarshal([]byte(s), &d.values); err != nil {
+		return nil, fmt.Errorf("decode saga data: %w", err)
+	}
+	return d, nil
+}
+
+// Encode serialises the bag. An empty bag encodes to "".
+func (d *Data) Encode() (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if len(d.values) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(d.values)
+	if err != nil {
+		return "", fmt.Errorf("encode saga data: %w", err)
+	}
+	return string(b), nil
+}
+
+// Key is a typed name for a value in Data.
+//
+//	var OrderIDKey = coordinator.Key[string]("order_id")
+//	_ = OrderIDKey.Set(data, "ord_123")
+//	id, ok := OrderIDKey.Get(data)
+type Key[T any] string
+
+// Get returns the value stored under k. ok is false if the key is missing
+// or its value cannot be decoded as T.
+func (k Key[T]) Get(d *Data) (v T, ok bool) {
+	d.mu.RLock()
+	raw, found := d.values[string(k)]
+	d.mu.RUnlock()
+
+	if !found || json.Unmarshal(raw, &v) != nil {
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// Set stores v under k, replacing any previous value.
+func (k Key[T]) Set(d *Data, v T) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("saga data: encode %q: %w", string(k), err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.values[string(k)] = raw
+	return nil
+}
+
+// Well-known keys shared by the order saga steps.
+var (
+	// OrderIDKey holds the ID of the order the saga works on.
+	OrderIDKey = Key[string]("order_id")
+
+	// FulfillmentKey holds what InventoryStep reserved and backordered of
+	// each item, in item order.
+	FulfillmentKey = Key[[]ItemFulfillment]("fulfillment")
+
+	// FulfilledTotalKey holds what the customer pays for the units that
+	// will ship, as recorded by ConfirmOrderStep. PaymentCaptureStep takes
+	// only this much when it is less than the order total.
+	FulfilledTotalKey = Key[money.Money]("fulfilled_total")
+)
+
+// ItemFulfillment is what the inventory did with one order item.
+type ItemFulfillment struct {
+	ProductID   string `json:"product_id"`
+	Requested   int32  `json:"requested"`
+	Reserved    int32  `json:"reserved"`
+	Backordered int32  `json:"backordered"`
+}
+
+type dataCtxKey struct{}
+
+// ContextWithData returns a copy of ctx carrying d.
+func ContextWithData(ctx context.Context, d *Data) context.Context {
+	return context.WithValue(ctx, dataCtxKey{}, d)
+}
+
+// DataFromContext returns the saga data carried by ctx. Outside a saga it
+// returns a fresh, empty bag so steps never have to nil-check.
+func DataFromContext(ctx context.Context) *Data {
+	if d, ok := ctx.Value(dataCtxKey{}).(*Data); ok {
+		return d
+	}
+	return NewData()
+}
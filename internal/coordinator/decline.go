@@ -0,0 +1,86 @@
+package coordinator
+
+import (
+	"fmt"
+	"strings"
+
+	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
+	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+)
+
+// DeclineError is a business refusal by a downstream service: the request
+// was valid but cannot be honoured, e.g. a declined card or missing stock.
+// It is never retried. Its message ends up in the saga log and in the
+// cancelled order's reason, so it is written for customers.
+type DeclineError struct {
+	// Reason is the machine-readable code, e.g. "INSUFFICIENT_FUNDS" or
+	// "OUT_OF_STOCK".
+	Reason string
+	// Detail says what was refused.
+	Detail string
+	// Shortages lists the items that could not be reserved, if any.
+	Shortages []Shortage
+}
+
+// Shortage is an item a reservation could not cover.
+type Shortage struct {
+	ProductID string
+	Reason    string // "OUT_OF_STOCK" or "UNKNOWN_PRODUCT"
+	Requested int32
+	Available int32
+}
+
+func (e *DeclineError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Reason)
+	b.WriteString(": ")
+	b.WriteString(e.Detail)
+	for i, s := range e.Shortages {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		if s.Reason == inventoryv1.ReserveFailure_UNKNOWN_PRODUCT.String() {
+			fmt.Fprintf(&b, "%s is not a known product", s.ProductID)
+			continue
+		}
+		fmt.Fprintf(&b, "%s requested %d, available %d", s.ProductID, s.Requested, s.Available)
+	}
+	return b.String()
+}
+
+// paymentDecline builds the DeclineError for a refused charge or hold.
+// Services that predate decline reasons report a generic decline.
+func paymentDecline(what, orderID string, reason paymentv1.DeclineReason, message string) *DeclineError {
+	if reason == paymentv1.DeclineReason_DECLINE_REASON_UNSPECIFIED {
+		reason = paymentv1.DeclineReason_GENERIC_DECLINE
+	}
+	detail := fmt.Sprintf("%s declined for order %s", what, orderID)
+	if message != "" {
+		detail += " (" + message + ")"
+	}
+	return &DeclineError{Reason: reason.String(), Detail: detail}
+}
+
+// inventoryDecline builds the DeclineError for a refused reservation.
+// Services that predate failure reasons report the stock as insufficient.
+func inventoryDecline(orderID string, res *inventoryv1.ReserveResponse) *DeclineError {
+	reason := res.GetFailureReason()
+	if reason == inventoryv1.ReserveFailure_RESERVE_FAILURE_UNSPECIFIED {
+		reason = inventoryv1.ReserveFailure_OUT_OF_STOCK
+	}
+	e := &DeclineError{
+		Reason: reason.String(),
+		Detail: "inventory insufficient for order " + orderID,
+	}
+	for _, s := range res.GetShortages() {
+		e.Shortages = append(e.Shortages, Shortage{
+			ProductID: s.GetProductId(),
+			Reason:    s.GetReason().String(),
+			Requested: s.GetRequested(),
+			Available: s.GetAvailable(),
+		})
+	}
+	return e
+}
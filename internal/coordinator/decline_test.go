@@ -0,0 +1,209 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
+	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+func TestDeclineError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *DeclineError
+		want string
+	}{
+		{
+			name: "no shortages",
+			err:  &DeclineError{Reason: "INSUFFICIENT_FUNDS", Detail: "payment declined for order o1"},
+			want: "INSUFFICIENT_FUNDS: payment declined for order o1",
+		},
+		{
+			name: "shortages",
+			err: &DeclineError{
+				Reason: "OUT_OF_STOCK",
+				Detail: "inventory insufficient for order o1",
+				Shortages: []Shortage{
+					{ProductID: "p1", Reason: "OUT_OF_STOCK", Requested: 3, Available: 1},
+					{ProductID: "p2", Reason: "UNKNOWN_PRODUCT", Requested: 1},
+				},
+			},
+			want: "OUT_OF_STOCK: inventory insufficient for order o1: p1 requested 3, available 1; p2 is not a known product",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakePaymentClient answers Charge and Authorize with err, or else with
+// the configured outcome.
+type fakePaymentClient struct {
+	paymentv1.PaymentClient
+
+	success bool
+	reason  paymentv1.DeclineReason
+	message string
+	err     error
+}
+
+func (c *fakePaymentClient) Charge(context.Context, *paymentv1.ChargeRequest, ...grpc.CallOption) (*paymentv1.ChargeResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &paymentv1.ChargeResponse{Success: c.success, DeclineReason: c.reason, DeclineMessage: c.message}, nil
+}
+
+func (c *fakePaymentClient) Authorize(context.Context, *paymentv1.AuthorizeRequest, ...grpc.CallOption) (*paymentv1.AuthorizeResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &paymentv1.AuthorizeResponse{Success: c.success, DeclineReason: c.reason, DeclineMessage: c.message}, nil
+}
+
+func TestPaymentSteps_Declines(t *testing.T) {
+	amount := money.New(10_00, "USD")
+	tests := []struct {
+		name   string
+		client *fakePaymentClient
+		want   *DeclineError
+	}{
+		{
+			name:   "approved",
+			client: &fakePaymentClient{success: true},
+		},
+		{
+			name:   "declined with a reason",
+			client: &fakePaymentClient{reason: paymentv1.DeclineReason_EXPIRED_CARD, message: "card declined: expired_card"},
+			want:   &DeclineError{Reason: "EXPIRED_CARD", Detail: "%s declined for order o1 (card declined: expired_card)"},
+		},
+		{
+			name:   "declined by a service without reasons",
+			client: &fakePaymentClient{},
+			want:   &DeclineError{Reason: "GENERIC_DECLINE", Detail: "%s declined for order o1"},
+		},
+	}
+	steps := []struct {
+		what string
+		step func(paymentv1.PaymentClient) Step
+	}{
+		{"payment", func(c paymentv1.PaymentClient) Step { return NewPaymentStep(c, "o1", amount) }},
+		{"payment authorization", func(c paymentv1.PaymentClient) Step { return NewPaymentAuthorizeStep(c, "o1", amount) }},
+	}
+	for _, s := range steps {
+		for _, tt := range tests {
+			t.Run(s.what+"/"+tt.name, func(t *testing.T) {
+				err := s.step(tt.client).Execute(context.Background())
+				if tt.want == nil {
+					if err != nil {
+						t.Fatalf("Execute error = %v, want nil", err)
+					}
+					return
+				}
+				var de *DeclineError
+				if !errors.As(err, &de) {
+					t.Fatalf("Execute error = %v, want a DeclineError", err)
+				}
+				want := *tt.want
+				want.Detail = fmt.Sprintf(want.Detail, s.what)
+				if !reflect.DeepEqual(*de, want) {
+					t.Errorf("decline %+v, want %+v", *de, want)
+				}
+				if DefaultRetryPolicy.shouldRetry(err) {
+					t.Error("a decline is retried")
+				}
+			})
+		}
+	}
+}
+
+func TestPaymentStep_ServiceErrorIsNotADecline(t *testing.T) {
+	step := NewPaymentStep(&fakePaymentClient{err: status.Error(codes.Unavailable, "down")}, "o1", money.New(10_00, "USD"))
+	err := step.Execute(context.Background())
+	var de *DeclineError
+	if errors.As(err, &de) {
+		t.Fatalf("Execute error = %v, want a service error", err)
+	}
+	if !DefaultRetryPolicy.shouldRetry(err) {
+		t.Errorf("Execute error = %v is not retried", err)
+	}
+}
+
+// fakeInventoryClient answers Reserve with res.
+type fakeInventoryClient struct {
+	inventoryv1.InventoryClient
+
+	res *inventoryv1.ReserveResponse
+}
+
+func (c *fakeInventoryClient) Reserve(context.Context, *inventoryv1.ReserveRequest, ...grpc.CallOption) (*inventoryv1.ReserveResponse, error) {
+	return c.res, nil
+}
+
+func TestInventoryStep_Declines(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *inventoryv1.ReserveResponse
+		want DeclineError
+	}{
+		{
+			name: "out of stock",
+			res: &inventoryv1.ReserveResponse{
+				FailureReason: inventoryv1.ReserveFailure_OUT_OF_STOCK,
+				Shortages: []*inventoryv1.StockShortage{
+					{ProductId: "p1", Reason: inventoryv1.ReserveFailure_OUT_OF_STOCK, Requested: 3, Available: 1},
+				},
+			},
+			want: DeclineError{
+				Reason:    "OUT_OF_STOCK",
+				Detail:    "inventory insufficient for order o1",
+				Shortages: []Shortage{{ProductID: "p1", Reason: "OUT_OF_STOCK", Requested: 3, Available: 1}},
+			},
+		},
+		{
+			name: "unknown product",
+			res: &inventoryv1.ReserveResponse{
+				FailureReason: inventoryv1.ReserveFailure_UNKNOWN_PRODUCT,
+				Shortages: []*inventoryv1.StockShortage{
+					{ProductId: "p9", Reason: inventoryv1.ReserveFailure_UNKNOWN_PRODUCT, Requested: 1},
+				},
+			},
+			want: DeclineError{
+				Reason:    "UNKNOWN_PRODUCT",
+				Detail:    "inventory insufficient for order o1",
+				Shortages: []Shortage{{ProductID: "p9", Reason: "UNKNOWN_PRODUCT", Requested: 1}},
+			},
+		},
+		{
+			name: "service without failure reasons",
+			res:  &inventoryv1.ReserveResponse{},
+			want: DeclineError{Reason: "OUT_OF_STOCK", Detail: "inventory insufficient for order o1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := NewInventoryStep(&fakeInventoryClient{res: tt.res}, "o1", nil, inventoryv1.FulfillmentPolicy_ALL_OR_NOTHING)
+			err := step.Execute(context.Background())
+			var de *DeclineError
+			if !errors.As(err, &de) {
+				t.Fatalf("Execute error = %v, want a DeclineError", err)
+			}
+			if !reflect.DeepEqual(*de, tt.want) {
+				t.Errorf("decline %+v, want %+v", *de, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+// Event describes a single saga state transition. The orchestrator emits
+// one for every entry it records in the saga log, in the same order.
+type Event struct {
+	SagaID string
+	Status sagalog.Status
+	Step   string
+	Errors []string
+	At     time.Time
+}
+
+// Terminal reports whether the saga has finished and no further events
+// will follow.
+func (e Event) Terminal() bool {
+	return !e.Status.IsInFlight()
+}
+
+// EventHandler receives saga transitions as they happen. It is called on the
+// saga's goroutines, possibly concurrently, and must not block.
+type EventHandler func(ctx context.Context, e Event)
+
+// CompletionHandler is called once every step of a saga has succeeded, just
+// before the COMPLETED status is written.
+type CompletionHandler func(ctx context.Context, sagaID string)
@@ -0,0 +1,94 @@
+package coordinator
+
+import "fmt"
+
+// Node is a step in a saga graph together with the names of the steps that
+// must complete before it may start. Nodes with no dependency between them
+// run concurrently.
+type Node struct {
+	Step      Step
+	DependsOn []string
+}
+
+// Chain turns a list of steps into nodes that each depend on the previous
+// one, i.e. the classic sequential saga.
+func Chain(steps ...Step) []Node {
+	nodes := make([]Node, len(steps))
+	for i, step := range steps {
+		nodes[i] = Node{Step: step}
+		if i > 0 {
+			nodes[i].DependsOn = []string{steps[i-1].Name()}
+		}
+	}
+	return nodes
+}
+
+// Groups turns ordered groups of steps into nodes: steps inside a group run
+// concurrently, and every step of a group depends on all steps of the
+// previous group.
+//
+//	coordinator.Groups(
+//		[]coordinator.Step{inventory, payment}, // in parallel
+//		[]coordinator.Step{confirm},            // once both are done
+//	)
+func Groups(groups ...[]Step) []Node {
+	var nodes []Node
+	var prev []string
+	for _, group := range groups {
+		names := make([]string, len(group))
+		for i, step := range group {
+			nodes = append(nodes, Node{Step: step, DependsOn: prev})
+			names[i] = step.Name()
+		}
+		prev = names
+	}
+	return nodes
+}
+
+// sortNodes validates the graph and returns its nodes in a topological
+// order that is stable with respect to the declaration order.
+func sortNodes(nodes []Node) ([]Node, error) {
+	index := make(map[string]int, len(nodes))
+	for i, n := range nodes {
+		name := n.Step.Name()
+		if _, dup := index[name]; dup {
+			return nil, fmt.Errorf("saga graph: duplicate step %q", name)
+		}
+		index[name] = i
+	}
+	for _, n := range nodes {
+		for _, dep := range n.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("saga graph: step %q depends on unknown step %q", n.Step.Name(), dep)
+			}
+		}
+	}
+
+	sorted := make([]Node, 0, len(nodes))
+	placed := make(map[string]bool, len(nodes))
+	for len(sorted) < len(nodes) {
+		progress := false
+		for _, n := range nodes {
+			if placed[n.Step.Name()] || !depsMet(n, placed) {
+				continue
+			}
+			sorted = append(sorted, n)
+			placed[n.Step.Name()] = true
+			progress = true
+		}
+		if !progress {
+			return nil, fmt.Errorf("saga graph: dependency cycle detected")
+		}
+	}
+	return sorted, nil
+}
+
+// depsMet reports whether every dependency of n is in done.
+func depsMet(n Node, done map[string]bool) bool {
+	for _, dep := range n.DependsOn {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package coordinator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func names(nodes []Node) []string {
+	out := make([]string, len(nodes))
+	for i, n := range nodes {
+		out[i] = n.Step.Name()
+	}
+	return out
+}
+
+func node(name string, deps ...string) Node {
+	return Node{Step: testStep{name: name}, DependsOn: deps}
+}
+
+func TestSortNodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []Node
+		want    []string
+		wantErr string
+	}{
+		{"chain", Chain(testStep{name: "a"}, testStep{name: "b"}, testStep{name: "c"}), []string{"a", "b", "c"}, ""},
+		{"declared before its dependency", []Node{node("b", "a"), node("a")}, []string{"a", "b"}, ""},
+		{"diamond keeps declaration order", []Node{node("d", "b", "c"), node("c", "a"), node("b", "a"), node("a")}, []string{"a", "c", "b", "d"}, ""},
+		{"independent steps", []Node{node("x"), node("y")}, []string{"x", "y"}, ""},
+		{"duplicate step", []Node{node("a"), node("a")}, nil, "duplicate step"},
+		{"unknown dependency", []Node{node("a", "missing")}, nil, "unknown step"},
+		{"cycle", []Node{node("a", "c"), node("b", "a"), node("c", "b")}, nil, "cycle"},
+		{"self dependency", []Node{node("a", "a")}, nil, "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := sortNodes(tt.nodes)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(sorted); !slices.Equal(got, tt.want) {
+				t.Errorf("order %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroups(t *testing.T) {
+	nodes := Groups(
+		[]Step{testStep{name: "inventory"}, testStep{name: "payment"}},
+		[]Step{testStep{name: "confirm"}},
+	)
+	want := map[string][]string{
+		"inventory": nil,
+		"payment":   nil,
+		"confirm":   {"inventory", "payment"},
+	}
+	if len(nodes) != len(want) {
+		t.Fatalf("%d nodes, want %d", len(nodes), len(want))
+	}
+	for _, n := range nodes {
+		if got := n.DependsOn; !slices.Equal(got, want[n.Step.Name()]) {
+			t.Errorf("%s depends on %v, want %v", n.Step.Name(), got, want[n.Step.Name()])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package coordinator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// Payload is the serialised input of an order saga. It is stored once, on
+// the STARTED row of the saga log, and is everything a Registry needs to
+// rebuild the saga's steps for replay, debugging or crash recovery.
+type Payload struct {
+	OrderID    string        `json:"order_id"`
+	CustomerID string        `json:"customer_id"`
+	Items      []PayloadItem `json:"items"`
+	Total      money.Money   `json:"total_money"`
+
+	// FulfillmentPolicy names what the inventory does with items short of
+	// stock, as in the inventory and order APIs (e.g. "PARTIAL"). Empty,
+	// as in payloads written before it existed, means all or nothing.
+	FulfillmentPolicy string `json:"fulfillment_policy,omitempty"`
+
+	// IdempotencyKey is sent with the order creation, so that a retried
+	// Create_Order_Step finds the order its first attempt stored.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+	// Deprecated: LegacyTotal is the float total written by older builds.
+	// Encode still fills it so they can replay new payloads; DecodePayload
+	// converts it when total_money is missing.
+	LegacyTotal float64 `json:"total,omitempty"`
+
+	// Steps holds the step names in execution order. The Orchestrator fills
+	// it from its own steps before the payload is written.
+	Steps []string `json:"steps"`
+
+	// DependsOn maps a step name to the steps it waits for. Also filled by
+	// the Orchestrator. Payloads written before graphs were supported have
+	// no DependsOn and are rebuilt as a sequential chain of Steps.
+	DependsOn map[string][]string `json:"depends_on,omitempty"`
+}
+
+// PayloadItem is a single order line inside a Payload.
+type PayloadItem struct {
+	ProductID string      `json:"product_id"`
+	Quantity  int32       `json:"quantity"`
+	UnitPrice money.Money `json:"unit_price_money"`
+
+	// Deprecated: see Payload.LegacyTotal.
+	LegacyUnitPrice float64 `json:"unit_price,omitempty"`
+}
+
+// Encode serialises the payload to the JSON stored in sagalog.SagaLog.Payload.
+func (p *Payload) Encode() (string, error) {
+	out := *p
+	out.LegacyTotal = p.Total.Major()
+	out.Items = make([]PayloadItem, len(p.Items))
+	for i, it := range p.Items {
+		it.LegacyUnitPrice = it.UnitPrice.Major()
+		out.Items[i] = it
+	}
+
+	b, err := json.Marshal(&out)
+	if err != nil {
+		return "", fmt.Errorf("encode saga payload: %w", err)
+	}
+	return string(b), nil
+}
+
+// DecodePayload parses a payload previously produced by Encode. Payloads
+// written before exact amounts only carry the float fields, which are
+// converted in money.DefaultCurrency.
+func DecodePayload(s string) (*Payload, error) {
+	var p Payload
+	if err := json.Unmarshal([]byte(s), &p); err != nil {
+		return nil, fmt.Errorf("decode saga payload: %w", err)
+	}
+
+	if p.Total.CurrencyCode == "" {
+		p.Total = money.FromMajor(p.LegacyTotal, money.DefaultCurrency)
+	}
+	for i, it := range p.Items {
+		if it.UnitPrice.CurrencyCode == "" {
+			p.Items[i].UnitPrice = money.FromMajor(it.LegacyUnitPrice, money.DefaultCurrency)
+		}
+	}
+	return &p, nil
+}
@@ -0,0 +1,110 @@
+package coordinator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+func TestPayload_EncodeDecode(t *testing.T) {
+	p := &Payload{
+		OrderID:    "o1",
+		CustomerID: "c1",
+		Items: []PayloadItem{
+			{ProductID: "p1", Quantity: 2, UnitPrice: money.New(1999, "EUR")},
+		},
+		Total:             money.New(3998, "EUR"),
+		FulfillmentPolicy: "PARTIAL",
+		IdempotencyKey:    "key-1",
+		Steps:             []string{"a", "b"},
+		DependsOn:         map[string][]string{"b": {"a"}},
+	}
+	s, err := p.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Older builds read the float fields only.
+	var legacy struct {
+		Total float64 `json:"total"`
+		Items []struct {
+			UnitPrice float64 `json:"unit_price"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(s), &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.Total != 39.98 || legacy.Items[0].UnitPrice != 19.99 {
+		t.Errorf("legacy fields %+v, want total 39.98 and unit price 19.99", legacy)
+	}
+
+	got, err := DecodePayload(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := *p
+	want.LegacyTotal = 39.98
+	want.Items = []PayloadItem{{ProductID: "p1", Quantity: 2, UnitPrice: money.New(1999, "EUR"), LegacyUnitPrice: 19.99}}
+	if !reflect.DeepEqual(got, &want) {
+		t.Errorf("DecodePayload(Encode(p))\n got %+v\nwant %+v", got, &want)
+	}
+	if p.LegacyTotal != 0 || p.Items[0].LegacyUnitPrice != 0 {
+		t.Error("Encode modified the payload")
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *Payload
+		wantErr bool
+	}{
+		{
+			name:    "written before exact amounts",
+			payload: `{"order_id":"o1","customer_id":"c1","items":[{"product_id":"p1","quantity":2,"unit_price":19.99}],"total":39.98,"steps":["a","b"]}`,
+			want: &Payload{
+				OrderID: "o1", CustomerID: "c1",
+				Items:       []PayloadItem{{ProductID: "p1", Quantity: 2, UnitPrice: money.New(1999, money.DefaultCurrency), LegacyUnitPrice: 19.99}},
+				Total:       money.New(3998, money.DefaultCurrency),
+				LegacyTotal: 39.98,
+				Steps:       []string{"a", "b"},
+			},
+		},
+		{
+			name:    "written before graphs, policies and keys",
+			payload: `{"order_id":"o1","customer_id":"c1","items":[],"total_money":{"minor_units":500,"currency_code":"GBP"},"steps":["a"]}`,
+			want: &Payload{
+				OrderID: "o1", CustomerID: "c1",
+				Items: []PayloadItem{},
+				Total: money.New(500, "GBP"),
+				Steps: []string{"a"},
+			},
+		},
+		{
+			name:    "exact amounts win over the float ones",
+			payload: `{"order_id":"o1","items":[{"product_id":"p1","quantity":1,"unit_price_money":{"minor_units":1000,"currency_code":"JPY"},"unit_price":1.5}],"total_money":{"minor_units":1000,"currency_code":"JPY"},"total":1.5,"steps":["a"]}`,
+			want: &Payload{
+				OrderID:     "o1",
+				Items:       []PayloadItem{{ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "JPY"), LegacyUnitPrice: 1.5}},
+				Total:       money.New(1000, "JPY"),
+				LegacyTotal: 1.5,
+				Steps:       []string{"a"},
+			},
+		},
+		{name: "not JSON", payload: `order o1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePayload(tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodePayload error = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodePayload\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+// errInterrupted is recorded as the cause when an interrupted saga is
+// compensated instead of resumed.
+var errInterrupted = errors.New("saga interrupted by process restart")
+
+// RebuildFunc reconstructs the step graph of a saga from the payload stored
+// on its STARTED log row. Registry.Rebuild is the standard implementation.
+type RebuildFunc func(ctx context.Context, sagaID, payload string) ([]Node, error)
+
+// RecoveryMode decides what happens to sagas that were still running.
+type RecoveryMode int
+
+const (
+	// ResumeInFlight continues forward from the first pending step.
+	// Sagas that were already compensating or had timed out are compensated.
+	ResumeInFlight RecoveryMode = iota
+
+	// CompensateInFlight rolls back every interrupted saga.
+	CompensateInFlight
+)
+
+// Recoverer finds sagas that were in-flight when the process stopped and
+// drives each of them to a terminal state. Run it once on startup, before
+// accepting new traffic or in the background right after.
+type Recoverer struct {
+	log     sagalog.Repository
+	rebuild RebuildFunc
+	mode    RecoveryMode
+	opts    []Option
+}
+
+// NewRecoverer creates a Recoverer.
+//
+//   - log: the saga log to scan and to write new transitions to.
+//   - rebuild: turns a stored payload back into concrete steps.
+//   - opts: applied to every recovered Orchestrator (e.g. WithFailureHandler).
+func NewRecoverer(log sagalog.Repository, rebuild RebuildFunc, mode RecoveryMode, opts ...Option) *Recoverer {
+	return &Recoverer{
+		log:     log,
+		rebuild: rebuild,
+		mode:    mode,
+		opts:    opts,
+	}
+}
+
+// Recover drives every in-flight saga to COMPLETED or FAILED, one at a time.
+// A saga that cannot be recovered is logged and skipped so that a single bad
+// row does not block the others. It returns how many sagas were processed.
+func (r *Recoverer) Recover(ctx context.Context) (int, error) {
+	inFlight, err := r.listInFlight(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("recovery: list in-flight sagas: %w", err)
+	}
+
+	if len(inFlight) > 0 {
+		slog.InfoContext(ctx, "recovering in-flight sagas", "count", len(inFlight))
+	}
+
+	recovered := 0
+	for _, latest := range inFlight {
+		if err := r.recoverOne(ctx, latest); err != nil {
+			slog.ErrorContext(ctx, "CRITICAL: saga recovery failed",
+				"saga_id", latest.SagaID,
+				"status", latest.Status,
+				"error", err,
+			)
+			continue
+		}
+		recovered++
+	}
+	return recovered, nil
+}
+
+// listInFlight collects the latest entry of every saga that has not reached
+// a terminal state, oldest first. All pages are read before any saga is
+// recovered, so that every page shows the log as the crash left it.
+func (r *Recoverer) listInFlight(ctx context.Context) ([]*sagalog.SagaLog, error) {
+	var sagas []*sagalog.SagaLog
+	filter := sagalog.Filter{Statuses: sagalog.InFlightStatuses, Limit: sagalog.MaxListLimit}
+	for {
+		page, err := r.log.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, page.Sagas...)
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	slices.Reverse(sagas)
+	return sagas, nil
+}
+
+// recoverOne rebuilds a single saga and resumes or compensates it.
+func (r *Recoverer) recoverOne(ctx context.Context, latest *sagalog.SagaLog) error {
+	history, err := r.log.History(ctx, latest.SagaID)
+	if err != nil {
+		return err
+	}
+
+	payload, snapshot, completed := replay(history)
+	if payload == "" {
+		return fmt.Errorf("no payload stored for saga %q", latest.SagaID)
+	}
+
+	data, err := DecodeData(snapshot)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := r.rebuild(ctx, latest.SagaID, payload)
+	if err != nil {
+		return fmt.Errorf("rebuild steps: %w", err)
+	}
+
+	opts := append([]Option{withEncodedPayload(payload), WithData(data)}, r.opts...)
+	saga, err := NewGraphOrchestrator(latest.SagaID, nodes, r.log, opts...)
+	if err != nil {
+		return fmt.Errorf("rebuild steps: %w", err)
+	}
+
+	if r.mode == CompensateInFlight ||
+		latest.Status == sagalog.StatusCompensating ||
+		latest.Status == sagalog.StatusTimedOut {
+		slog.InfoContext(ctx, "recovery: compensating saga", "saga_id", latest.SagaID, "status", latest.Status)
+		_ = saga.Compensate(ctx, completed, errInterrupted)
+		return nil
+	}
+
+	slog.InfoContext(ctx, "recovery: resuming saga", "saga_id", latest.SagaID, "status", latest.Status)
+	if err := saga.Resume(ctx, completed); err != nil {
+		// The orchestrator already compensated and logged the failure.
+		slog.WarnContext(ctx, "recovery: resumed saga failed", "saga_id", latest.SagaID, "error", err)
+	}
+	return nil
+}
+
+// replay walks a saga's history and returns the payload of its last STARTED
+// row, the latest data snapshot and the names of the steps completed since.
+func replay(history []*sagalog.SagaLog) (payload, data string, completed []string) {
+	for _, entry := range history {
+		switch entry.Status {
+		case sagalog.StatusStarted:
+			payload = entry.Payload
+			data = entry.Data
+			completed = nil
+		case sagalog.StatusStepDone:
+			data = entry.Data
+			completed = append(completed, entry.CurrentStep)
+		}
+	}
+	return payload, data, completed
+}
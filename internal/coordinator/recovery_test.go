@@ -0,0 +1,257 @@
+package coordinator
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+const tokenKey Key[string] = "token"
+
+// tracker records what the steps of a recovered saga did, as
+// "step order-ID token": the order ID comes from the payload and the token
+// from the saga data, so both show what recovery restored.
+type tracker struct {
+	mu          sync.Mutex
+	executed    []string
+	compensated []string
+}
+
+func (tr *tracker) record(ctx context.Context, list *[]string, step, orderID string) {
+	token, _ := tokenKey.Get(DataFromContext(ctx))
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	*list = append(*list, step+" "+orderID+" "+token)
+}
+
+// trackedStep is a step rebuilt from a payload. Step a publishes the token.
+type trackedStep struct {
+	name    string
+	orderID string
+	tr      *tracker
+}
+
+func (s trackedStep) Name() string { return s.name }
+
+func (s trackedStep) Execute(ctx context.Context) error {
+	if s.name == "a" {
+		if err := tokenKey.Set(DataFromContext(ctx), "tok"); err != nil {
+			return err
+		}
+	}
+	s.tr.record(ctx, &s.tr.executed, s.name, s.orderID)
+	return nil
+}
+
+func (s trackedStep) Compensate(ctx context.Context) error {
+	s.tr.record(ctx, &s.tr.compensated, s.name, s.orderID)
+	return nil
+}
+
+// trackedRegistry rebuilds the graph a -> (b, c) -> d.
+func trackedRegistry(tr *tracker) *Registry {
+	reg := NewRegistry()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		reg.Register(name, func(p *Payload) (Step, error) {
+			return trackedStep{name: name, orderID: p.OrderID, tr: tr}, nil
+		})
+	}
+	return reg
+}
+
+func trackedPayload(t *testing.T) string {
+	t.Helper()
+	p := &Payload{
+		OrderID:   "o1",
+		Steps:     []string{"a", "b", "c", "d"},
+		DependsOn: map[string][]string{"b": {"a"}, "c": {"a"}, "d": {"b", "c"}},
+	}
+	s, err := p.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// logRow is a saga log row as a crash left it.
+type logRow struct {
+	status sagalog.Status
+	step   string
+}
+
+func TestRecoverer(t *testing.T) {
+	const aDone = `{"token":"tok"}`
+	interrupted := []logRow{
+		{sagalog.StatusStarted, ""},
+		{sagalog.StatusStepDone, "a"},
+		{sagalog.StatusStepRetrying, "b"},
+	}
+
+	tests := []struct {
+		name            string
+		mode            RecoveryMode
+		logged          []logRow
+		wantExecuted    []string
+		wantCompensated []string
+		wantStatus      sagalog.Status
+	}{
+		{
+			name:         "resume runs the pending steps",
+			mode:         ResumeInFlight,
+			logged:       interrupted,
+			wantExecuted: []string{"b o1 tok", "c o1 tok", "d o1 tok"},
+			wantStatus:   sagalog.StatusCompleted,
+		},
+		{
+			name:            "compensate also undoes the steps that were running",
+			mode:            CompensateInFlight,
+			logged:          interrupted,
+			wantCompensated: []string{"c o1 tok", "b o1 tok", "a o1 tok"},
+			wantStatus:      sagalog.StatusFailed,
+		},
+		{
+			name:            "compensate before any step completed",
+			mode:            CompensateInFlight,
+			logged:          []logRow{{sagalog.StatusStarted, ""}},
+			wantCompensated: []string{"a o1 "},
+			wantStatus:      sagalog.StatusFailed,
+		},
+		{
+			name:            "timed out saga is compensated when resuming",
+			mode:            ResumeInFlight,
+			logged:          append(slices.Clone(interrupted), logRow{sagalog.StatusTimedOut, "b"}),
+			wantCompensated: []string{"c o1 tok", "b o1 tok", "a o1 tok"},
+			wantStatus:      sagalog.StatusFailed,
+		},
+		{
+			name:            "compensating saga is compensated when resuming",
+			mode:            ResumeInFlight,
+			logged:          append(slices.Clone(interrupted), logRow{sagalog.StatusCompensating, "b"}),
+			wantCompensated: []string{"c o1 tok", "b o1 tok", "a o1 tok"},
+			wantStatus:      sagalog.StatusFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := openQueue(t)
+			at := time.Now().Add(-time.Hour)
+			data := ""
+			for _, row := range tt.logged {
+				entry := &sagalog.SagaLog{SagaID: "o1", Status: row.status, CurrentStep: row.step, ErrorMessages: "[]", UpdatedAt: at}
+				switch row.status {
+				case sagalog.StatusStarted:
+					entry.Payload = trackedPayload(t)
+				case sagalog.StatusStepDone:
+					data = aDone
+					entry.Data = data
+				}
+				if err := repo.Save(context.Background(), entry); err != nil {
+					t.Fatal(err)
+				}
+				at = at.Add(time.Second)
+			}
+
+			tr := &tracker{}
+			n, err := NewRecoverer(repo, trackedRegistry(tr).Rebuild, tt.mode).Recover(context.Background())
+			if err != nil || n != 1 {
+				t.Fatalf("Recover = %d, %v, want 1 saga", n, err)
+			}
+
+			slices.Sort(tr.executed)
+			if !slices.Equal(tr.executed, tt.wantExecuted) {
+				t.Errorf("executed %q, want %q", tr.executed, tt.wantExecuted)
+			}
+			if !slices.Equal(tr.compensated, tt.wantCompensated) {
+				t.Errorf("compensated %q, want %q", tr.compensated, tt.wantCompensated)
+			}
+			latest, err := repo.GetLatest(context.Background(), "o1")
+			if err != nil || latest.Status != tt.wantStatus {
+				t.Errorf("latest status %v (%v), want %s", latest, err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRecoverer_SkipsFinishedSagas(t *testing.T) {
+	repo := openQueue(t)
+	at := time.Now().Add(-time.Hour)
+	for _, st := range []sagalog.Status{sagalog.StatusStarted, sagalog.StatusCompleted} {
+		entry := &sagalog.SagaLog{SagaID: "o1", Status: st, ErrorMessages: "[]", UpdatedAt: at}
+		if st == sagalog.StatusStarted {
+			entry.Payload = trackedPayload(t)
+		}
+		if err := repo.Save(context.Background(), entry); err != nil {
+			t.Fatal(err)
+		}
+		at = at.Add(time.Second)
+	}
+
+	tr := &tracker{}
+	n, err := NewRecoverer(repo, trackedRegistry(tr).Rebuild, CompensateInFlight).Recover(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("Recover = %d, %v, want nothing to recover", n, err)
+	}
+	if len(tr.executed)+len(tr.compensated) > 0 {
+		t.Errorf("finished saga was touched: executed %q, compensated %q", tr.executed, tr.compensated)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name          string
+		history       []*sagalog.SagaLog
+		wantPayload   string
+		wantData      string
+		wantCompleted []string
+	}{
+		{
+			name: "steps done since the start",
+			history: []*sagalog.SagaLog{
+				{Status: sagalog.StatusStarted, Payload: "p1", Data: "d0"},
+				{Status: sagalog.StatusStepDone, CurrentStep: "a", Data: "d1"},
+				{Status: sagalog.StatusStepRetrying, CurrentStep: "b"},
+				{Status: sagalog.StatusStepDone, CurrentStep: "b", Data: "d2"},
+			},
+			wantPayload: "p1", wantData: "d2", wantCompleted: []string{"a", "b"},
+		},
+		{
+			name: "retrying and timed out rows keep the last snapshot",
+			history: []*sagalog.SagaLog{
+				{Status: sagalog.StatusStarted, Payload: "p1", Data: "d0"},
+				{Status: sagalog.StatusStepDone, CurrentStep: "a", Data: "d1"},
+				{Status: sagalog.StatusStepRetrying, CurrentStep: "b"},
+				{Status: sagalog.StatusTimedOut, CurrentStep: "b"},
+			},
+			wantPayload: "p1", wantData: "d1", wantCompleted: []string{"a"},
+		},
+		{
+			name: "a restarted saga forgets the earlier run",
+			history: []*sagalog.SagaLog{
+				{Status: sagalog.StatusStarted, Payload: "p1"},
+				{Status: sagalog.StatusStepDone, CurrentStep: "a", Data: "d1"},
+				{Status: sagalog.StatusFailed},
+				{Status: sagalog.StatusStarted, Payload: "p2"},
+			},
+			wantPayload: "p2", wantData: "", wantCompleted: nil,
+		},
+		{
+			name:    "no STARTED row",
+			history: []*sagalog.SagaLog{{Status: sagalog.StatusStepDone, CurrentStep: "a", Data: "d1"}},
+			// Recovery refuses a saga without a payload.
+			wantPayload: "", wantData: "d1", wantCompleted: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, data, completed := replay(tt.history)
+			if payload != tt.wantPayload || data != tt.wantData || !slices.Equal(completed, tt.wantCompleted) {
+				t.Errorf("replay = %q, %q, %q, want %q, %q, %q",
+					payload, data, completed, tt.wantPayload, tt.wantData, tt.wantCompleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+)
+
+// StepBuilder creates a concrete Step from a saga payload.
+type StepBuilder func(p *Payload) (Step, error)
+
+// Registry maps step names (as returned by Step.Name and stored in
+// Payload.Steps) to the builders that recreate them.
+type Registry struct {
+	builders map[string]StepBuilder
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{builders: make(map[string]StepBuilder)}
+}
+
+// Register adds or replaces the builder for a step name.
+func (r *Registry) Register(name string, build StepBuilder) {
+	r.builders[name] = build
+}
+
+// Build returns the steps listed in p.Steps, in order.
+// It fails if any step name has no registered builder.
+func (r *Registry) Build(p *Payload) ([]Step, error) {
+	steps := make([]Step, 0, len(p.Steps))
+	for _, name := range p.Steps {
+		build, ok := r.builders[name]
+		if !ok {
+			return nil, fmt.Errorf("registry: unknown step %q", name)
+		}
+		step, err := build(p)
+		if err != nil {
+			return nil, fmt.Errorf("registry: build step %q: %w", name, err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// BuildNodes returns the saga graph described by p: the steps listed in
+// p.Steps wired according to p.DependsOn. A payload without DependsOn is
+// treated as a sequential chain.
+func (r *Registry) BuildNodes(p *Payload) ([]Node, error) {
+	steps, err := r.Build(p)
+	if err != nil {
+		return nil, err
+	}
+	if p.DependsOn == nil {
+		return Chain(steps...), nil
+	}
+
+	nodes := make([]Node, len(steps))
+	for i, step := range steps {
+		nodes[i] = Node{Step: step, DependsOn: p.DependsOn[step.Name()]}
+	}
+	return nodes, nil
+}
+
+// Rebuild decodes a stored payload and builds its graph. It satisfies
+// RebuildFunc so a Registry can be handed straight to NewRecoverer.
+func (r *Registry) Rebuild(_ context.Context, _ string, payload string) ([]Node, error) {
+	p, err := DecodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	return r.BuildNodes(p)
+}
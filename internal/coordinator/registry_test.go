@@ -0,0 +1,102 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testRegistry() *Registry {
+	reg := NewRegistry()
+	for _, name := range []string{"a", "b", "c"} {
+		reg.Register(name, func(*Payload) (Step, error) { return testStep{name: name}, nil })
+	}
+	reg.Register("broken", func(*Payload) (Step, error) { return nil, errors.New("no client") })
+	return reg
+}
+
+// graphOf describes nodes as step name -> dependencies.
+func graphOf(nodes []Node) map[string][]string {
+	out := make(map[string][]string, len(nodes))
+	for _, n := range nodes {
+		out[n.Step.Name()] = n.DependsOn
+	}
+	return out
+}
+
+func TestRegistry_Rebuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name:    "graph",
+			payload: `{"order_id":"o1","steps":["a","b","c"],"depends_on":{"b":["a"],"c":["a"]}}`,
+			want:    map[string][]string{"a": nil, "b": {"a"}, "c": {"a"}},
+		},
+		{
+			name:    "written before graphs is a chain",
+			payload: `{"order_id":"o1","steps":["a","b","c"]}`,
+			want:    map[string][]string{"a": nil, "b": {"a"}, "c": {"b"}},
+		},
+		{
+			name:    "unknown step",
+			payload: `{"order_id":"o1","steps":["a","Retired_Step"]}`,
+			wantErr: `registry: unknown step "Retired_Step"`,
+		},
+		{
+			name:    "step that cannot be built",
+			payload: `{"order_id":"o1","steps":["a","broken"]}`,
+			wantErr: `registry: build step "broken": no client`,
+		},
+		{
+			name:    "invalid payload",
+			payload: `{`,
+			wantErr: "decode saga payload",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := testRegistry().Rebuild(context.Background(), "o1", tt.payload)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Rebuild error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := graphOf(nodes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rebuild = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOrderSagaRegistry_RebuildsEveryStep checks that every step an order
+// saga has ever used can be rebuilt, the retired one-shot payment included.
+func TestOrderSagaRegistry_RebuildsEveryStep(t *testing.T) {
+	p := &Payload{
+		OrderID: "o1",
+		Steps: []string{
+			CreateOrderStepName, InventoryStepName, PaymentStepName, PaymentAuthorizeStepName,
+			ConfirmOrderStepName, PaymentCaptureStepName, InventoryCommitStepName,
+		},
+	}
+	nodes, err := NewOrderSagaRegistry(nil, nil, nil).BuildNodes(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.Step.Name())
+	}
+	if !reflect.DeepEqual(names, p.Steps) {
+		t.Errorf("rebuilt %v, want %v", names, p.Steps)
+	}
+}
@@ -0,0 +1,96 @@
+package coordinator
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy controls how often a failing step is executed again before the
+// saga gives up and starts compensating.
+//
+// Only errors carrying a gRPC status whose code is listed in RetryableCodes
+// are retried. Business refusals (e.g. ChargeResponse.Success == false) are
+// plain Go errors without a status, so they always fail the step immediately.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of executions, including the first.
+	// Values <= 1 disable retries.
+	MaxAttempts int
+
+	// BaseBackoff is the delay before the second attempt. It doubles on
+	// every following attempt, capped at MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// Jitter is the fraction (0..1) of each delay that is randomised, so that
+	// many sagas retrying against the same service do not synchronise.
+	Jitter float64
+
+	// RetryableCodes lists the gRPC codes treated as transient.
+	RetryableCodes []codes.Code
+}
+
+// Retryable is implemented by steps that declare a RetryPolicy.
+// Steps that do not implement it are executed exactly once.
+type Retryable interface {
+	RetryPolicy() RetryPolicy
+}
+
+// DefaultRetryPolicy is used by the built-in order saga steps.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseBackoff: 200 * time.Millisecond,
+	MaxBackoff:  2 * time.Second,
+	Jitter:      0.2,
+	RetryableCodes: []codes.Code{
+		codes.Unavailable,
+		codes.DeadlineExceeded,
+		codes.ResourceExhausted,
+		codes.Aborted,
+	},
+}
+
+// retryPolicyOf returns the step's policy, or a single-attempt policy.
+func retryPolicyOf(step Step) RetryPolicy {
+	if r, ok := step.(Retryable); ok {
+		return r.RetryPolicy()
+	}
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// shouldRetry reports whether err is transient according to the policy.
+func (p RetryPolicy) shouldRetry(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	return slices.Contains(p.RetryableCodes, st.Code())
+}
+
+// backoff returns the delay to wait after the given failed attempt (1-based).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"first retry waits the base", RetryPolicy{BaseBackoff: 100 * time.Millisecond}, 1, 100 * time.Millisecond},
+		{"doubles per attempt", RetryPolicy{BaseBackoff: 100 * time.Millisecond}, 3, 400 * time.Millisecond},
+		{"capped at the maximum", RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: 250 * time.Millisecond}, 3, 250 * time.Millisecond},
+		{"below the cap", RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}, 2, 200 * time.Millisecond},
+		{"shift overflow falls back to the cap", RetryPolicy{BaseBackoff: time.Second, MaxBackoff: time.Minute}, 64, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.backoff(tt.attempt); got != tt.want {
+				t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff_JitterShortensTheDelay(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 100 * time.Millisecond, Jitter: 0.2}
+	for range 100 {
+		if d := p.backoff(2); d <= 160*time.Millisecond || d > 200*time.Millisecond {
+			t.Fatalf("backoff(2) = %v, want in (160ms, 200ms]", d)
+		}
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"retryable code", status.Error(codes.Unavailable, "down"), true},
+		{"deadline", status.Error(codes.DeadlineExceeded, "slow"), true},
+		{"other code", status.Error(codes.InvalidArgument, "bad"), false},
+		{"business refusal", errors.New("payment declined"), false},
+		{"plain context error", context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultRetryPolicy.shouldRetry(tt.err); got != tt.want {
+				t.Errorf("shouldRetry(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// retryingStep fails with errs in order, then succeeds.
+type retryingStep struct {
+	testStep
+	policy   RetryPolicy
+	errs     []error
+	attempts int
+}
+
+func (s *retryingStep) Execute(context.Context) error {
+	s.attempts++
+	if s.attempts <= len(s.errs) {
+		return s.errs[s.attempts-1]
+	}
+	return nil
+}
+
+func (s *retryingStep) RetryPolicy() RetryPolicy { return s.policy }
+
+func TestExecute_Retries(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		BaseBackoff:    time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      bool
+		wantAttempts int
+		wantRetrying int
+	}{
+		{"succeeds first time", nil, false, 1, 0},
+		{"transient failures then success", []error{unavailable, unavailable}, false, 3, 2},
+		{"gives up after the last attempt", []error{unavailable, unavailable, unavailable}, true, 3, 2},
+		{"permanent failure is not retried", []error{errors.New("declined")}, true, 1, 0},
+		{"stops at a permanent failure", []error{unavailable, status.Error(codes.InvalidArgument, "bad")}, true, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &retryingStep{testStep: testStep{name: "flaky"}, policy: policy, errs: tt.errs}
+			rec := &recorder{}
+			err := NewOrchestrator("saga-1", []Step{step}, nil, WithEventHandler(rec.handle)).Start(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("saga error = %v, want error %v", err, tt.wantErr)
+			}
+			if step.attempts != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", step.attempts, tt.wantAttempts)
+			}
+			retrying := 0
+			for _, st := range rec.statuses() {
+				if st == sagalog.StatusStepRetrying {
+					retrying++
+				}
+			}
+			if retrying != tt.wantRetrying {
+				t.Errorf("%d STEP_RETRYING entries, want %d", retrying, tt.wantRetrying)
+			}
+		})
+	}
+}
+
+func TestExecute_StopsRetryingWhenTheSagaTimesOut(t *testing.T) {
+	step := &retryingStep{
+		testStep: testStep{name: "flaky"},
+		policy: RetryPolicy{
+			MaxAttempts:    5,
+			BaseBackoff:    time.Hour,
+			RetryableCodes: []codes.Code{codes.Unavailable},
+		},
+		errs: []error{status.Error(codes.Unavailable, "down")},
+	}
+	rec := &recorder{}
+	err := NewOrchestrator("saga-1", []Step{step}, nil,
+		WithSagaTimeout(10*time.Millisecond), WithEventHandler(rec.handle),
+	).Start(context.Background())
+
+	if !errors.Is(err, ErrSagaTimedOut) {
+		t.Fatalf("saga error = %v, want ErrSagaTimedOut", err)
+	}
+	if step.attempts != 1 {
+		t.Errorf("%d attempts, want 1", step.attempts)
+	}
+	if got := rec.statuses(); !slices.Contains(got, sagalog.StatusTimedOut) {
+		t.Errorf("statuses %v, want TIMED_OUT", got)
+	}
+}
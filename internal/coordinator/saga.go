@@ -2,7 +2,14 @@ package coordinator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
 )
@@ -15,97 +22,467 @@ type Step interface {
 	Compensate(ctx context.Context) error
 }
 
-// Orchestrator manages the sequential execution of a collection of Steps.
-// On failure, it triggers compensation in reverse order (LIFO).
+// FailureHandler is called once a saga has failed and its completed steps
+// have been compensated, just before the FAILED status is written.
+// Typical use: mark the business entity (e.g. the order) as cancelled.
+type FailureHandler func(ctx context.Context, sagaID string, err error)
+
+// Option configures optional Orchestrator behaviour.
+type Option func(*Orchestrator)
+
+// WithPayload sets the saga input stored on the STARTED log row. Its Steps
+// and DependsOn fields are overwritten with the orchestrator's graph, so a
+// Registry can rebuild exactly the same saga from the log.
+func WithPayload(p *Payload) Option {
+	return func(o *Orchestrator) {
+		o.payload = p
+	}
+}
+
+// WithFailureHandler registers a hook that runs after a failed saga has been
+// compensated.
+func WithFailureHandler(h FailureHandler) Option {
+	return func(o *Orchestrator) {
+		o.onFailure = h
+	}
+}
+
+// WithCompletionHandler registers a hook that runs after every step of the
+// saga has succeeded.
+func WithCompletionHandler(h CompletionHandler) Option {
+	return func(o *Orchestrator) {
+		o.onComplete = h
+	}
+}
+
+// WithEventHandler publishes every saga transition to h, e.g. to stream
+// progress to clients.
+func WithEventHandler(h EventHandler) Option {
+	return func(o *Orchestrator) {
+		o.onEvent = h
+	}
+}
+
+// WithCompensationQueue makes compensation failures durable: instead of
+// only being logged, they are enqueued for a CompensationWorker to retry.
+func WithCompensationQueue(q sagalog.CompensationQueue) Option {
+	return func(o *Orchestrator) {
+		o.queue = q
+	}
+}
+
+// WithSagaTimeout bounds the whole saga run. Once d has elapsed, running
+// steps are cancelled, TIMED_OUT is recorded and the completed steps are
+// compensated. Zero (the default) means no deadline.
+func WithSagaTimeout(d time.Duration) Option {
+	return func(o *Orchestrator) {
+		o.timeout = d
+	}
+}
+
+// WithData seeds the saga's shared data bag. Steps read and write it through
+// DataFromContext; without this option the saga starts with an empty bag.
+func WithData(d *Data) Option {
+	return func(o *Orchestrator) {
+		o.data = d
+	}
+}
+
+// withEncodedPayload sets the already-serialised payload. The Recoverer uses
+// it so that compensations it enqueues can still be rebuilt later.
+func withEncodedPayload(payload string) Option {
+	return func(o *Orchestrator) {
+		o.encoded = payload
+	}
+}
+
+// Orchestrator manages the execution of a saga's Steps. Steps run as soon as
+// the steps they depend on have completed: a plain []Step runs sequentially,
+// while a graph built with Groups or explicit Nodes runs independent
+// branches concurrently. On failure, it triggers compensation of every
+// completed step in reverse completion order (LIFO), which is always a
+// reverse topological order.
 //
 // If a sagalog.Repository is provided, every state transition is persisted
 // to the Saga Log so you can audit, debug, and recover sagas.
 type Orchestrator struct {
-	sagaID string
-	steps  []Step
-	log    sagalog.Repository // nil-safe: logging is skipped if nil
+	sagaID     string
+	nodes      []Node                    // topologically sorted
+	steps      []Step                    // the nodes' steps, same order
+	log        sagalog.Repository        // nil-safe: logging is skipped if nil
+	payload    *Payload                  // nil-safe: STARTED row carries no payload if nil
+	encoded    string                    // payload as written to the log
+	onFailure  FailureHandler            // nil-safe
+	onComplete CompletionHandler         // nil-safe
+	onEvent    EventHandler              // nil-safe
+	queue      sagalog.CompensationQueue // nil-safe: failures are only logged if nil
+	data       *Data                     // shared by all steps, never nil
+	timeout    time.Duration             // whole-saga deadline, 0 = none
 }
 
-// NewOrchestrator creates a new Orchestrator.
+// NewOrchestrator creates a new Orchestrator that runs steps sequentially.
 //
 //   - sagaID: the business identifier (typically the order ID). Used as the
 //     primary key in the saga_logs table.
 //   - repo: the saga log repository. Pass nil to disable logging (e.g. in tests).
-func NewOrchestrator(sagaID string, steps []Step, repo sagalog.Repository) *Orchestrator {
-	return &Orchestrator{
+//   - opts: optional behaviour such as WithPayload, WithFailureHandler or
+//     WithCompensationQueue.
+func NewOrchestrator(sagaID string, steps []Step, repo sagalog.Repository, opts ...Option) *Orchestrator {
+	// A chain is always a valid graph, so the error can be ignored.
+	o, _ := NewGraphOrchestrator(sagaID, Chain(steps...), repo, opts...)
+	return o
+}
+
+// NewGraphOrchestrator creates an Orchestrator for a dependency graph of
+// steps. It returns an error if a step name is duplicated, a dependency is
+// unknown or the graph contains a cycle.
+func NewGraphOrchestrator(sagaID string, nodes []Node, repo sagalog.Repository, opts ...Option) (*Orchestrator, error) {
+	sorted, err := sortNodes(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Orchestrator{
 		sagaID: sagaID,
-		steps:  steps,
+		nodes:  sorted,
+		steps:  make([]Step, len(sorted)),
 		log:    repo,
+		data:   NewData(),
+	}
+	for i, n := range sorted {
+		o.steps[i] = n.Step
+	}
+	for _, opt := range opts {
+		opt(o)
 	}
+	return o, nil
 }
 
-// Start runs the saga steps sequentially.
-// If a step fails, it triggers the compensation of all previously successful
-// steps in reverse order and returns the original error.
+// Start runs the saga.
+// If a step fails, the branches still running are cancelled and every
+// previously successful step is compensated in reverse order; the original
+// error is returned.
 func (o *Orchestrator) Start(ctx context.Context) error {
-	o.saveLog(ctx, sagalog.StatusStarted, "", "", nil)
+	o.saveLog(ctx, sagalog.StatusStarted, "", o.encodePayload(ctx), nil)
+	return o.run(ctx, nil)
+}
 
-	var completed []Step
-	var errors []string
+// Resume continues a saga that was interrupted after the given steps had
+// completed. Completed steps are skipped; pending steps are executed again,
+// so steps must be idempotent (all our services are, keyed by order ID).
+func (o *Orchestrator) Resume(ctx context.Context, completed []string) error {
+	slog.InfoContext(ctx, "resuming saga", "saga_id", o.sagaID, "completed_steps", completed)
+	return o.run(ctx, completed)
+}
+
+// Compensate rolls back a saga that was interrupted after the given steps had
+// completed, without executing any pending step. Steps whose dependencies
+// had all completed may have been running when the saga stopped, and may
+// have taken effect without logging it, so they are compensated too, before
+// the completed ones; compensations must therefore be safe for a step that
+// never ran. cause is recorded in the log.
+func (o *Orchestrator) Compensate(ctx context.Context, completed []string, cause error) error {
+	slog.InfoContext(ctx, "compensating interrupted saga", "saga_id", o.sagaID, "completed_steps", completed)
+	ctx = ContextWithData(ctx, o.data)
 
+	done := make(map[string]bool, len(completed))
+	for _, name := range completed {
+		done[name] = true
+	}
+	var steps, inFlight []Step
+	for _, n := range o.nodes {
+		switch {
+		case done[n.Step.Name()]:
+			steps = append(steps, n.Step)
+		case depsMet(n, done):
+			inFlight = append(inFlight, n.Step)
+		}
+	}
+	// rollback runs from the end, so the in-flight steps go last.
+	steps = append(steps, inFlight...)
+
+	o.fail(ctx, "", steps, []string{cause.Error()}, cause)
+	return cause
+}
+
+// stepResult is sent by a branch goroutine when its step finishes.
+type stepResult struct {
+	step Step
+	err  error
+}
+
+// run executes every step not listed in skip, each as soon as its
+// dependencies are done. Independent steps run in their own goroutines.
+func (o *Orchestrator) run(ctx context.Context, skip []string) error {
+	ctx = ContextWithData(ctx, o.data)
+
+	// sagaCtx carries the whole-saga deadline. ctx itself stays free of it
+	// so that compensation can still run after the deadline has passed.
+	sagaCtx, cancelSaga := withTimeout(ctx, o.timeout)
+	defer cancelSaga()
+
+	done := make(map[string]bool, len(o.nodes))
+	var completed []Step
 	for _, step := range o.steps {
-		slog.InfoContext(ctx, "executing saga step", "saga_id", o.sagaID, "step", step.Name())
+		if slices.Contains(skip, step.Name()) {
+			done[step.Name()] = true
+			completed = append(completed, step)
+		}
+	}
 
-		if err := step.Execute(ctx); err != nil {
-			slog.ErrorContext(ctx, "saga step failed, starting rollback",
-				"saga_id", o.sagaID,
-				"step", step.Name(),
-				"error", err,
-			)
-			errors = append(errors, err.Error())
-			o.saveLog(ctx, sagalog.StatusCompensating, step.Name(), "", errors)
-			o.rollback(ctx, completed, errors)
-			o.saveLog(ctx, sagalog.StatusFailed, step.Name(), "", errors)
-			return err
+	// Cancelling branchCtx stops sibling branches once one of them fails.
+	branchCtx, cancel := context.WithCancel(sagaCtx)
+	defer cancel()
+
+	results := make(chan stepResult)
+	started := make(map[string]bool, len(o.nodes))
+	running := 0
+
+	var failed error
+	var failedStep string
+	var failures []string
+
+	for {
+		if failed == nil {
+			for _, n := range o.nodes {
+				name := n.Step.Name()
+				if done[name] || started[name] || !depsMet(n, done) {
+					continue
+				}
+				started[name] = true
+				running++
+				slog.InfoContext(ctx, "executing saga step", "saga_id", o.sagaID, "step", name)
+				go func(step Step) {
+					results <- stepResult{step: step, err: o.execute(branchCtx, step)}
+				}(n.Step)
+			}
+		}
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		name := res.step.Name()
+
+		if res.err != nil {
+			if failed == nil {
+				slog.ErrorContext(ctx, "saga step failed, starting rollback",
+					"saga_id", o.sagaID,
+					"step", name,
+					"error", res.err,
+				)
+				failed, failedStep = res.err, name
+				failures = append(failures, res.err.Error())
+				cancel()
+			} else if branchCtx.Err() == nil || !isCancellation(res.err) {
+				failures = append(failures, res.err.Error())
+			}
+			continue
 		}
 
-		slog.InfoContext(ctx, "saga step completed", "saga_id", o.sagaID, "step", step.Name())
-		o.saveLog(ctx, sagalog.StatusStepDone, step.Name(), "", nil)
-		completed = append(completed, step)
+		slog.InfoContext(ctx, "saga step completed", "saga_id", o.sagaID, "step", name)
+		o.saveLog(ctx, sagalog.StatusStepDone, name, "", nil)
+		done[name] = true
+		completed = append(completed, res.step)
 	}
 
+	if failed != nil {
+		if sagaCtx.Err() == context.DeadlineExceeded {
+			slog.ErrorContext(ctx, "saga timed out", "saga_id", o.sagaID, "step", failedStep, "error", failed)
+			o.saveLog(ctx, sagalog.StatusTimedOut, failedStep, "", failures)
+			// Compensation overwrites TIMED_OUT, so the timeout is kept as
+			// the reason of the FAILED status that ends the saga.
+			failed = fmt.Errorf("%w: %w", ErrSagaTimedOut, failed)
+			failures[0] = failed.Error()
+		}
+		o.fail(ctx, failedStep, completed, failures, failed)
+		return failed
+	}
+
+	if o.onComplete != nil {
+		o.onComplete(ctx, o.sagaID)
+	}
 	o.saveLog(ctx, sagalog.StatusCompleted, "", "", nil)
 	slog.InfoContext(ctx, "saga completed successfully", "saga_id", o.sagaID)
 	return nil
 }
 
-// rollback compensates all completed steps in reverse order (LIFO).
-func (o *Orchestrator) rollback(ctx context.Context, steps []Step, errs []string) {
+// isCancellation reports whether err was caused by a cancelled context,
+// either directly or as a gRPC Canceled status.
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
+}
+
+// execute runs a step, retrying transient failures according to the step's
+// RetryPolicy. Each attempt is bounded by the step's execution timeout.
+// Every failed attempt that will be retried is recorded in the saga log as
+// STEP_RETRYING.
+func (o *Orchestrator) execute(ctx context.Context, step Step) error {
+	policy := retryPolicyOf(step)
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := withTimeout(ctx, executeTimeoutOf(step))
+		err := step.Execute(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(err) {
+			return err
+		}
+
+		delay := policy.backoff(attempt)
+		slog.WarnContext(ctx, "saga step failed, retrying",
+			"saga_id", o.sagaID,
+			"step", step.Name(),
+			"attempt", attempt,
+			"max_attempts", policy.MaxAttempts,
+			"backoff", delay,
+			"error", err,
+		)
+		o.saveLog(ctx, sagalog.StatusStepRetrying, step.Name(), "",
+			[]string{fmt.Sprintf("attempt %d/%d failed: %v", attempt, policy.MaxAttempts, err)})
+
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// fail compensates the completed steps, runs the failure hook and records
+// the terminal FAILED status.
+func (o *Orchestrator) fail(ctx context.Context, failedStep string, completed []Step, failures []string, cause error) {
+	o.saveLog(ctx, sagalog.StatusCompensating, failedStep, "", failures)
+	failures = o.rollback(ctx, completed, failures)
+	if o.onFailure != nil {
+		o.onFailure(ctx, o.sagaID, cause)
+	}
+	o.saveLog(ctx, sagalog.StatusFailed, failedStep, "", failures)
+}
+
+// rollback compensates all completed steps in reverse order (LIFO), each
+// bounded by its compensation timeout.
+// It returns errs extended with any compensation failures.
+func (o *Orchestrator) rollback(ctx context.Context, steps []Step, errs []string) []string {
 	for i := len(steps) - 1; i >= 0; i-- {
 		step := steps[i]
 		slog.InfoContext(ctx, "compensating saga step", "saga_id", o.sagaID, "step", step.Name())
 
-		if err := step.Compensate(ctx); err != nil {
+		compCtx, cancel := withTimeout(ctx, compensateTimeoutOf(step))
+		err := step.Compensate(compCtx)
+		cancel()
+		if err != nil {
 			slog.ErrorContext(ctx, "CRITICAL: compensation failed",
 				"saga_id", o.sagaID,
 				"step", step.Name(),
 				"error", err,
 			)
 			errs = append(errs, "compensation of "+step.Name()+" failed: "+err.Error())
+			o.enqueueCompensation(ctx, step, err)
 		}
 	}
+	return errs
 }
 
-// saveLog persists a saga log entry. It is a no-op if no repository was provided.
-// Errors are logged but never returned — a logging failure must never abort the saga.
-func (o *Orchestrator) saveLog(ctx context.Context, status sagalog.Status, step, payload string, errs []string) {
-	if o.log == nil {
+// enqueueCompensation hands a failed compensation to the durable retry queue.
+// It is a no-op if no queue was provided.
+func (o *Orchestrator) enqueueCompensation(ctx context.Context, step Step, cause error) {
+	if o.queue == nil {
 		return
 	}
 
-	entry := sagalog.NewEntry(ctx, o.sagaID, status, step, payload, errs)
-
-	if err := o.log.Save(ctx, entry); err != nil {
-		// Non-fatal: the saga must continue even if the audit log fails.
-		slog.WarnContext(ctx, "failed to save saga log entry",
+	c := &sagalog.Compensation{
+		SagaID:    o.sagaID,
+		Step:      step.Name(),
+		Payload:   o.encoded,
+		Data:      o.encodeData(ctx),
+		Attempts:  1,
+		LastError: cause.Error(),
+	}
+	if err := o.queue.Enqueue(ctx, c); err != nil {
+		slog.ErrorContext(ctx, "CRITICAL: failed to enqueue compensation for retry",
 			"saga_id", o.sagaID,
-			"status", status,
+			"step", step.Name(),
 			"error", err,
 		)
+		return
+	}
+	slog.WarnContext(ctx, "compensation queued for retry",
+		"saga_id", o.sagaID,
+		"step", step.Name(),
+		"compensation_id", c.ID,
+	)
+}
+
+// encodePayload stamps the step names and dependencies onto the payload and
+// serialises it.
+// Encoding errors are logged and yield an empty payload: the saga still runs,
+// it just cannot be recovered from the log.
+func (o *Orchestrator) encodePayload(ctx context.Context) string {
+	if o.payload == nil {
+		return ""
+	}
+
+	o.payload.Steps = make([]string, len(o.nodes))
+	o.payload.DependsOn = make(map[string][]string, len(o.nodes))
+	for i, n := range o.nodes {
+		o.payload.Steps[i] = n.Step.Name()
+		if len(n.DependsOn) > 0 {
+			o.payload.DependsOn[n.Step.Name()] = n.DependsOn
+		}
+	}
+
+	s, err := o.payload.Encode()
+	if err != nil {
+		slog.WarnContext(ctx, "failed to encode saga payload", "saga_id", o.sagaID, "error", err)
+		return ""
+	}
+	o.encoded = s
+	return s
+}
+
+// encodeData snapshots the shared data bag. Encoding errors are logged and
+// yield an empty snapshot.
+func (o *Orchestrator) encodeData(ctx context.Context) string {
+	s, err := o.data.Encode()
+	if err != nil {
+		slog.WarnContext(ctx, "failed to encode saga data", "saga_id", o.sagaID, "error", err)
+		return ""
+	}
+	return s
+}
+
+// saveLog persists a saga log entry and then publishes it to the event
+// handler, so subscribers never see a transition the log does not have yet.
+// Persisting is skipped if no repository was provided.
+// STARTED and STEP_DONE entries carry a snapshot of the shared data bag, so
+// recovery can restore it as of the last completed step.
+// Errors are logged but never returned — a logging failure must never abort the saga.
+func (o *Orchestrator) saveLog(ctx context.Context, status sagalog.Status, step, payload string, errs []string) {
+	entry := sagalog.NewEntry(ctx, o.sagaID, status, step, payload, errs)
+
+	if o.log != nil {
+		if status == sagalog.StatusStarted || status == sagalog.StatusStepDone {
+			entry.Data = o.encodeData(ctx)
+		}
+		if err := o.log.Save(ctx, entry); err != nil {
+			// Non-fatal: the saga must continue even if the audit log fails.
+			slog.WarnContext(ctx, "failed to save saga log entry",
+				"saga_id", o.sagaID,
+				"status", status,
+				"error", err,
+			)
+		}
+	}
+
+	if o.onEvent != nil {
+		o.onEvent(ctx, Event{
+			SagaID: o.sagaID,
+			Status: status,
+			Step:   step,
+			Errors: errs,
+			At:     entry.UpdatedAt,
+		})
 	}
 }
@@ -0,0 +1,276 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+)
+
+// testStep runs execute, or succeeds when it is nil, and records its
+// compensation.
+type testStep struct {
+	name        string
+	execute     func(ctx context.Context) error
+	compensated *[]string
+	mu          *sync.Mutex
+}
+
+func (s testStep) Name() string { return s.name }
+
+func (s testStep) Execute(ctx context.Context) error {
+	if s.execute == nil {
+		return nil
+	}
+	return s.execute(ctx)
+}
+
+func (s testStep) Compensate(context.Context) error {
+	if s.compensated != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		*s.compensated = append(*s.compensated, s.name)
+	}
+	return nil
+}
+
+// recorder collects the events of a saga.
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) handle(_ context.Context, e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) statuses() []sagalog.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []sagalog.Status
+	for _, e := range r.events {
+		out = append(out, e.Status)
+	}
+	return out
+}
+
+func (r *recorder) last() Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events[len(r.events)-1]
+}
+
+func blockUntilDone(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestStart_TimeoutIsTheReasonOfTheFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		execute      func(ctx context.Context) error
+		timeout      time.Duration
+		wantTimedOut bool
+	}{
+		{"saga deadline exceeded", blockUntilDone, 10 * time.Millisecond, true},
+		{"step returned a deadline error", func(context.Context) error { return context.DeadlineExceeded }, 0, false},
+		{"downstream call timed out", func(context.Context) error { return status.Error(codes.DeadlineExceeded, "slow") }, 0, false},
+		{"step failed", func(context.Context) error { return errors.New("declined") }, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu          sync.Mutex
+				compensated []string
+				failureErr  error
+			)
+			rec := &recorder{}
+			steps := []Step{
+				testStep{name: "first", compensated: &compensated, mu: &mu},
+				testStep{name: "second", execute: tt.execute, compensated: &compensated, mu: &mu},
+			}
+			err := NewOrchestrator("saga-1", steps, nil,
+				WithSagaTimeout(tt.timeout),
+				WithEventHandler(rec.handle),
+				WithFailureHandler(func(_ context.Context, _ string, err error) { failureErr = err }),
+			).Start(context.Background())
+
+			if err == nil {
+				t.Fatal("saga succeeded, want an error")
+			}
+			if got := errors.Is(err, ErrSagaTimedOut); got != tt.wantTimedOut {
+				t.Errorf("errors.Is(%v, ErrSagaTimedOut) = %v, want %v", err, got, tt.wantTimedOut)
+			}
+			if !errors.Is(failureErr, err) {
+				t.Errorf("failure hook got %v, want %v", failureErr, err)
+			}
+
+			want := []sagalog.Status{sagalog.StatusStarted, sagalog.StatusStepDone}
+			if tt.wantTimedOut {
+				want = append(want, sagalog.StatusTimedOut)
+			}
+			want = append(want, sagalog.StatusCompensating, sagalog.StatusFailed)
+			if got := rec.statuses(); !slices.Equal(got, want) {
+				t.Errorf("statuses %v, want %v", got, want)
+			}
+
+			last := rec.last()
+			if len(last.Errors) == 0 || last.Errors[0] != err.Error() {
+				t.Errorf("FAILED errors %q, want the saga error %q first", last.Errors, err)
+			}
+			if got := strings.HasPrefix(last.Errors[0], ErrSagaTimedOut.Error()); got != tt.wantTimedOut {
+				t.Errorf("FAILED reason %q names the timeout: %v, want %v", last.Errors[0], got, tt.wantTimedOut)
+			}
+			if !slices.Equal(compensated, []string{"first"}) {
+				t.Errorf("compensated %v, want [first]", compensated)
+			}
+		})
+	}
+}
+
+// slowStep is a testStep with an execution timeout.
+type slowStep struct {
+	testStep
+	timeout time.Duration
+}
+
+func (s slowStep) ExecuteTimeout() time.Duration { return s.timeout }
+
+func (s slowStep) CompensateTimeout() time.Duration { return 0 }
+
+func TestStart_StepTimeoutIsAStepFailure(t *testing.T) {
+	rec := &recorder{}
+	steps := []Step{slowStep{testStep: testStep{name: "slow", execute: blockUntilDone}, timeout: 10 * time.Millisecond}}
+	err := NewOrchestrator("saga-1", steps, nil,
+		WithSagaTimeout(time.Minute),
+		WithEventHandler(rec.handle),
+	).Start(context.Background())
+
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrSagaTimedOut) {
+		t.Fatalf("saga error = %v, want the step's deadline without %v", err, ErrSagaTimedOut)
+	}
+	if got := rec.statuses(); slices.Contains(got, sagalog.StatusTimedOut) {
+		t.Errorf("statuses %v, want no %s", got, sagalog.StatusTimedOut)
+	}
+}
+
+func TestStart_RunsIndependentStepsConcurrently(t *testing.T) {
+	aStarted, bStarted := make(chan struct{}), make(chan struct{})
+	// Each step waits for the other to start, so running them one after
+	// the other would time out.
+	waitFor := func(mine, other chan struct{}) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			close(mine)
+			select {
+			case <-other:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	nodes := Groups(
+		[]Step{testStep{name: "a", execute: waitFor(aStarted, bStarted)}, testStep{name: "b", execute: waitFor(bStarted, aStarted)}},
+		[]Step{testStep{name: "c"}},
+	)
+	saga, err := NewGraphOrchestrator("saga-1", nodes, nil, WithSagaTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := saga.Start(context.Background()); err != nil {
+		t.Fatalf("saga error = %v, want both branches to run at once", err)
+	}
+}
+
+func TestStart_FailureStopsTheGraph(t *testing.T) {
+	errCharge := errors.New("charge declined")
+
+	tests := []struct {
+		name string
+		// sibling runs next to the failing step, after it has started to
+		// fail.
+		sibling         func(ctx context.Context, failing <-chan struct{}) error
+		wantCompensated []string
+		wantErrors      []string
+	}{
+		{
+			name: "running sibling is cancelled",
+			sibling: func(ctx context.Context, _ <-chan struct{}) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantCompensated: []string{"a"},
+			wantErrors:      []string{errCharge.Error()},
+		},
+		{
+			name: "sibling that completes anyway is compensated",
+			sibling: func(_ context.Context, failing <-chan struct{}) error {
+				<-failing
+				return nil
+			},
+			wantCompensated: []string{"b", "a"},
+			wantErrors:      []string{errCharge.Error()},
+		},
+		{
+			name: "sibling failing for another reason is recorded",
+			sibling: func(ctx context.Context, _ <-chan struct{}) error {
+				<-ctx.Done()
+				return errors.New("reservation lost")
+			},
+			wantCompensated: []string{"a"},
+			wantErrors:      []string{errCharge.Error(), "reservation lost"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var compensated []string
+			failing := make(chan struct{})
+			ranLast := false
+
+			nodes := []Node{
+				{Step: testStep{name: "a", compensated: &compensated, mu: &mu}},
+				{Step: testStep{name: "b", compensated: &compensated, mu: &mu, execute: func(ctx context.Context) error {
+					return tt.sibling(ctx, failing)
+				}}, DependsOn: []string{"a"}},
+				{Step: testStep{name: "c", compensated: &compensated, mu: &mu, execute: func(context.Context) error {
+					close(failing)
+					return errCharge
+				}}, DependsOn: []string{"a"}},
+				{Step: testStep{name: "d", compensated: &compensated, mu: &mu, execute: func(context.Context) error {
+					ranLast = true
+					return nil
+				}}, DependsOn: []string{"b", "c"}},
+			}
+			rec := &recorder{}
+			saga, err := NewGraphOrchestrator("saga-1", nodes, nil, WithEventHandler(rec.handle))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := saga.Start(context.Background()); !errors.Is(err, errCharge) {
+				t.Fatalf("saga error = %v, want %v", err, errCharge)
+			}
+			if ranLast {
+				t.Error("d ran after one of its dependencies failed")
+			}
+			if !slices.Equal(compensated, tt.wantCompensated) {
+				t.Errorf("compensated %v, want %v", compensated, tt.wantCompensated)
+			}
+			last := rec.last()
+			if last.Status != sagalog.StatusFailed || last.Step != "c" || !slices.Equal(last.Errors, tt.wantErrors) {
+				t.Errorf("last event %+v, want FAILED at c with errors %q", last, tt.wantErrors)
+			}
+		})
+	}
+}
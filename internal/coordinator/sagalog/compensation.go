@@ -0,0 +1,72 @@
+package sagalog
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by read methods when the requested row does not exist.
+var ErrNotFound = errors.New("sagalog: not found")
+
+// Compensation is a compensating action that failed during rollback and must
+// be retried until it succeeds. It carries the saga payload so the step can
+// be rebuilt from a Registry long after the original saga has finished.
+type Compensation struct {
+	// ID is assigned by the queue on Enqueue.
+	ID int64
+
+	SagaID string
+
+	// Step is the name of the step whose Compensate call failed.
+	Step string
+
+	// Payload is the saga payload from the STARTED row.
+	Payload string
+
+	// Data is the snapshot of the saga's shared data bag at the time the
+	// compensation failed. It is restored before retrying.
+	Data string
+
+	// Attempts counts failed compensation attempts, including the original one.
+	Attempts int
+
+	// LastError is the error returned by the most recent attempt.
+	LastError string
+
+	// NextAttemptAt is when the worker may try again.
+	NextAttemptAt time.Time
+
+	CreatedAt time.Time
+
+	// DeadAt is set once the compensation has been moved to the dead-letter
+	// table; zero while it is still queued.
+	DeadAt time.Time
+}
+
+// CompensationQueue is the port for the durable retry queue of failed
+// compensations and its dead-letter table.
+type CompensationQueue interface {
+	// Enqueue stores a failed compensation. c.ID is set on success.
+	Enqueue(ctx context.Context, c *Compensation) error
+
+	// Due returns up to limit queued compensations whose NextAttemptAt is
+	// not after now, oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]*Compensation, error)
+
+	// Reschedule records another failed attempt.
+	Reschedule(ctx context.Context, id int64, attempts int, lastErr string, next time.Time) error
+
+	// Complete removes a compensation that finally succeeded.
+	Complete(ctx context.Context, id int64) error
+
+	// DeadLetter moves a compensation out of the queue into the dead-letter table.
+	DeadLetter(ctx context.Context, id int64, attempts int, lastErr string) error
+
+	// ListDeadLetters returns every dead-lettered compensation, oldest first.
+	ListDeadLetters(ctx context.Context) ([]*Compensation, error)
+
+	// Redrive moves a dead-lettered compensation back into the queue with
+	// its attempt counter reset. Returns ErrNotFound for unknown IDs.
+	Redrive(ctx context.Context, id int64) error
+}
@@ -18,11 +18,28 @@ type Status string
 const (
 	StatusStarted      Status = "STARTED"
 	StatusStepDone     Status = "STEP_DONE"
+	StatusStepRetrying Status = "STEP_RETRYING"
 	StatusCompleted    Status = "COMPLETED"
 	StatusCompensating Status = "COMPENSATING"
+	StatusTimedOut     Status = "TIMED_OUT"
 	StatusFailed       Status = "FAILED"
 )
 
+// InFlightStatuses lists the statuses a saga can be left in when the process
+// dies before reaching COMPLETED or FAILED. Recovery scans for these.
+var InFlightStatuses = []Status{StatusStarted, StatusStepDone, StatusStepRetrying, StatusTimedOut, StatusCompensating}
+
+// IsInFlight reports whether a saga whose latest status is s has not yet
+// reached a terminal state.
+func (s Status) IsInFlight() bool {
+	for _, st := range InFlightStatuses {
+		if s == st {
+			return true
+		}
+	}
+	return false
+}
+
 // SagaLog is a single row in the saga_logs table.
 // It captures a point-in-time snapshot of a saga execution.
 type SagaLog struct {
@@ -40,6 +57,10 @@ type SagaLog struct {
 	// Stored once at creation so the saga can be replayed from the log.
 	Payload string
 
+	// Data is a JSON snapshot of the saga's shared data bag, written on
+	// STARTED and STEP_DONE entries. Empty on other entries.
+	Data string
+
 	// ErrorMessages accumulates failure details, one per failed step.
 	// Stored as a JSON array: ["step X failed: ...", "compensation of Y failed: ..."]
 	ErrorMessages string
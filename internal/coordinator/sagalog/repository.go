@@ -1,6 +1,15 @@
 package sagalog
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// DefaultListLimit is the page size List uses when Filter.Limit is zero.
+const DefaultListLimit = 50
+
+// MaxListLimit caps Filter.Limit so a single query cannot scan the whole log.
+const MaxListLimit = 500
 
 // Repository is the port (interface) for persisting saga log entries.
 // The coordinator depends on this abstraction, not on SQLite directly,
@@ -9,4 +18,48 @@ type Repository interface {
 	// Save persists a new log entry. Each call appends a row; the table is
 	// an append-only audit log, not an upsert.
 	Save(ctx context.Context, entry *SagaLog) error
+
+	// GetLatest returns the most recent entry for a saga, i.e. its current
+	// state. Returns ErrNotFound for unknown saga IDs.
+	GetLatest(ctx context.Context, sagaID string) (*SagaLog, error)
+
+	// History returns every entry for a saga, oldest first. An unknown saga
+	// ID yields an empty slice.
+	History(ctx context.Context, sagaID string) ([]*SagaLog, error)
+
+	// List returns the current state of every saga matching filter, most
+	// recently started first, one page at a time. A saga keeps its place
+	// across pages however many transitions it records in between.
+	List(ctx context.Context, filter Filter) (*Page, error)
+
+	// FindByTraceID returns every entry written under the given OTel trace
+	// ID, oldest first.
+	FindByTraceID(ctx context.Context, traceID string) ([]*SagaLog, error)
+}
+
+// Filter selects sagas by their current (latest) entry. Zero values mean
+// "no constraint".
+type Filter struct {
+	// Statuses keeps only sagas whose current status is one of these.
+	Statuses []Status
+
+	// From and To bound the time of the latest transition: From is
+	// inclusive, To is exclusive.
+	From time.Time
+	To   time.Time
+
+	// Limit is the page size, DefaultListLimit when zero and capped at
+	// MaxListLimit.
+	Limit int
+
+	// Cursor continues a previous List call; pass Page.NextCursor.
+	Cursor string
+}
+
+// Page is one page of List results.
+type Page struct {
+	Sagas []*SagaLog
+
+	// NextCursor is empty on the last page.
+	NextCursor string
 }
@@ -0,0 +1,244 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/sqlitetime"
+)
+
+// Ensure Repository implements the compensation queue port at compile time.
+var _ sagalog.CompensationQueue = (*Repository)(nil)
+
+// compensationSchema holds the retry queue and dead-letter table for failed
+// compensations. They live next to saga_logs so a single file backs all
+// saga state.
+const compensationSchema = `
+CREATE TABLE IF NOT EXISTS compensation_queue (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    saga_id          TEXT    NOT NULL,
+
+    -- Name of the step whose Compensate call failed.
+    step             TEXT    NOT NULL,
+
+    -- Saga payload (copied from the STARTED row) used to rebuild the step.
+    payload          TEXT    NOT NULL DEFAULT '',
+
+    -- Snapshot of the saga's shared data bag when the compensation failed.
+    data             TEXT    NOT NULL DEFAULT '',
+
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    last_error       TEXT    NOT NULL DEFAULT '',
+    next_attempt_at  TEXT    NOT NULL,
+    created_at       TEXT    NOT NULL
+);
+
+-- Index for the worker's polling query: "what is due now?".
+CREATE INDEX IF NOT EXISTS idx_compensation_queue_next ON compensation_queue(next_attempt_at);
+
+-- Compensations that exhausted their attempts. Operators inspect this table
+-- and re-drive rows back into the queue once the root cause is fixed.
+CREATE TABLE IF NOT EXISTS compensation_dead_letters (
+    id               INTEGER PRIMARY KEY,
+    saga_id          TEXT    NOT NULL,
+    step             TEXT    NOT NULL,
+    payload          TEXT    NOT NULL DEFAULT '',
+    data             TEXT    NOT NULL DEFAULT '',
+    attempts         INTEGER NOT NULL,
+    last_error       TEXT    NOT NULL DEFAULT '',
+    created_at       TEXT    NOT NULL,
+    dead_at          TEXT    NOT NULL
+);
+`
+
+// Enqueue stores a failed compensation in the retry queue.
+func (r *Repository) Enqueue(ctx context.Context, c *sagalog.Compensation) error {
+	const q = `
+		INSERT INTO compensation_queue
+			(saga_id, step, payload, data, attempts, last_error, next_attempt_at, created_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?)`
+
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now().UTC()
+	}
+	if c.NextAttemptAt.IsZero() {
+		c.NextAttemptAt = c.CreatedAt
+	}
+
+	res, err := r.db.ExecContext(ctx, q,
+		c.SagaID,
+		c.Step,
+		c.Payload,
+		c.Data,
+		c.Attempts,
+		c.LastError,
+		sqlitetime.Format(c.NextAttemptAt),
+		sqlitetime.Format(c.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: enqueue compensation of %q for %q: %w", c.Step, c.SagaID, err)
+	}
+
+	c.ID, err = res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("sqlite: enqueue compensation of %q for %q: %w", c.Step, c.SagaID, err)
+	}
+	return nil
+}
+
+// Due returns queued compensations that are ready to be retried.
+func (r *Repository) Due(ctx context.Context, now time.Time, limit int) ([]*sagalog.Compensation, error) {
+	const q = `
+		SELECT id, saga_id, step, payload, data, attempts, last_error, next_attempt_at, created_at
+		FROM   compensation_queue
+		WHERE  next_attempt_at <= ?
+		ORDER  BY next_attempt_at ASC, id ASC
+		LIMIT  ?`
+
+	rows, err := r.db.QueryContext(ctx, q, sqlitetime.Format(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list due compensations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*sagalog.Compensation
+	for rows.Next() {
+		var c sagalog.Compensation
+		var next, created string
+		if err := rows.Scan(&c.ID, &c.SagaID, &c.Step, &c.Payload, &c.Data, &c.Attempts, &c.LastError, &next, &created); err != nil {
+			return nil, fmt.Errorf("sqlite: scan compensation: %w", err)
+		}
+		if c.NextAttemptAt, err = sqlitetime.Parse(next); err != nil {
+			return nil, err
+		}
+		if c.CreatedAt, err = sqlitetime.Parse(created); err != nil {
+			return nil, err
+		}
+		out = append(out, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate compensations: %w", err)
+	}
+	return out, nil
+}
+
+// Reschedule records a failed attempt and the time of the next one.
+func (r *Repository) Reschedule(ctx context.Context, id int64, attempts int, lastErr string, next time.Time) error {
+	const q = `
+		UPDATE compensation_queue
+		SET    attempts = ?, last_error = ?, next_attempt_at = ?
+		WHERE  id = ?`
+
+	if _, err := r.db.ExecContext(ctx, q, attempts, lastErr, sqlitetime.Format(next), id); err != nil {
+		return fmt.Errorf("sqlite: reschedule compensation %d: %w", id, err)
+	}
+	return nil
+}
+
+// Complete deletes a compensation that succeeded.
+func (r *Repository) Complete(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM compensation_queue WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("sqlite: complete compensation %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeadLetter atomically moves a compensation from the queue to the
+// dead-letter table.
+func (r *Repository) DeadLetter(ctx context.Context, id int64, attempts int, lastErr string) error {
+	const insert = `
+		INSERT INTO compensation_dead_letters
+			(id, saga_id, step, payload, data, attempts, last_error, created_at, dead_at)
+		SELECT id, saga_id, step, payload, data, ?, ?, created_at, ?
+		FROM   compensation_queue
+		WHERE  id = ?`
+
+	return r.move(ctx, id, "dead-letter",
+		func(tx *sql.Tx) (sql.Result, error) {
+			return tx.ExecContext(ctx, insert, attempts, lastErr, sqlitetime.Format(time.Now()), id)
+		},
+		`DELETE FROM compensation_queue WHERE id = ?`,
+	)
+}
+
+// ListDeadLetters returns every dead-lettered compensation.
+func (r *Repository) ListDeadLetters(ctx context.Context) ([]*sagalog.Compensation, error) {
+	const q = `
+		SELECT id, saga_id, step, payload, data, attempts, last_error, created_at, dead_at
+		FROM   compensation_dead_letters
+		ORDER  BY dead_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*sagalog.Compensation
+	for rows.Next() {
+		var c sagalog.Compensation
+		var created, dead string
+		if err := rows.Scan(&c.ID, &c.SagaID, &c.Step, &c.Payload, &c.Data, &c.Attempts, &c.LastError, &created, &dead); err != nil {
+			return nil, fmt.Errorf("sqlite: scan dead letter: %w", err)
+		}
+		if c.CreatedAt, err = sqlitetime.Parse(created); err != nil {
+			return nil, err
+		}
+		if c.DeadAt, err = sqlitetime.Parse(dead); err != nil {
+			return nil, err
+		}
+		out = append(out, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate dead letters: %w", err)
+	}
+	return out, nil
+}
+
+// Redrive atomically moves a dead letter back into the queue, due now and
+// with its attempt counter reset.
+func (r *Repository) Redrive(ctx context.Context, id int64) error {
+	const insert = `
+		INSERT INTO compensation_queue
+			(id, saga_id, step, payload, data, attempts, last_error, next_attempt_at, created_at)
+		SELECT id, saga_id, step, payload, data, 0, last_error, ?, created_at
+		FROM   compensation_dead_letters
+		WHERE  id = ?`
+
+	return r.move(ctx, id, "redrive",
+		func(tx *sql.Tx) (sql.Result, error) {
+			return tx.ExecContext(ctx, insert, sqlitetime.Format(time.Now()), id)
+		},
+		`DELETE FROM compensation_dead_letters WHERE id = ?`,
+	)
+}
+
+// move copies a row with insert and removes the original with del, in one
+// transaction. It returns sagalog.ErrNotFound if insert copied nothing.
+func (r *Repository) move(ctx context.Context, id int64, op string, insert func(*sql.Tx) (sql.Result, error), del string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: %s compensation %d: %w", op, id, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := insert(tx)
+	if err != nil {
+		return fmt.Errorf("sqlite: %s compensation %d: %w", op, id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("sqlite: %s compensation %d: %w", op, id, sagalog.ErrNotFound)
+	}
+
+	if _, err := tx.ExecContext(ctx, del, id); err != nil {
+		return fmt.Errorf("sqlite: %s compensation %d: %w", op, id, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: %s compensation %d: %w", op, id, err)
+	}
+	return nil
+}
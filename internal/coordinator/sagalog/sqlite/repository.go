@@ -9,8 +9,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/sqlitetime"
 
 	// Register the pure-Go SQLite driver.
 	// We use modernc.org/sqlite instead of mattn/go-sqlite3 to avoid CGO
@@ -39,6 +42,10 @@ CREATE TABLE IF NOT EXISTS saga_logs (
     -- JSON payload that started the saga. Written once on STARTED, NULL after.
     payload         TEXT,
 
+    -- JSON snapshot of the saga's shared data bag, written on STARTED and
+    -- STEP_DONE rows so recovery can restore it. NULL on other rows.
+    data            TEXT,
+
     -- JSON array of error strings accumulated during failure/compensation.
     error_messages  TEXT        NOT NULL DEFAULT '[]',
 
@@ -49,7 +56,8 @@ CREATE TABLE IF NOT EXISTS saga_logs (
     -- W3C span_id (16 hex chars) — pinpoints the exact RPC within the trace.
     span_id         TEXT        NOT NULL DEFAULT '',
 
-    -- Wall-clock timestamp of this event (RFC3339 stored as TEXT, SQLite idiom).
+    -- Wall-clock timestamp of this event, as fixed-width RFC3339 TEXT (see
+    -- sqlitetime.Layout) so that it compares chronologically.
     updated_at      TEXT        NOT NULL
 );
 
@@ -60,6 +68,40 @@ CREATE INDEX IF NOT EXISTS idx_saga_logs_saga_id ON saga_logs(saga_id, updated_a
 CREATE INDEX IF NOT EXISTS idx_saga_logs_trace_id ON saga_logs(trace_id);
 `
 
+// sagasSchema creates the per-saga index of the log that List pages
+// through, and fills it from the log for databases written before it
+// existed. It runs in one transaction, so the table never exists without
+// its rows.
+const sagasSchema = `
+CREATE TABLE sagas (
+    saga_id        TEXT    PRIMARY KEY,
+
+    -- ID of the saga's first log row. It never changes, so it orders the
+    -- sagas for pagination by start.
+    first_log_id   INTEGER NOT NULL UNIQUE,
+
+    -- ID, status and time of the saga's latest log row, i.e. its current
+    -- state. Kept up to date by Save.
+    latest_log_id  INTEGER NOT NULL,
+    status         TEXT    NOT NULL,
+    updated_at     TEXT    NOT NULL
+);
+
+-- Index for the status filter of List, in pagination order.
+CREATE INDEX idx_sagas_status ON sagas(status, first_log_id);
+
+INSERT INTO sagas (saga_id, first_log_id, latest_log_id, status, updated_at)
+SELECT f.saga_id, f.first_log_id, l.id, l.status, l.updated_at
+FROM   (SELECT saga_id, MIN(id) AS first_log_id FROM saga_logs GROUP BY saga_id) AS f
+JOIN   saga_logs AS l ON l.id = (
+           SELECT id FROM saga_logs
+           WHERE  saga_id = f.saga_id
+           ORDER  BY updated_at DESC, id DESC
+           LIMIT  1);
+`
+
+var _ sagalog.Repository = (*Repository)(nil)
+
 // Repository is the SQLite implementation of sagalog.Repository.
 type Repository struct {
 	db *sql.DB
@@ -98,27 +140,60 @@ func (r *Repository) Close() error {
 	return r.db.Close()
 }
 
-// Save inserts a new saga log entry. It is safe to call concurrently.
+// Save inserts a new saga log entry and makes it the saga's current state
+// in the sagas table, in one transaction. It is safe to call concurrently.
 func (r *Repository) Save(ctx context.Context, entry *sagalog.SagaLog) error {
 	const q = `
 		INSERT INTO saga_logs
-			(saga_id, status, current_step, payload, error_messages, trace_id, span_id, updated_at)
+			(saga_id, status, current_step, payload, data, error_messages, trace_id, span_id, updated_at)
 		VALUES
-			(?, ?, ?, ?, ?, ?, ?, ?)`
+			(?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// An entry older than the current state (clocks are not monotonic
+	// across restarts) stays in the log but does not become the state,
+	// as in GetLatest.
+	const upsert = `
+		INSERT INTO sagas (saga_id, first_log_id, latest_log_id, status, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (saga_id) DO UPDATE SET
+			latest_log_id = excluded.latest_log_id,
+			status        = excluded.status,
+			updated_at    = excluded.updated_at
+		WHERE excluded.updated_at >= sagas.updated_at`
 
-	_, err := r.db.ExecContext(ctx, q,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: save saga log for %q: %w", entry.SagaID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, q,
 		entry.SagaID,
 		string(entry.Status),
 		entry.CurrentStep,
 		nullableString(entry.Payload),
+		nullableString(entry.Data),
 		entry.ErrorMessages,
 		entry.TraceID,
 		entry.SpanID,
-		entry.UpdatedAt.UTC().Format("2006-01-02T15:04:05.999999999Z"),
+		sqlitetime.Format(entry.UpdatedAt),
 	)
 	if err != nil {
 		return fmt.Errorf("sqlite: save saga log for %q: %w", entry.SagaID, err)
 	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("sqlite: save saga log for %q: %w", entry.SagaID, err)
+	}
+	if _, err := tx.ExecContext(ctx, upsert,
+		entry.SagaID, id, id, string(entry.Status), sqlitetime.Format(entry.UpdatedAt),
+	); err != nil {
+		return fmt.Errorf("sqlite: save state of saga %q: %w", entry.SagaID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: save saga log for %q: %w", entry.SagaID, err)
+	}
 	return nil
 }
 
@@ -126,15 +201,159 @@ func (r *Repository) Save(ctx context.Context, entry *sagalog.SagaLog) error {
 // Useful for a status endpoint or for recovery on restart.
 func (r *Repository) GetLatest(ctx context.Context, sagaID string) (*sagalog.SagaLog, error) {
 	const q = `
-		SELECT saga_id, status, current_step, COALESCE(payload,''), error_messages,
-		       trace_id, span_id, updated_at
+		SELECT ` + columns + `
 		FROM   saga_logs
 		WHERE  saga_id = ?
 		ORDER  BY updated_at DESC, id DESC
 		LIMIT  1`
 
-	row := r.db.QueryRowContext(ctx, q, sagaID)
+	entry, err := scanEntry(r.db.QueryRowContext(ctx, q, sagaID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("sqlite: saga %q: %w", sagaID, sagalog.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: get latest for %q: %w", sagaID, err)
+	}
+	return entry, nil
+}
+
+// History returns every log entry for a given saga ID, oldest first.
+func (r *Repository) History(ctx context.Context, sagaID string) ([]*sagalog.SagaLog, error) {
+	const q = `
+		SELECT ` + columns + `
+		FROM   saga_logs
+		WHERE  saga_id = ?
+		ORDER  BY updated_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, q, sagaID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: history for %q: %w", sagaID, err)
+	}
+	return scanEntries(rows)
+}
+
+// FindByTraceID returns every log entry recorded under traceID, oldest
+// first. It is served by idx_saga_logs_trace_id.
+func (r *Repository) FindByTraceID(ctx context.Context, traceID string) ([]*sagalog.SagaLog, error) {
+	const q = `
+		SELECT ` + columns + `
+		FROM   saga_logs
+		WHERE  trace_id = ?
+		ORDER  BY updated_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, q, traceID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: find by trace %q: %w", traceID, err)
+	}
+	return scanEntries(rows)
+}
+
+// List returns the latest log entry of every saga matching filter, most
+// recently started first. It reads the sagas table, whose status index
+// serves the status filter. The cursor is the first log row ID of the last
+// saga on the previous page: that ID never changes, so a saga keeps its
+// place however many transitions it records between pages, and sagas
+// started in the meantime come before the first page rather than shifting
+// the later ones. A saga whose status changes between pages is filtered
+// by the status it has when its page is read.
+func (r *Repository) List(ctx context.Context, filter sagalog.Filter) (*sagalog.Page, error) {
+	limit := filter.Limit
+	switch {
+	case limit <= 0:
+		limit = sagalog.DefaultListLimit
+	case limit > sagalog.MaxListLimit:
+		limit = sagalog.MaxListLimit
+	}
+
+	var where []string
+	var args []any
+	if len(filter.Statuses) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(filter.Statuses)), ",")
+		where = append(where, "status IN ("+placeholders+")")
+		for _, st := range filter.Statuses {
+			args = append(args, string(st))
+		}
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "updated_at >= ?")
+		args = append(args, sqlitetime.Format(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "updated_at < ?")
+		args = append(args, sqlitetime.Format(filter.To))
+	}
+	if filter.Cursor != "" {
+		after, err := strconv.ParseInt(filter.Cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: invalid cursor %q", filter.Cursor)
+		}
+		where = append(where, "first_log_id < ?")
+		args = append(args, after)
+	}
+	cond := "1"
+	if len(where) > 0 {
+		cond = strings.Join(where, "\n\t\t\tAND    ")
+	}
+
+	// Fetch one extra row to know whether another page follows.
+	q := `
+		SELECT s.first_log_id, ` + columns + `
+		FROM   (SELECT first_log_id, latest_log_id
+		        FROM   sagas
+		        WHERE  ` + cond + `
+		        ORDER  BY first_log_id DESC
+		        LIMIT  ?) AS s
+		JOIN   saga_logs ON saga_logs.id = s.latest_log_id
+		ORDER  BY s.first_log_id DESC`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list sagas: %w", err)
+	}
+	defer rows.Close()
+
+	page := &sagalog.Page{}
+	var lastID int64
+	for rows.Next() {
+		if len(page.Sagas) == limit {
+			page.NextCursor = strconv.FormatInt(lastID, 10)
+			break
+		}
+		entry, err := scanEntry(idScanner{rows, &lastID})
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scan saga log: %w", err)
+		}
+		page.Sagas = append(page.Sagas, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate saga logs: %w", err)
+	}
+	return page, nil
+}
 
+// columns is the SELECT list shared by every read query; it matches scanEntry.
+const columns = `saga_id, status, current_step, COALESCE(payload,''), COALESCE(data,''),
+		       error_messages, trace_id, span_id, updated_at`
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// idScanner reads a leading row ID column before delegating the rest of the
+// row to scanEntry.
+type idScanner struct {
+	rows *sql.Rows
+	id   *int64
+}
+
+func (s idScanner) Scan(dest ...any) error {
+	return s.rows.Scan(append([]any{s.id}, dest...)...)
+}
+
+// scanEntry reads a single saga log row selected with columns.
+func scanEntry(row scanner) (*sagalog.SagaLog, error) {
 	var entry sagalog.SagaLog
 	var updatedAt string
 	err := row.Scan(
@@ -142,34 +361,191 @@ func (r *Repository) GetLatest(ctx context.Context, sagaID string) (*sagalog.Sag
 		&entry.Status,
 		&entry.CurrentStep,
 		&entry.Payload,
+		&entry.Data,
 		&entry.ErrorMessages,
 		&entry.TraceID,
 		&entry.SpanID,
 		&updatedAt,
 	)
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("sqlite: saga %q not found", sagaID)
-	}
 	if err != nil {
-		return nil, fmt.Errorf("sqlite: get latest for %q: %w", sagaID, err)
+		return nil, err
 	}
 
-	entry.UpdatedAt, err = parseRFC3339(updatedAt)
+	entry.UpdatedAt, err = sqlitetime.Parse(updatedAt)
 	if err != nil {
 		return nil, err
 	}
-
 	return &entry, nil
 }
 
-// applySchema runs the DDL statements once. Idempotent due to IF NOT EXISTS.
+// scanEntries drains rows into a slice and closes them.
+func scanEntries(rows *sql.Rows) ([]*sagalog.SagaLog, error) {
+	defer rows.Close()
+
+	var entries []*sagalog.SagaLog
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scan saga log: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate saga logs: %w", err)
+	}
+	return entries, nil
+}
+
+// migrations adds columns introduced after a table was first released.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so databases
+// created by an older build get the new columns here.
+var migrations = []struct {
+	table, column, ddl string
+}{
+	{"saga_logs", "data", `ALTER TABLE saga_logs ADD COLUMN data TEXT`},
+	{"compensation_queue", "data", `ALTER TABLE compensation_queue ADD COLUMN data TEXT NOT NULL DEFAULT ''`},
+	{"compensation_dead_letters", "data", `ALTER TABLE compensation_dead_letters ADD COLUMN data TEXT NOT NULL DEFAULT ''`},
+}
+
+// applySchema runs the DDL statements once. Idempotent due to IF NOT EXISTS,
+// to the sagas table only being created when it is missing, to timestamps
+// only being rewritten while not in sqlitetime.Layout, and to migrations
+// only adding columns that are missing.
 func applySchema(db *sql.DB) error {
-	if _, err := db.Exec(schema); err != nil {
-		return fmt.Errorf("sqlite: apply schema: %w", err)
+	for _, ddl := range []string{schema, compensationSchema} {
+		if _, err := db.Exec(ddl); err != nil {
+			return fmt.Errorf("sqlite: apply schema: %w", err)
+		}
+	}
+	if err := createSagas(db); err != nil {
+		return err
+	}
+	if err := normalizeTimes(db); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		exists, err := hasColumn(db, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(m.ddl); err != nil {
+			return fmt.Errorf("sqlite: migrate %s.%s: %w", m.table, m.column, err)
+		}
 	}
 	return nil
 }
 
+// createSagas creates and fills the sagas table unless it exists.
+func createSagas(db *sql.DB) error {
+	var exists bool
+	if err := db.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'sagas')`,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("sqlite: inspect sagas: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("sqlite: create sagas: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(sagasSchema); err != nil {
+		return fmt.Errorf("sqlite: create sagas: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: create sagas: %w", err)
+	}
+	return nil
+}
+
+// refreshSagas recomputes the current state of every saga from its log.
+const refreshSagas = `
+UPDATE sagas SET (latest_log_id, status, updated_at) = (
+    SELECT id, status, updated_at FROM saga_logs
+    WHERE  saga_id = sagas.saga_id
+    ORDER  BY updated_at DESC, id DESC
+    LIMIT  1)`
+
+// normalizeTimes rewrites the log timestamps written before
+// sqlitetime.Layout was adopted. Their width varies with the fraction of a
+// second, so as text they sort and filter wrongly against each other and
+// against newer rows. The state of every saga is then recomputed, since it
+// was picked by comparing them.
+func normalizeTimes(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("sqlite: normalize times: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`SELECT id, updated_at FROM saga_logs WHERE length(updated_at) <> ?`, len(sqlitetime.Layout))
+	if err != nil {
+		return fmt.Errorf("sqlite: normalize times: %w", err)
+	}
+	updated := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var updatedAt string
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("sqlite: normalize times: %w", err)
+		}
+		t, err := sqlitetime.Parse(updatedAt)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		updated[id] = sqlitetime.Format(t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sqlite: normalize times: %w", err)
+	}
+	if len(updated) == 0 {
+		return nil
+	}
+
+	for id, updatedAt := range updated {
+		if _, err := tx.Exec(`UPDATE saga_logs SET updated_at = ? WHERE id = ?`, updatedAt, id); err != nil {
+			return fmt.Errorf("sqlite: normalize times: %w", err)
+		}
+	}
+	if _, err := tx.Exec(refreshSagas); err != nil {
+		return fmt.Errorf("sqlite: normalize times: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: normalize times: %w", err)
+	}
+	return nil
+}
+
+// hasColumn reports whether table already has the given column.
+func hasColumn(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: inspect %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, fmt.Errorf("sqlite: inspect %s: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
 // nullableString returns nil for empty strings so SQLite stores NULL instead
 // of an empty TEXT — keeps the payload column clean on non-STARTED rows.
 func nullableString(s string) any {
@@ -0,0 +1,273 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/coordinator/sagalog"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/sqlitetime"
+)
+
+func open(t *testing.T) *Repository {
+	t.Helper()
+	repo, err := Open(filepath.Join(t.TempDir(), "saga.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+// clock hands out strictly increasing timestamps.
+type clock struct{ now time.Time }
+
+func (c *clock) next() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func save(t *testing.T, repo *Repository, c *clock, sagaID string, st sagalog.Status) {
+	t.Helper()
+	entry := &sagalog.SagaLog{SagaID: sagaID, Status: st, ErrorMessages: "[]", UpdatedAt: c.next()}
+	if err := repo.Save(context.Background(), entry); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// listAll pages through List and returns the ID and status of every saga
+// it saw. between, if set, runs after every page but the last.
+func listAll(t *testing.T, repo *Repository, filter sagalog.Filter, between func()) []string {
+	t.Helper()
+	var got []string
+	for {
+		page, err := repo.List(context.Background(), filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range page.Sagas {
+			got = append(got, s.SagaID+":"+string(s.Status))
+		}
+		if page.NextCursor == "" {
+			return got
+		}
+		filter.Cursor = page.NextCursor
+		if between != nil {
+			between()
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter sagalog.Filter
+		// between runs after every page, e.g. to record new transitions.
+		between func(t *testing.T, repo *Repository, c *clock)
+		want    []string
+	}{
+		{
+			name:   "newest started first",
+			filter: sagalog.Filter{Limit: 2},
+			want:   []string{"s5:STARTED", "s4:STEP_DONE", "s3:FAILED", "s2:STEP_DONE", "s1:COMPLETED"},
+		},
+		{
+			name:   "transitions between pages do not move sagas",
+			filter: sagalog.Filter{Limit: 2},
+			between: func(t *testing.T, repo *Repository, c *clock) {
+				for _, id := range []string{"s1", "s2", "s3", "s4", "s5"} {
+					save(t, repo, c, id, sagalog.StatusStepRetrying)
+				}
+			},
+			want: []string{"s5:STARTED", "s4:STEP_DONE", "s3:STEP_RETRYING", "s2:STEP_RETRYING", "s1:STEP_RETRYING"},
+		},
+		{
+			name:   "sagas started between pages do not shift them",
+			filter: sagalog.Filter{Limit: 2},
+			between: func(t *testing.T, repo *Repository, c *clock) {
+				save(t, repo, c, fmt.Sprintf("new-%d", c.now.Unix()), sagalog.StatusStarted)
+			},
+			want: []string{"s5:STARTED", "s4:STEP_DONE", "s3:FAILED", "s2:STEP_DONE", "s1:COMPLETED"},
+		},
+		{
+			name:   "status filter",
+			filter: sagalog.Filter{Statuses: sagalog.InFlightStatuses, Limit: 1},
+			want:   []string{"s5:STARTED", "s4:STEP_DONE", "s2:STEP_DONE"},
+		},
+		{
+			name:   "time range of the latest transition",
+			filter: sagalog.Filter{From: time.Unix(6, 0), To: time.Unix(8, 0)},
+			want:   []string{"s4:STEP_DONE", "s3:FAILED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t)
+			c := &clock{now: time.Unix(0, 0)}
+			// Timestamps are in seconds. s2 has its latest transition after
+			// s3 and s4 started, so ordering by latest transition would
+			// differ from ordering by start.
+			save(t, repo, c, "s1", sagalog.StatusStarted)   // 1
+			save(t, repo, c, "s2", sagalog.StatusStarted)   // 2
+			save(t, repo, c, "s1", sagalog.StatusCompleted) // 3
+			save(t, repo, c, "s3", sagalog.StatusStarted)   // 4
+			save(t, repo, c, "s4", sagalog.StatusStarted)   // 5
+			save(t, repo, c, "s3", sagalog.StatusFailed)    // 6
+			save(t, repo, c, "s4", sagalog.StatusStepDone)  // 7
+			save(t, repo, c, "s2", sagalog.StatusStepDone)  // 8
+			save(t, repo, c, "s5", sagalog.StatusStarted)   // 9
+
+			var between func()
+			if tt.between != nil {
+				between = func() { tt.between(t, repo, c) }
+			}
+			if got := listAll(t, repo, tt.filter, between); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_StatusFilterUsesIndex(t *testing.T) {
+	repo := open(t)
+	rows, err := repo.db.Query(`EXPLAIN QUERY PLAN
+		SELECT first_log_id FROM sagas WHERE status IN (?, ?) AND first_log_id < ? ORDER BY first_log_id DESC`,
+		"STARTED", "FAILED", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+			t.Fatal(err)
+		}
+		plan = append(plan, detail)
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "idx_sagas_status") {
+		t.Errorf("plan does not use idx_sagas_status:\n%s", strings.Join(plan, "\n"))
+	}
+}
+
+// TestOpen_FillsSagasFromAnOlderLog opens a log written before the sagas
+// table existed.
+func TestOpen_FillsSagasFromAnOlderLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saga.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range []struct {
+		sagaID string
+		status sagalog.Status
+	}{
+		{"s1", sagalog.StatusStarted},
+		{"s2", sagalog.StatusStarted},
+		{"s1", sagalog.StatusCompleted},
+		{"s2", sagalog.StatusCompensating},
+	} {
+		if _, err := db.Exec(`INSERT INTO saga_logs (saga_id, status, updated_at) VALUES (?, ?, ?)`,
+			row.sagaID, string(row.status), sqlitetime.Format(time.Unix(int64(i), 0)),
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 { // the second Open must leave the table as it is
+		repo, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := listAll(t, repo, sagalog.Filter{}, nil)
+		if want := []string{"s2:COMPENSATING", "s1:COMPLETED"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if err := repo.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestOpen_RewritesVariableWidthTimes opens a log whose timestamps were
+// written with the width depending on the fraction of a second, where
+// "...:05Z" sorts after "...:05.5Z" as text.
+func TestOpen_RewritesVariableWidthTimes(t *testing.T) {
+	const oldLayout = "2006-01-02T15:04:05.999999999Z"
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	path := filepath.Join(t.TempDir(), "saga.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range []struct {
+		status sagalog.Status
+		at     time.Time
+	}{
+		{sagalog.StatusStarted, start},
+		{sagalog.StatusStepDone, start.Add(500 * time.Millisecond)},
+	} {
+		if _, err := db.Exec(`INSERT INTO saga_logs (saga_id, status, updated_at) VALUES ('s1', ?, ?)`,
+			string(row.status), row.at.Format(oldLayout),
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	latest, err := repo.GetLatest(ctx, "s1")
+	if err != nil || latest.Status != sagalog.StatusStepDone {
+		t.Fatalf("GetLatest = %+v, %v, want STEP_DONE", latest, err)
+	}
+	from := sagalog.Filter{From: start.Add(250 * time.Millisecond)}
+	if got := listAll(t, repo, from, nil); !slices.Equal(got, []string{"s1:STEP_DONE"}) {
+		t.Errorf("List from 05.25 = %v, want [s1:STEP_DONE]", got)
+	}
+
+	// A row in the new layout a moment later must become the state.
+	entry := &sagalog.SagaLog{SagaID: "s1", Status: sagalog.StatusCompleted, ErrorMessages: "[]", UpdatedAt: start.Add(600 * time.Millisecond)}
+	if err := repo.Save(ctx, entry); err != nil {
+		t.Fatal(err)
+	}
+	history, err := repo.History(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statuses []sagalog.Status
+	for _, e := range history {
+		statuses = append(statuses, e.Status)
+	}
+	want := []sagalog.Status{sagalog.StatusStarted, sagalog.StatusStepDone, sagalog.StatusCompleted}
+	if !slices.Equal(statuses, want) {
+		t.Errorf("history %v, want %v", statuses, want)
+	}
+	if got := listAll(t, repo, sagalog.Filter{}, nil); !slices.Equal(got, []string{"s1:COMPLETED"}) {
+		t.Errorf("List = %v, want [s1:COMPLETED]", got)
+	}
+}
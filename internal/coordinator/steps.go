@@ -3,56 +3,184 @@ package coordinator
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
 	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
 	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// Step names as stored in the saga log and in Payload.Steps.
+const (
+	CreateOrderStepName      = "Create_Order_Step"
+	PaymentStepName          = "Payment_Charge_Step"
+	PaymentAuthorizeStepName = "Payment_Authorize_Step"
+	PaymentCaptureStepName   = "Payment_Capture_Step"
+	InventoryStepName        = "Inventory_Reservation_Step"
+	InventoryCommitStepName  = "Inventory_Commit_Step"
+	ConfirmOrderStepName     = "Confirm_Order_Step"
 )
 
+// OrderActor identifies the orchestrator in the order status history.
+const OrderActor = "saga-orchestrator"
+
+// compensationReason is recorded on the refunds and order cancellations
+// issued by a rollback.
+const compensationReason = "saga compensation"
+
+// NewOrderSagaRegistry returns a Registry that can rebuild every step of the
+// order saga from its Payload.
+func NewOrderSagaRegistry(oc orderv1.OrderClient, pc paymentv1.PaymentClient, ic inventoryv1.InventoryClient) *Registry {
+	r := NewRegistry()
+	r.Register(CreateOrderStepName, func(p *Payload) (Step, error) {
+		items := make([]*orderv1.OrderItem, len(p.Items))
+		for i, it := range p.Items {
+			items[i] = &orderv1.OrderItem{
+				ProductId:      it.ProductID,
+				Quantity:       it.Quantity,
+				UnitPrice:      it.UnitPrice.Major(),
+				UnitPriceMoney: it.UnitPrice.ToProto(),
+			}
+		}
+		return NewCreateOrderStep(oc, &orderv1.CreateOrderRequest{
+			Id:                p.OrderID,
+			CustomerId:        p.CustomerID,
+			Items:             items,
+			CurrencyCode:      p.Total.CurrencyCode,
+			FulfillmentPolicy: orderv1.FulfillmentPolicy(orderv1.FulfillmentPolicy_value[p.FulfillmentPolicy]),
+		}, p.IdempotencyKey), nil
+	})
+	r.Register(InventoryStepName, func(p *Payload) (Step, error) {
+		items := make([]*inventoryv1.StockItem, len(p.Items))
+		for i, it := range p.Items {
+			items[i] = &inventoryv1.StockItem{ProductId: it.ProductID, Quantity: it.Quantity}
+		}
+		policy := inventoryv1.FulfillmentPolicy(inventoryv1.FulfillmentPolicy_value[p.FulfillmentPolicy])
+		return NewInventoryStep(ic, p.OrderID, items, policy), nil
+	})
+	r.Register(InventoryCommitStepName, func(p *Payload) (Step, error) {
+		return NewInventoryCommitStep(ic, p.OrderID), nil
+	})
+	r.Register(PaymentStepName, func(p *Payload) (Step, error) {
+		return NewPaymentStep(pc, p.OrderID, p.Total), nil
+	})
+	r.Register(PaymentAuthorizeStepName, func(p *Payload) (Step, error) {
+		return NewPaymentAuthorizeStep(pc, p.OrderID, p.Total), nil
+	})
+	r.Register(PaymentCaptureStepName, func(p *Payload) (Step, error) {
+		return NewPaymentCaptureStep(pc, p.OrderID, p.Total), nil
+	})
+	r.Register(ConfirmOrderStepName, func(p *Payload) (Step, error) {
+		return NewConfirmOrderStep(oc, p.OrderID), nil
+	})
+	return r
+}
+
+// Timeouts declared by the built-in steps. Compensations get more room than
+// executions because giving up on them is far more expensive.
+const (
+	defaultExecuteTimeout    = 5 * time.Second
+	defaultCompensateTimeout = 15 * time.Second
+)
+
+// resolveOrderID returns the order ID published to the saga data bag by
+// CreateOrderStep, which is the ID the order service actually stored, or id
+// in sagas that did not create their order.
+func resolveOrderID(ctx context.Context, id string) string {
+	if created, ok := OrderIDKey.Get(DataFromContext(ctx)); ok && created != "" {
+		return created
+	}
+	return id
+}
+
 // --- CreateOrderStep ---
 
+// CreateOrderStep creates the order and publishes its ID under OrderIDKey,
+// so the steps that follow it can be built without knowing the ID upfront.
+// The request is sent with the saga's idempotency key, which makes it safe
+// to retry: an attempt that timed out after storing the order is answered
+// with that order by the next one.
 type CreateOrderStep struct {
-	client  orderv1.OrderClient
-	request *orderv1.CreateOrderRequest
-	orderID string
+	client         orderv1.OrderClient
+	request        *orderv1.CreateOrderRequest
+	idempotencyKey string
+	orderID        string
 }
 
-// NewCreateOrderStep is the constructor for CreateOrderStep
-func NewCreateOrderStep(client orderv1.OrderClient, request *orderv1.CreateOrderRequest) *CreateOrderStep {
+// NewCreateOrderStep is the constructor for CreateOrderStep. idempotencyKey
+// must be set for the step to be retried safely.
+func NewCreateOrderStep(client orderv1.OrderClient, request *orderv1.CreateOrderRequest, idempotencyKey string) *CreateOrderStep {
 	return &CreateOrderStep{
-		client:  client,
-		request: request,
+		client:         client,
+		request:        request,
+		idempotencyKey: idempotencyKey,
+	}
+}
+
+func (s *CreateOrderStep) Name() string { return CreateOrderStepName }
+
+// RetryPolicy retries only when the request carries an idempotency key;
+// without one, a retry after a lost response would store a second order.
+func (s *CreateOrderStep) RetryPolicy() RetryPolicy {
+	if s.idempotencyKey == "" {
+		return RetryPolicy{MaxAttempts: 1}
 	}
+	return DefaultRetryPolicy
 }
 
-func (s *CreateOrderStep) Name() string { return "Create_Order_Step" }
+func (s *CreateOrderStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *CreateOrderStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
 
 func (s *CreateOrderStep) Execute(ctx context.Context) error {
+	if s.idempotencyKey != "" {
+		// Replace rather than append: the context may already carry the
+		// key of the HTTP request, possibly empty, and the order service
+		// reads the first value.
+		md, _ := metadata.FromOutgoingContext(ctx)
+		md = md.Copy()
+		md.Set(constants.HeaderXIdempotencyKey, s.idempotencyKey)
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
 	res, err := s.client.CreateOrder(ctx, s.request)
 	if err != nil {
 		return fmt.Errorf("failed to create order: %w", err)
 	}
 	s.orderID = res.Order.Id
-	return nil
+	return OrderIDKey.Set(DataFromContext(ctx), s.orderID)
 }
 
 func (s *CreateOrderStep) Compensate(ctx context.Context) error {
 	_, err := s.client.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
-		Id:     s.orderID,
+		Id:     resolveOrderID(ctx, s.orderID),
 		Status: orderv1.Status_CANCELLED,
+		Reason: compensationReason,
+		Actor:  OrderActor,
 	})
+	if status.Code(err) == codes.NotFound {
+		// The step stopped before storing the order: nothing to cancel.
+		return nil
+	}
 	return err
 }
 
 // --- PaymentStep ---
 
+// PaymentStep charges the full amount in one go. New sagas authorize and
+// capture instead; it stays registered to replay sagas that used it.
 type PaymentStep struct {
 	client  paymentv1.PaymentClient
 	orderID string
-	amount  float64
+	amount  money.Money
 }
 
-func NewPaymentStep(client paymentv1.PaymentClient, orderID string, amount float64) *PaymentStep {
+func NewPaymentStep(client paymentv1.PaymentClient, orderID string, amount money.Money) *PaymentStep {
 	return &PaymentStep{
 		client:  client,
 		orderID: orderID,
@@ -60,69 +188,264 @@ func NewPaymentStep(client paymentv1.PaymentClient, orderID string, amount float
 	}
 }
 
-func (s *PaymentStep) Name() string { return "Payment_Charge_Step" }
+func (s *PaymentStep) Name() string { return PaymentStepName }
+
+func (s *PaymentStep) RetryPolicy() RetryPolicy { return DefaultRetryPolicy }
+
+func (s *PaymentStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *PaymentStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
 
 func (s *PaymentStep) Execute(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
 	res, err := s.client.Charge(ctx, &paymentv1.ChargeRequest{
-		OrderId: s.orderID,
-		Amount:  s.amount,
+		OrderId:      orderID,
+		Amount:       s.amount.Major(),
+		AmountMoney:  s.amount.ToProto(),
+		CurrencyCode: s.amount.CurrencyCode,
 	})
 	// Check both the gRPC error and the business logic success flag
 	if err != nil {
 		return fmt.Errorf("payment service error: %w", err)
 	}
 	if !res.Success {
-		return fmt.Errorf("payment declined for order %s", s.orderID)
+		return paymentDecline("payment", orderID, res.GetDeclineReason(), res.GetDeclineMessage())
 	}
 	return nil
 }
 
 func (s *PaymentStep) Compensate(ctx context.Context) error {
-	_, err := s.client.Refund(ctx, &paymentv1.RefundRequest{
-		OrderId: s.orderID,
+	return refundAll(ctx, s.client, resolveOrderID(ctx, s.orderID), s.amount.CurrencyCode)
+}
+
+// --- PaymentAuthorizeStep ---
+
+// PaymentAuthorizeStep holds the order amount without taking it. Its
+// compensation voids the hold, or refunds if it was captured meanwhile.
+type PaymentAuthorizeStep struct {
+	client  paymentv1.PaymentClient
+	orderID string
+	amount  money.Money
+}
+
+func NewPaymentAuthorizeStep(client paymentv1.PaymentClient, orderID string, amount money.Money) *PaymentAuthorizeStep {
+	return &PaymentAuthorizeStep{
+		client:  client,
+		orderID: orderID,
+		amount:  amount,
+	}
+}
+
+func (s *PaymentAuthorizeStep) Name() string { return PaymentAuthorizeStepName }
+
+func (s *PaymentAuthorizeStep) RetryPolicy() RetryPolicy { return DefaultRetryPolicy }
+
+func (s *PaymentAuthorizeStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *PaymentAuthorizeStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
+
+func (s *PaymentAuthorizeStep) Execute(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
+	res, err := s.client.Authorize(ctx, &paymentv1.AuthorizeRequest{
+		OrderId:      orderID,
+		Amount:       s.amount.ToProto(),
+		CurrencyCode: s.amount.CurrencyCode,
+	})
+	if err != nil {
+		return fmt.Errorf("payment service error: %w", err)
+	}
+	if !res.Success {
+		return paymentDecline("payment authorization", orderID, res.GetDeclineReason(), res.GetDeclineMessage())
+	}
+	return nil
+}
+
+func (s *PaymentAuthorizeStep) Compensate(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
+	_, err := s.client.Void(ctx, &paymentv1.VoidRequest{OrderId: orderID})
+	if status.Code(err) != codes.FailedPrecondition {
+		return err
+	}
+	// Captured after all: only a refund gives the money back.
+	return refundAll(ctx, s.client, orderID, s.amount.CurrencyCode)
+}
+
+// --- PaymentCaptureStep ---
+
+// PaymentCaptureStep takes the money held by PaymentAuthorizeStep, or only
+// the fulfilled total published by ConfirmOrderStep if that is less. It
+// runs once the order is confirmed, so a saga that fails earlier never pays
+// refund fees.
+type PaymentCaptureStep struct {
+	client  paymentv1.PaymentClient
+	orderID string
+	amount  money.Money
+}
+
+func NewPaymentCaptureStep(client paymentv1.PaymentClient, orderID string, amount money.Money) *PaymentCaptureStep {
+	return &PaymentCaptureStep{
+		client:  client,
+		orderID: orderID,
+		amount:  amount,
+	}
+}
+
+func (s *PaymentCaptureStep) Name() string { return PaymentCaptureStepName }
+
+func (s *PaymentCaptureStep) RetryPolicy() RetryPolicy { return DefaultRetryPolicy }
+
+func (s *PaymentCaptureStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *PaymentCaptureStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
+
+func (s *PaymentCaptureStep) Execute(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
+	req := &paymentv1.CaptureRequest{OrderId: orderID}
+	// Units the inventory dropped are not charged for.
+	if total, ok := FulfilledTotalKey.Get(DataFromContext(ctx)); ok {
+		if c, err := total.Cmp(s.amount); err == nil && c < 0 {
+			req.Amount = total.ToProto()
+		}
+	}
+	res, err := s.client.Capture(ctx, req)
+	if err != nil {
+		return fmt.Errorf("payment service error: %w", err)
+	}
+	if !res.Success {
+		return fmt.Errorf("payment capture failed for order %s", orderID)
+	}
+	return nil
+}
+
+func (s *PaymentCaptureStep) Compensate(ctx context.Context) error {
+	return refundAll(ctx, s.client, resolveOrderID(ctx, s.orderID), s.amount.CurrencyCode)
+}
+
+// refundAll gives back whatever is left of the order's payment. A payment
+// that is already gone (voided, or never recorded) has nothing to refund.
+func refundAll(ctx context.Context, client paymentv1.PaymentClient, orderID, currency string) error {
+	_, err := client.Refund(ctx, &paymentv1.RefundRequest{
+		OrderId:      orderID,
+		CurrencyCode: currency,
+		Reason:       compensationReason,
 	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
 	return err
 }
 
 // --- InventoryStep ---
 
+// InventoryStep reserves the order's items under the fulfillment policy
+// and publishes what it reserved and backordered under FulfillmentKey.
 type InventoryStep struct {
 	client  inventoryv1.InventoryClient
 	orderID string
 	items   []*inventoryv1.StockItem
+	policy  inventoryv1.FulfillmentPolicy
 }
 
-func NewInventoryStep(client inventoryv1.InventoryClient, orderID string, items []*inventoryv1.StockItem) *InventoryStep {
+func NewInventoryStep(client inventoryv1.InventoryClient, orderID string, items []*inventoryv1.StockItem, policy inventoryv1.FulfillmentPolicy) *InventoryStep {
 	return &InventoryStep{
 		client:  client,
 		orderID: orderID,
 		items:   items,
+		policy:  policy,
 	}
 }
 
-func (s *InventoryStep) Name() string { return "Inventory_Reservation_Step" }
+func (s *InventoryStep) Name() string { return InventoryStepName }
+
+func (s *InventoryStep) RetryPolicy() RetryPolicy { return DefaultRetryPolicy }
+
+func (s *InventoryStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *InventoryStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
 
 func (s *InventoryStep) Execute(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
 	res, err := s.client.Reserve(ctx, &inventoryv1.ReserveRequest{
-		OrderId: s.orderID,
-		Items:   s.items,
+		OrderId:           orderID,
+		Items:             s.items,
+		FulfillmentPolicy: s.policy,
 	})
 	if err != nil {
 		return fmt.Errorf("inventory service error: %w", err)
 	}
 	if !res.Success {
-		return fmt.Errorf("inventory insufficient for order %s", s.orderID)
+		return inventoryDecline(orderID, res)
 	}
-	return nil
+	if len(res.GetItems()) == 0 {
+		// An inventory service that predates fulfillment policies.
+		return nil
+	}
+
+	items := make([]ItemFulfillment, len(res.GetItems()))
+	for i, it := range res.GetItems() {
+		items[i] = ItemFulfillment{
+			ProductID:   it.GetProductId(),
+			Requested:   it.GetRequested(),
+			Reserved:    it.GetReserved(),
+			Backordered: it.GetBackordered(),
+		}
+	}
+	return FulfillmentKey.Set(DataFromContext(ctx), items)
 }
 
 func (s *InventoryStep) Compensate(ctx context.Context) error {
-	_, err := s.client.Release(ctx, &inventoryv1.ReleaseRequest{OrderId: s.orderID})
+	orderID := resolveOrderID(ctx, s.orderID)
+	_, err := s.client.Release(ctx, &inventoryv1.ReleaseRequest{OrderId: orderID})
 	return err
 }
 
+// --- InventoryCommitStep ---
+
+// InventoryCommitStep makes the reservation of InventoryStep permanent once
+// the payment is secured. Until then the reservation expires on its own, so
+// stock held by a saga that never finishes returns to sale.
+type InventoryCommitStep struct {
+	client  inventoryv1.InventoryClient
+	orderID string
+}
+
+func NewInventoryCommitStep(client inventoryv1.InventoryClient, orderID string) *InventoryCommitStep {
+	return &InventoryCommitStep{
+		client:  client,
+		orderID: orderID,
+	}
+}
+
+func (s *InventoryCommitStep) Name() string { return InventoryCommitStepName }
+
+func (s *InventoryCommitStep) RetryPolicy() RetryPolicy { return DefaultRetryPolicy }
+
+func (s *InventoryCommitStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *InventoryCommitStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
+
+func (s *InventoryCommitStep) Execute(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
+	res, err := s.client.Commit(ctx, &inventoryv1.CommitRequest{OrderId: orderID})
+	if err != nil {
+		return fmt.Errorf("inventory service error: %w", err)
+	}
+	if !res.Success {
+		return fmt.Errorf("inventory commit failed for order %s", orderID)
+	}
+	return nil
+}
+
+func (s *InventoryCommitStep) Compensate(ctx context.Context) error {
+	// InventoryStep's compensation releases committed reservations too.
+	return nil
+}
+
 // --- ConfirmOrderStep ---
 
+// ConfirmOrderStep records what InventoryStep reserved, if it published
+// it, publishes what the customer pays under FulfilledTotalKey and confirms
+// the order.
 type ConfirmOrderStep struct {
 	client  orderv1.OrderClient
 	orderID string
@@ -136,22 +459,72 @@ func NewConfirmOrderStep(client orderv1.OrderClient, orderID string) *ConfirmOrd
 	}
 }
 
-func (s *ConfirmOrderStep) Name() string { return "Confirm_Order_Step" }
+func (s *ConfirmOrderStep) Name() string { return ConfirmOrderStepName }
+
+func (s *ConfirmOrderStep) RetryPolicy() RetryPolicy { return DefaultRetryPolicy }
+
+func (s *ConfirmOrderStep) ExecuteTimeout() time.Duration { return defaultExecuteTimeout }
+
+func (s *ConfirmOrderStep) CompensateTimeout() time.Duration { return defaultCompensateTimeout }
 
 func (s *ConfirmOrderStep) Execute(ctx context.Context) error {
+	orderID := resolveOrderID(ctx, s.orderID)
+	reason := "payment secured and stock reserved"
+
+	if items, ok := FulfillmentKey.Get(DataFromContext(ctx)); ok {
+		order, err := s.recordFulfillment(ctx, orderID, items)
+		if err != nil {
+			return err
+		}
+		switch order.GetFulfillment() {
+		case orderv1.Fulfillment_FULFILLMENT_PARTIAL:
+			reason = "payment secured and stock partially reserved"
+		case orderv1.Fulfillment_FULFILLMENT_BACKORDERED:
+			reason = "payment secured, stock reserved with backorders"
+		}
+	}
+
 	res, err := s.client.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
-		Id:     s.orderID,
+		Id:     orderID,
 		Status: orderv1.Status_CONFIRMED,
+		Reason: reason,
+		Actor:  OrderActor,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to confirm order gRPC: %w", err)
 	}
 	if !res.Success {
-		return fmt.Errorf("order service refused to confirm order %s", s.orderID)
+		return fmt.Errorf("order service refused to confirm order %s", orderID)
 	}
 	return nil
 }
 
+// recordFulfillment stores items on the order and publishes the fulfilled
+// total it works out.
+func (s *ConfirmOrderStep) recordFulfillment(ctx context.Context, orderID string, items []ItemFulfillment) (*orderv1.OrderInfo, error) {
+	req := &orderv1.RecordFulfillmentRequest{Id: orderID}
+	for _, it := range items {
+		req.Items = append(req.Items, &orderv1.ItemFulfillment{
+			ProductId:           it.ProductID,
+			ReservedQuantity:    it.Reserved,
+			BackorderedQuantity: it.Backordered,
+		})
+	}
+	res, err := s.client.RecordFulfillment(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record order fulfillment: %w", err)
+	}
+
+	total, err := money.FromProto(res.GetOrder().GetFulfilledTotalMoney())
+	if err != nil {
+		return nil, fmt.Errorf("order %s: invalid fulfilled total: %w", orderID, err)
+	}
+	if err := FulfilledTotalKey.Set(DataFromContext(ctx), total); err != nil {
+		return nil, err
+	}
+	return res.GetOrder(), nil
+}
+
 func (s *ConfirmOrderStep) Compensate(ctx context.Context) error {
 	// Usually empty as it's the last step.
 	// In complex systems, you might trigger a 'Return' process here.
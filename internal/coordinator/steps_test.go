@@ -0,0 +1,145 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// fakeOrderClient stores orders by idempotency key, as the order service
+// does. The first lostResponses calls store the order and then fail as if
+// the response had been lost.
+type fakeOrderClient struct {
+	orderv1.OrderClient
+
+	mu            sync.Mutex
+	byKey         map[string]string
+	created       int
+	keys          []string
+	lostResponses int
+	confirmed     []string
+}
+
+func (c *fakeOrderClient) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest, _ ...grpc.CallOption) (*orderv1.CreateOrderResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	key := ""
+	if v := md.Get(constants.HeaderXIdempotencyKey); len(v) > 0 {
+		key = v[0]
+	}
+	c.keys = append(c.keys, key)
+
+	id, ok := c.byKey[key]
+	if !ok || key == "" {
+		c.created++
+		id = req.GetId()
+		if id == "" {
+			id = fmt.Sprintf("ord_%d", c.created)
+		}
+		if key != "" {
+			c.byKey[key] = id
+		}
+	}
+	if c.lostResponses > 0 {
+		c.lostResponses--
+		return nil, status.Error(codes.DeadlineExceeded, "response lost")
+	}
+	return &orderv1.CreateOrderResponse{Order: &orderv1.OrderInfo{Id: id}}, nil
+}
+
+func (c *fakeOrderClient) UpdateOrderStatus(_ context.Context, req *orderv1.UpdateOrderStatusRequest, _ ...grpc.CallOption) (*orderv1.UpdateOrderStatusResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if req.GetStatus() == orderv1.Status_CONFIRMED {
+		c.confirmed = append(c.confirmed, req.GetId())
+	}
+	return &orderv1.UpdateOrderStatusResponse{Success: true}, nil
+}
+
+func TestCreateOrderStep_RetriesOnlyWithAnIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		// requestKey is what the HTTP request put in the outgoing metadata.
+		requestKey   string
+		wantErr      bool
+		wantAttempts int
+	}{
+		{"lost response is retried with the key", "key-1", "", false, 2},
+		{"saga key wins over the request metadata", "key-1", "other", false, 2},
+		{"no key, no retry", "", "", true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeOrderClient{byKey: map[string]string{}, lostResponses: 1}
+			step := NewCreateOrderStep(client, &orderv1.CreateOrderRequest{Id: "ord_requested"}, tt.key)
+			data := NewData()
+			ctx := metadata.AppendToOutgoingContext(context.Background(), constants.HeaderXIdempotencyKey, tt.requestKey)
+
+			err := NewOrchestrator("saga-1", []Step{step}, nil, WithData(data)).Start(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("saga error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(client.keys) != tt.wantAttempts {
+				t.Fatalf("%d attempts, want %d", len(client.keys), tt.wantAttempts)
+			}
+			for _, key := range client.keys {
+				if key != tt.key {
+					t.Errorf("attempt sent key %q, want %q", key, tt.key)
+				}
+			}
+			if client.created != 1 {
+				t.Errorf("%d orders created, want 1", client.created)
+			}
+			if id, _ := OrderIDKey.Get(data); !tt.wantErr && id != "ord_requested" {
+				t.Errorf("published order ID %q, want ord_requested", id)
+			}
+		})
+	}
+}
+
+// TestOrderSaga_LaterStepsUseTheCreatedOrder has the order service answer
+// with an order stored earlier under the same key: the steps that follow
+// must work on that order, not on the ID the saga asked for.
+func TestOrderSaga_LaterStepsUseTheCreatedOrder(t *testing.T) {
+	client := &fakeOrderClient{byKey: map[string]string{"key-1": "ord_first"}}
+	payload := &Payload{
+		OrderID:        "ord_requested",
+		CustomerID:     "cust-1",
+		Items:          []PayloadItem{{ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "USD")}},
+		Total:          money.New(1000, "USD"),
+		IdempotencyKey: "key-1",
+		Steps:          []string{CreateOrderStepName, ConfirmOrderStepName},
+		DependsOn:      map[string][]string{ConfirmOrderStepName: {CreateOrderStepName}},
+	}
+	nodes, err := NewOrderSagaRegistry(client, nil, nil).BuildNodes(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saga, err := NewGraphOrchestrator(payload.OrderID, nodes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := saga.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if client.created != 0 {
+		t.Errorf("%d orders created, want the existing one", client.created)
+	}
+	if len(client.confirmed) != 1 || client.confirmed[0] != "ord_first" {
+		t.Errorf("confirmed %v, want [ord_first]", client.confirmed)
+	}
+}
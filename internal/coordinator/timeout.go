@@ -0,0 +1,47 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSagaTimedOut wraps the error of a saga that failed because its own
+// deadline (WithSagaTimeout) was exceeded. It is what the saga reports as
+// the reason of the failure, so the terminal FAILED entry and the failure
+// hook both name the timeout. A step that runs out of its execution
+// timeout, or a downstream call that reports DeadlineExceeded, is an
+// ordinary step failure.
+var ErrSagaTimedOut = errors.New("saga timed out")
+
+// Timeouts is implemented by steps that bound how long their actions may
+// take. A zero duration means no limit. The execution timeout applies to
+// each attempt separately, so a RetryPolicy can still retry a slow call.
+type Timeouts interface {
+	ExecuteTimeout() time.Duration
+	CompensateTimeout() time.Duration
+}
+
+// executeTimeoutOf returns the step's execution timeout, or 0.
+func executeTimeoutOf(step Step) time.Duration {
+	if t, ok := step.(Timeouts); ok {
+		return t.ExecuteTimeout()
+	}
+	return 0
+}
+
+// compensateTimeoutOf returns the step's compensation timeout, or 0.
+func compensateTimeoutOf(step Step) time.Duration {
+	if t, ok := step.(Timeouts); ok {
+		return t.CompensateTimeout()
+	}
+	return 0
+}
+
+// withTimeout is context.WithTimeout that treats d <= 0 as "no timeout".
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
@@ -19,8 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Inventory_Reserve_FullMethodName = "/inventory.v1.Inventory/Reserve"
-	Inventory_Release_FullMethodName = "/inventory.v1.Inventory/Release"
+	Inventory_Reserve_FullMethodName          = "/inventory.v1.Inventory/Reserve"
+	Inventory_Release_FullMethodName          = "/inventory.v1.Inventory/Release"
+	Inventory_Commit_FullMethodName           = "/inventory.v1.Inventory/Commit"
+	Inventory_CreateProduct_FullMethodName    = "/inventory.v1.Inventory/CreateProduct"
+	Inventory_GetStock_FullMethodName         = "/inventory.v1.Inventory/GetStock"
+	Inventory_SetStock_FullMethodName         = "/inventory.v1.Inventory/SetStock"
+	Inventory_SetBackorderable_FullMethodName = "/inventory.v1.Inventory/SetBackorderable"
+	Inventory_AdjustStock_FullMethodName      = "/inventory.v1.Inventory/AdjustStock"
+	Inventory_ListProducts_FullMethodName     = "/inventory.v1.Inventory/ListProducts"
+	Inventory_CreateWarehouse_FullMethodName  = "/inventory.v1.Inventory/CreateWarehouse"
+	Inventory_ListWarehouses_FullMethodName   = "/inventory.v1.Inventory/ListWarehouses"
 )
 
 // InventoryClient is the client API for Inventory service.
@@ -31,14 +40,46 @@ const (
 // It ensures that items are locked during the order process and
 // released if the order cannot be completed.
 type InventoryClient interface {
-	// Reserve locks the requested quantity of items for a specific order.
-	// If the items are unavailable, it returns success = false.
+	// Reserve locks the requested quantity of items for a specific order,
+	// allocating them across warehouses by the requested strategy.
+	// Items short of stock are handled by the fulfillment policy; if the
+	// policy cannot accept them, nothing is reserved and it returns
+	// success = false.
 	// This operation is idempotent based on the order_id.
 	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
-	// Release unlocks items previously reserved for an order.
+	// Release unlocks items previously reserved for an order, returning them
+	// to the warehouses they were allocated from.
 	// This is the compensation step used when a payment fails
 	// or the order is cancelled by the orchestrator.
 	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
+	// Commit makes a reservation permanent once the order is paid, so it no
+	// longer expires. It is idempotent; it fails with NOT_FOUND if there is
+	// no reservation for the order and FAILED_PRECONDITION if it expired.
+	Commit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*CommitResponse, error)
+	// CreateProduct adds a product to the catalogue. It fails with
+	// ALREADY_EXISTS if the product_id is taken.
+	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error)
+	// GetStock returns a product with its available and reserved units.
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*GetStockResponse, error)
+	// SetStock overwrites the available units of a product, e.g. after a
+	// stock count. Units held by reservations are not affected.
+	SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*SetStockResponse, error)
+	// SetBackorderable sets whether a product may be ordered beyond its stock
+	// under ALLOW_BACKORDER.
+	SetBackorderable(ctx context.Context, in *SetBackorderableRequest, opts ...grpc.CallOption) (*SetBackorderableResponse, error)
+	// AdjustStock adds (or, with a negative delta, removes) available units,
+	// e.g. on a delivery or a write-off. It fails with FAILED_PRECONDITION
+	// instead of going below zero.
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	// ListProducts returns the catalogue ordered by product_id, one page at
+	// a time.
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	// CreateWarehouse adds a location that holds stock. It fails with
+	// ALREADY_EXISTS if the warehouse_id is taken.
+	CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*CreateWarehouseResponse, error)
+	// ListWarehouses returns every warehouse ordered by warehouse_id,
+	// including the "default" one.
+	ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error)
 }
 
 type inventoryClient struct {
@@ -69,6 +110,96 @@ func (c *inventoryClient) Release(ctx context.Context, in *ReleaseRequest, opts
 	return out, nil
 }
 
+func (c *inventoryClient) Commit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*CommitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitResponse)
+	err := c.cc.Invoke(ctx, Inventory_Commit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateProductResponse)
+	err := c.cc.Invoke(ctx, Inventory_CreateProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*GetStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockResponse)
+	err := c.cc.Invoke(ctx, Inventory_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*SetStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetStockResponse)
+	err := c.cc.Invoke(ctx, Inventory_SetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) SetBackorderable(ctx context.Context, in *SetBackorderableRequest, opts ...grpc.CallOption) (*SetBackorderableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBackorderableResponse)
+	err := c.cc.Invoke(ctx, Inventory_SetBackorderable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, Inventory_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*CreateWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWarehouseResponse)
+	err := c.cc.Invoke(ctx, Inventory_CreateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWarehousesResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListWarehouses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServer is the server API for Inventory service.
 // All implementations must embed UnimplementedInventoryServer
 // for forward compatibility.
@@ -77,14 +208,46 @@ func (c *inventoryClient) Release(ctx context.Context, in *ReleaseRequest, opts
 // It ensures that items are locked during the order process and
 // released if the order cannot be completed.
 type InventoryServer interface {
-	// Reserve locks the requested quantity of items for a specific order.
-	// If the items are unavailable, it returns success = false.
+	// Reserve locks the requested quantity of items for a specific order,
+	// allocating them across warehouses by the requested strategy.
+	// Items short of stock are handled by the fulfillment policy; if the
+	// policy cannot accept them, nothing is reserved and it returns
+	// success = false.
 	// This operation is idempotent based on the order_id.
 	Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error)
-	// Release unlocks items previously reserved for an order.
+	// Release unlocks items previously reserved for an order, returning them
+	// to the warehouses they were allocated from.
 	// This is the compensation step used when a payment fails
 	// or the order is cancelled by the orchestrator.
 	Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
+	// Commit makes a reservation permanent once the order is paid, so it no
+	// longer expires. It is idempotent; it fails with NOT_FOUND if there is
+	// no reservation for the order and FAILED_PRECONDITION if it expired.
+	Commit(context.Context, *CommitRequest) (*CommitResponse, error)
+	// CreateProduct adds a product to the catalogue. It fails with
+	// ALREADY_EXISTS if the product_id is taken.
+	CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
+	// GetStock returns a product with its available and reserved units.
+	GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error)
+	// SetStock overwrites the available units of a product, e.g. after a
+	// stock count. Units held by reservations are not affected.
+	SetStock(context.Context, *SetStockRequest) (*SetStockResponse, error)
+	// SetBackorderable sets whether a product may be ordered beyond its stock
+	// under ALLOW_BACKORDER.
+	SetBackorderable(context.Context, *SetBackorderableRequest) (*SetBackorderableResponse, error)
+	// AdjustStock adds (or, with a negative delta, removes) available units,
+	// e.g. on a delivery or a write-off. It fails with FAILED_PRECONDITION
+	// instead of going below zero.
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	// ListProducts returns the catalogue ordered by product_id, one page at
+	// a time.
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	// CreateWarehouse adds a location that holds stock. It fails with
+	// ALREADY_EXISTS if the warehouse_id is taken.
+	CreateWarehouse(context.Context, *CreateWarehouseRequest) (*CreateWarehouseResponse, error)
+	// ListWarehouses returns every warehouse ordered by warehouse_id,
+	// including the "default" one.
+	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
 	mustEmbedUnimplementedInventoryServer()
 }
 
@@ -101,6 +264,33 @@ func (UnimplementedInventoryServer) Reserve(context.Context, *ReserveRequest) (*
 func (UnimplementedInventoryServer) Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
 }
+func (UnimplementedInventoryServer) Commit(context.Context, *CommitRequest) (*CommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Commit not implemented")
+}
+func (UnimplementedInventoryServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedInventoryServer) GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedInventoryServer) SetStock(context.Context, *SetStockRequest) (*SetStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStock not implemented")
+}
+func (UnimplementedInventoryServer) SetBackorderable(context.Context, *SetBackorderableRequest) (*SetBackorderableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBackorderable not implemented")
+}
+func (UnimplementedInventoryServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedInventoryServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedInventoryServer) CreateWarehouse(context.Context, *CreateWarehouseRequest) (*CreateWarehouseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWarehouse not implemented")
+}
+func (UnimplementedInventoryServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWarehouses not implemented")
+}
 func (UnimplementedInventoryServer) mustEmbedUnimplementedInventoryServer() {}
 func (UnimplementedInventoryServer) testEmbeddedByValue()                   {}
 
@@ -158,6 +348,168 @@ func _Inventory_Release_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inventory_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_Commit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).Commit(ctx, req.(*CommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_SetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).SetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_SetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).SetStock(ctx, req.(*SetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_SetBackorderable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBackorderableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).SetBackorderable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_SetBackorderable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).SetBackorderable(ctx, req.(*SetBackorderableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateWarehouse(ctx, req.(*CreateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarehousesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListWarehouses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListWarehouses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Inventory_ServiceDesc is the grpc.ServiceDesc for Inventory service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -173,6 +525,42 @@ var Inventory_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Release",
 			Handler:    _Inventory_Release_Handler,
 		},
+		{
+			MethodName: "Commit",
+			Handler:    _Inventory_Commit_Handler,
+		},
+		{
+			MethodName: "CreateProduct",
+			Handler:    _Inventory_CreateProduct_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _Inventory_GetStock_Handler,
+		},
+		{
+			MethodName: "SetStock",
+			Handler:    _Inventory_SetStock_Handler,
+		},
+		{
+			MethodName: "SetBackorderable",
+			Handler:    _Inventory_SetBackorderable_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _Inventory_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _Inventory_ListProducts_Handler,
+		},
+		{
+			MethodName: "CreateWarehouse",
+			Handler:    _Inventory_CreateWarehouse_Handler,
+		},
+		{
+			MethodName: "ListWarehouses",
+			Handler:    _Inventory_ListWarehouses_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/inventory/v1/inventory.proto",
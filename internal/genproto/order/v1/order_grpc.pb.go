@@ -22,6 +22,9 @@ const (
 	Order_CreateOrder_FullMethodName       = "/order.v1.Order/CreateOrder"
 	Order_GetOrder_FullMethodName          = "/order.v1.Order/GetOrder"
 	Order_UpdateOrderStatus_FullMethodName = "/order.v1.Order/UpdateOrderStatus"
+	Order_RecordFulfillment_FullMethodName = "/order.v1.Order/RecordFulfillment"
+	Order_GetOrderHistory_FullMethodName   = "/order.v1.Order/GetOrderHistory"
+	Order_ListOrders_FullMethodName        = "/order.v1.Order/ListOrders"
 )
 
 // OrderClient is the client API for Order service.
@@ -36,8 +39,18 @@ type OrderClient interface {
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
 	// GetOrder retrieves the current state of an order by its ID.
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
-	// UpdateOrderStatus update the order status
+	// UpdateOrderStatus moves the order to a new status. Transitions not
+	// allowed by the order lifecycle fail with FAILED_PRECONDITION.
 	UpdateOrderStatus(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*UpdateOrderStatusResponse, error)
+	// RecordFulfillment stores how many units of each item were reserved
+	// and backordered. It is idempotent; it fails with INVALID_ARGUMENT if
+	// the items do not match the order's and FAILED_PRECONDITION once the
+	// order reached a terminal status.
+	RecordFulfillment(ctx context.Context, in *RecordFulfillmentRequest, opts ...grpc.CallOption) (*RecordFulfillmentResponse, error)
+	// GetOrderHistory returns every status change of an order, oldest first.
+	GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error)
+	// ListOrders returns orders matching the filters, one page at a time.
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
 }
 
 type orderClient struct {
@@ -78,6 +91,36 @@ func (c *orderClient) UpdateOrderStatus(ctx context.Context, in *UpdateOrderStat
 	return out, nil
 }
 
+func (c *orderClient) RecordFulfillment(ctx context.Context, in *RecordFulfillmentRequest, opts ...grpc.CallOption) (*RecordFulfillmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordFulfillmentResponse)
+	err := c.cc.Invoke(ctx, Order_RecordFulfillment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderHistoryResponse)
+	err := c.cc.Invoke(ctx, Order_GetOrderHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, Order_ListOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServer is the server API for Order service.
 // All implementations must embed UnimplementedOrderServer
 // for forward compatibility.
@@ -90,8 +133,18 @@ type OrderServer interface {
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 	// GetOrder retrieves the current state of an order by its ID.
 	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
-	// UpdateOrderStatus update the order status
+	// UpdateOrderStatus moves the order to a new status. Transitions not
+	// allowed by the order lifecycle fail with FAILED_PRECONDITION.
 	UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*UpdateOrderStatusResponse, error)
+	// RecordFulfillment stores how many units of each item were reserved
+	// and backordered. It is idempotent; it fails with INVALID_ARGUMENT if
+	// the items do not match the order's and FAILED_PRECONDITION once the
+	// order reached a terminal status.
+	RecordFulfillment(context.Context, *RecordFulfillmentRequest) (*RecordFulfillmentResponse, error)
+	// GetOrderHistory returns every status change of an order, oldest first.
+	GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error)
+	// ListOrders returns orders matching the filters, one page at a time.
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
 	mustEmbedUnimplementedOrderServer()
 }
 
@@ -111,6 +164,15 @@ func (UnimplementedOrderServer) GetOrder(context.Context, *GetOrderRequest) (*Ge
 func (UnimplementedOrderServer) UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*UpdateOrderStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderStatus not implemented")
 }
+func (UnimplementedOrderServer) RecordFulfillment(context.Context, *RecordFulfillmentRequest) (*RecordFulfillmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordFulfillment not implemented")
+}
+func (UnimplementedOrderServer) GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
+func (UnimplementedOrderServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
 func (UnimplementedOrderServer) mustEmbedUnimplementedOrderServer() {}
 func (UnimplementedOrderServer) testEmbeddedByValue()               {}
 
@@ -186,6 +248,60 @@ func _Order_UpdateOrderStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Order_RecordFulfillment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordFulfillmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).RecordFulfillment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_RecordFulfillment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).RecordFulfillment(ctx, req.(*RecordFulfillmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_GetOrderHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).GetOrderHistory(ctx, req.(*GetOrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_ListOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Order_ServiceDesc is the grpc.ServiceDesc for Order service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -205,6 +321,18 @@ var Order_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateOrderStatus",
 			Handler:    _Order_UpdateOrderStatus_Handler,
 		},
+		{
+			MethodName: "RecordFulfillment",
+			Handler:    _Order_RecordFulfillment_Handler,
+		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _Order_GetOrderHistory_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _Order_ListOrders_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/order/v1/order.proto",
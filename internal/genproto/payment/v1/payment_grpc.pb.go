@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Payment_Charge_FullMethodName = "/payment.v1.Payment/Charge"
-	Payment_Refund_FullMethodName = "/payment.v1.Payment/Refund"
+	Payment_Charge_FullMethodName      = "/payment.v1.Payment/Charge"
+	Payment_Refund_FullMethodName      = "/payment.v1.Payment/Refund"
+	Payment_ListRefunds_FullMethodName = "/payment.v1.Payment/ListRefunds"
+	Payment_Authorize_FullMethodName   = "/payment.v1.Payment/Authorize"
+	Payment_Capture_FullMethodName     = "/payment.v1.Payment/Capture"
+	Payment_Void_FullMethodName        = "/payment.v1.Payment/Void"
 )
 
 // PaymentClient is the client API for Payment service.
@@ -34,10 +38,23 @@ type PaymentClient interface {
 	// This operation must be idempotent based on the order_id and
 	// the idempotency key provided in the gRPC metadata.
 	Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
-	// Refund reverses a previously successful charge.
+	// Refund gives back all or part of a captured payment.
 	// This is used as a compensation step in the Order Saga
-	// when subsequent steps (like inventory reservation) fail.
+	// when subsequent steps (like inventory reservation) fail, and for
+	// customer returns. The refunds of an order never exceed what was
+	// captured. Idempotent per (order_id, idempotency_key).
 	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
+	// ListRefunds returns every refund recorded against an order's payment.
+	ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsResponse, error)
+	// Authorize places a hold for the order's amount without taking the
+	// money yet. Idempotent per order_id.
+	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error)
+	// Capture takes the money held by a previous Authorize. Once captured the
+	// payment can only be given back with Refund.
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*CaptureResponse, error)
+	// Void releases an authorization that was not captured, at no cost to
+	// the merchant. It fails with FAILED_PRECONDITION once captured.
+	Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*VoidResponse, error)
 }
 
 type paymentClient struct {
@@ -68,6 +85,46 @@ func (c *paymentClient) Refund(ctx context.Context, in *RefundRequest, opts ...g
 	return out, nil
 }
 
+func (c *paymentClient) ListRefunds(ctx context.Context, in *ListRefundsRequest, opts ...grpc.CallOption) (*ListRefundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefundsResponse)
+	err := c.cc.Invoke(ctx, Payment_ListRefunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorizeResponse)
+	err := c.cc.Invoke(ctx, Payment_Authorize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*CaptureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureResponse)
+	err := c.cc.Invoke(ctx, Payment_Capture_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*VoidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoidResponse)
+	err := c.cc.Invoke(ctx, Payment_Void_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServer is the server API for Payment service.
 // All implementations must embed UnimplementedPaymentServer
 // for forward compatibility.
@@ -79,10 +136,23 @@ type PaymentServer interface {
 	// This operation must be idempotent based on the order_id and
 	// the idempotency key provided in the gRPC metadata.
 	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
-	// Refund reverses a previously successful charge.
+	// Refund gives back all or part of a captured payment.
 	// This is used as a compensation step in the Order Saga
-	// when subsequent steps (like inventory reservation) fail.
+	// when subsequent steps (like inventory reservation) fail, and for
+	// customer returns. The refunds of an order never exceed what was
+	// captured. Idempotent per (order_id, idempotency_key).
 	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
+	// ListRefunds returns every refund recorded against an order's payment.
+	ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsResponse, error)
+	// Authorize places a hold for the order's amount without taking the
+	// money yet. Idempotent per order_id.
+	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
+	// Capture takes the money held by a previous Authorize. Once captured the
+	// payment can only be given back with Refund.
+	Capture(context.Context, *CaptureRequest) (*CaptureResponse, error)
+	// Void releases an authorization that was not captured, at no cost to
+	// the merchant. It fails with FAILED_PRECONDITION once captured.
+	Void(context.Context, *VoidRequest) (*VoidResponse, error)
 	mustEmbedUnimplementedPaymentServer()
 }
 
@@ -99,6 +169,18 @@ func (UnimplementedPaymentServer) Charge(context.Context, *ChargeRequest) (*Char
 func (UnimplementedPaymentServer) Refund(context.Context, *RefundRequest) (*RefundResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
 }
+func (UnimplementedPaymentServer) ListRefunds(context.Context, *ListRefundsRequest) (*ListRefundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefunds not implemented")
+}
+func (UnimplementedPaymentServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (UnimplementedPaymentServer) Capture(context.Context, *CaptureRequest) (*CaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (UnimplementedPaymentServer) Void(context.Context, *VoidRequest) (*VoidResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Void not implemented")
+}
 func (UnimplementedPaymentServer) mustEmbedUnimplementedPaymentServer() {}
 func (UnimplementedPaymentServer) testEmbeddedByValue()                 {}
 
@@ -156,6 +238,78 @@ func _Payment_Refund_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Payment_ListRefunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).ListRefunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_ListRefunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).ListRefunds(ctx, req.(*ListRefundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Authorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Authorize(ctx, req.(*AuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Capture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Capture(ctx, req.(*CaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Void_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Void(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Void_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Void(ctx, req.(*VoidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Payment_ServiceDesc is the grpc.ServiceDesc for Payment service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +325,22 @@ var Payment_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Refund",
 			Handler:    _Payment_Refund_Handler,
 		},
+		{
+			MethodName: "ListRefunds",
+			Handler:    _Payment_ListRefunds_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _Payment_Authorize_Handler,
+		},
+		{
+			MethodName: "Capture",
+			Handler:    _Payment_Capture_Handler,
+		},
+		{
+			MethodName: "Void",
+			Handler:    _Payment_Void_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/payment/v1/payment.proto",
@@ -0,0 +1,52 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+func ProductToProto(p *domain.Product) *inventoryv1.Product {
+	return &inventoryv1.Product{
+		ProductId:     p.ID,
+		Name:          p.Name,
+		Available:     p.Available,
+		Reserved:      p.Reserved,
+		Backorderable: p.Backorderable,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func ShortagesToProto(shortages []domain.Shortage) []*inventoryv1.StockShortage {
+	out := make([]*inventoryv1.StockShortage, len(shortages))
+	for i, s := range shortages {
+		out[i] = &inventoryv1.StockShortage{
+			ProductId: s.ProductID,
+			Reason:    shortageReasonToProto(s.Reason),
+			Requested: s.Requested,
+			Available: s.Available,
+		}
+	}
+	return out
+}
+
+// ReserveFailureToProto summarises shortages: UNKNOWN_PRODUCT wins over
+// OUT_OF_STOCK.
+func ReserveFailureToProto(shortages []domain.Shortage) inventoryv1.ReserveFailure {
+	failure := inventoryv1.ReserveFailure_OUT_OF_STOCK
+	for _, s := range shortages {
+		if s.Reason == domain.ShortageUnknownProduct {
+			failure = inventoryv1.ReserveFailure_UNKNOWN_PRODUCT
+		}
+	}
+	return failure
+}
+
+func shortageReasonToProto(r domain.ShortageReason) inventoryv1.ReserveFailure {
+	if r == domain.ShortageUnknownProduct {
+		return inventoryv1.ReserveFailure_UNKNOWN_PRODUCT
+	}
+	return inventoryv1.ReserveFailure_OUT_OF_STOCK
+}
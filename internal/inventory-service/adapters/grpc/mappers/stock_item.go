@@ -28,3 +28,31 @@ func mapItemsFromProto(protoItems []*inventoryv1.StockItem) []*domain.StockItem
 	}
 	return items
 }
+
+// FulfillmentPolicyFromProto returns the policy for p, or false if p is
+// unknown. Unset means all or nothing.
+func FulfillmentPolicyFromProto(p inventoryv1.FulfillmentPolicy) (domain.FulfillmentPolicy, bool) {
+	switch p {
+	case inventoryv1.FulfillmentPolicy_ALL_OR_NOTHING:
+		return domain.FulfillAllOrNothing, true
+	case inventoryv1.FulfillmentPolicy_PARTIAL:
+		return domain.FulfillPartial, true
+	case inventoryv1.FulfillmentPolicy_ALLOW_BACKORDER:
+		return domain.FulfillAllowBackorder, true
+	default:
+		return "", false
+	}
+}
+
+func ItemFulfillmentsToProto(items []*domain.StockItem) []*inventoryv1.ItemFulfillment {
+	out := make([]*inventoryv1.ItemFulfillment, len(items))
+	for i, it := range items {
+		out[i] = &inventoryv1.ItemFulfillment{
+			ProductId:   it.ProductID,
+			Requested:   it.Quantity,
+			Reserved:    it.Reserved,
+			Backordered: it.Backordered,
+		}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	inventoryv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+func WarehouseToProto(w *domain.Warehouse) *inventoryv1.Warehouse {
+	return &inventoryv1.Warehouse{
+		WarehouseId: w.ID,
+		Name:        w.Name,
+		Location:    &inventoryv1.Location{Latitude: w.Location.Latitude, Longitude: w.Location.Longitude},
+		UnitCost:    w.UnitCost,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+	}
+}
+
+// LocationFromProto returns nil for an unset location.
+func LocationFromProto(l *inventoryv1.Location) *domain.Location {
+	if l == nil {
+		return nil
+	}
+	return &domain.Location{Latitude: l.GetLatitude(), Longitude: l.GetLongitude()}
+}
+
+func WarehouseStockToProto(stock []domain.WarehouseStock) []*inventoryv1.WarehouseStock {
+	out := make([]*inventoryv1.WarehouseStock, len(stock))
+	for i, s := range stock {
+		out[i] = &inventoryv1.WarehouseStock{
+			WarehouseId: s.WarehouseID,
+			Available:   s.Available,
+			Reserved:    s.Reserved,
+		}
+	}
+	return out
+}
+
+func AllocationsToProto(allocations []domain.Allocation) []*inventoryv1.Allocation {
+	out := make([]*inventoryv1.Allocation, len(allocations))
+	for i, a := range allocations {
+		out[i] = &inventoryv1.Allocation{
+			ProductId:   a.ProductID,
+			WarehouseId: a.WarehouseID,
+			Quantity:    a.Quantity,
+		}
+	}
+	return out
+}
+
+// AllocationStrategyFromProto returns the built-in strategy for s, or
+// false if s is unset or unknown.
+func AllocationStrategyFromProto(s inventoryv1.AllocationStrategy) (domain.AllocationStrategy, bool) {
+	switch s {
+	case inventoryv1.AllocationStrategy_CLOSEST:
+		return domain.ClosestStrategy{}, true
+	case inventoryv1.AllocationStrategy_CHEAPEST:
+		return domain.CheapestStrategy{}, true
+	case inventoryv1.AllocationStrategy_FEWEST_SPLITS:
+		return domain.FewestSplitsStrategy{}, true
+	default:
+		return nil, false
+	}
+}
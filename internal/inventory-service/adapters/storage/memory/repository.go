@@ -0,0 +1,537 @@
+// Package memory provides an in-memory implementation of
+// domain.InventoryRepository, intended for tests and local experiments.
+package memory
+
+import (
+	"context"
+	"hash/maphash"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+var _ domain.InventoryRepository = (*Repository)(nil)
+
+// shardCount is the number of shards the reservations are spread over.
+const shardCount = 64
+
+// Repository keeps products and reservations in maps. Values are copied on
+// the way in and out so callers can never mutate the stored state by
+// accident.
+//
+// No lock is shared by all reservations: every product has its own lock,
+// and the reservations and their idempotency-key index are split into
+// shards by order ID and by key. Locks are always taken in this order,
+// which rules out deadlocks:
+//
+//  1. the locks of the products involved, by ascending product ID;
+//  2. the shards of the order ID and the idempotency key, by ascending
+//     index, only for as long as it takes to look up or change a
+//     reservation.
+//
+// mu only guards the warehouses and is never held together with another
+// lock.
+type Repository struct {
+	mu         sync.RWMutex
+	warehouses map[string]*domain.Warehouse
+
+	// products maps IDs to *productEntry. Products are never deleted, so
+	// an entry, once loaded, stays valid.
+	products sync.Map
+
+	seed   maphash.Seed
+	shards [shardCount]shard
+}
+
+// productEntry is a product with its stock per warehouse, both guarded by
+// mu.
+type productEntry struct {
+	mu      sync.Mutex
+	product domain.Product
+	stock   map[string]*domain.WarehouseStock // warehouse ID -> stock
+}
+
+// shard holds the reservations of the order IDs, and the index entries of
+// the idempotency keys, that hash to it.
+type shard struct {
+	mu           sync.Mutex
+	reservations map[string]*domain.Reserve // order ID -> reservation
+	byKey        map[string]string          // idempotency key -> order ID
+}
+
+// New creates a repository holding only the default warehouse.
+func New() *Repository {
+	r := &Repository{
+		warehouses: map[string]*domain.Warehouse{
+			domain.DefaultWarehouseID: {ID: domain.DefaultWarehouseID, Name: domain.DefaultWarehouseID, CreatedAt: time.Now().UTC()},
+		},
+		seed: maphash.MakeSeed(),
+	}
+	for i := range r.shards {
+		r.shards[i].reservations = make(map[string]*domain.Reserve)
+		r.shards[i].byKey = make(map[string]string)
+	}
+	return r
+}
+
+func (r *Repository) CreateWarehouse(_ context.Context, w *domain.Warehouse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, taken := r.warehouses[w.ID]; taken {
+		return domain.ErrWarehouseExists
+	}
+	c := *w
+	r.warehouses[w.ID] = &c
+	return nil
+}
+
+func (r *Repository) ListWarehouses(_ context.Context) ([]*domain.Warehouse, error) {
+	r.mu.RLock()
+	out := make([]*domain.Warehouse, 0, len(r.warehouses))
+	for _, w := range r.warehouses {
+		c := *w
+		out = append(out, &c)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b *domain.Warehouse) int { return strings.Compare(a.ID, b.ID) })
+	return out, nil
+}
+
+func (r *Repository) CreateProduct(_ context.Context, p *domain.Product) error {
+	e := &productEntry{
+		product: *p,
+		stock: map[string]*domain.WarehouseStock{
+			domain.DefaultWarehouseID: {
+				ProductID:   p.ID,
+				WarehouseID: domain.DefaultWarehouseID,
+				Available:   p.Available,
+				Reserved:    p.Reserved,
+			},
+		},
+	}
+	if _, taken := r.products.LoadOrStore(p.ID, e); taken {
+		return domain.ErrProductExists
+	}
+	return nil
+}
+
+func (r *Repository) GetProduct(_ context.Context, id string) (*domain.Product, error) {
+	e, ok := r.product(id)
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c := e.product
+	return &c, nil
+}
+
+func (r *Repository) ListProducts(_ context.Context, filter domain.ProductFilter) (*domain.ProductPage, error) {
+	var matches []*domain.Product
+	r.products.Range(func(id, v any) bool {
+		if id.(string) > filter.After {
+			e := v.(*productEntry)
+			e.mu.Lock()
+			c := e.product
+			e.mu.Unlock()
+			matches = append(matches, &c)
+		}
+		return true
+	})
+
+	slices.SortFunc(matches, func(a, b *domain.Product) int { return strings.Compare(a.ID, b.ID) })
+
+	page := &domain.ProductPage{Products: matches}
+	if limit := filter.PageLimit(); len(matches) > limit {
+		page.Products = matches[:limit]
+		page.NextCursor = page.Products[limit-1].ID
+	}
+	return page, nil
+}
+
+func (r *Repository) SetStock(_ context.Context, id, warehouseID string, available int32) (*domain.Product, error) {
+	if available < 0 {
+		return nil, domain.ErrNegativeStock
+	}
+	return r.updateStock(id, warehouseID, func(int32) int32 { return available })
+}
+
+func (r *Repository) AdjustStock(_ context.Context, id, warehouseID string, delta int32) (*domain.Product, error) {
+	return r.updateStock(id, warehouseID, func(old int32) int32 { return old + delta })
+}
+
+// updateStock sets the available units of product id in a warehouse to
+// what update makes of the current ones, and updates the product totals.
+func (r *Repository) updateStock(id, warehouseID string, update func(old int32) int32) (*domain.Product, error) {
+	e, ok := r.product(id)
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+	// Warehouses are never deleted either.
+	r.mu.RLock()
+	_, ok = r.warehouses[warehouseID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, domain.ErrWarehouseNotFound
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ws, ok := e.stock[warehouseID]
+	if !ok {
+		ws = &domain.WarehouseStock{ProductID: id, WarehouseID: warehouseID}
+	}
+	available := update(ws.Available)
+	if available < 0 {
+		return nil, domain.ErrInsufficientStock
+	}
+
+	e.product.Available += available - ws.Available
+	e.product.UpdatedAt = time.Now().UTC()
+	ws.Available = available
+	e.stock[warehouseID] = ws
+	c := e.product
+	return &c, nil
+}
+
+func (r *Repository) SetBackorderable(_ context.Context, id string, backorderable bool) (*domain.Product, error) {
+	e, ok := r.product(id)
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.product.Backorderable = backorderable
+	e.product.UpdatedAt = time.Now().UTC()
+	c := e.product
+	return &c, nil
+}
+
+func (r *Repository) Available(_ context.Context, ids []string) (map[string]int32, error) {
+	out := make(map[string]int32, len(ids))
+	for _, id := range ids {
+		if e, ok := r.product(id); ok {
+			e.mu.Lock()
+			out[id] = e.product.Available
+			e.mu.Unlock()
+		}
+	}
+	return out, nil
+}
+
+func (r *Repository) Stock(_ context.Context, ids []string) ([]domain.WarehouseStock, error) {
+	var out []domain.WarehouseStock
+	for _, id := range slices.Compact(slices.Sorted(slices.Values(ids))) {
+		e, ok := r.product(id)
+		if !ok {
+			continue
+		}
+		start := len(out)
+		e.mu.Lock()
+		for _, ws := range e.stock {
+			out = append(out, *ws)
+		}
+		e.mu.Unlock()
+		slices.SortFunc(out[start:], func(a, b domain.WarehouseStock) int {
+			return strings.Compare(a.WarehouseID, b.WarehouseID)
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) Reserve(_ context.Context, res *domain.Reserve) error {
+	locked, complete := r.lockProducts(res)
+	defer unlockProducts(locked)
+
+	shards := r.lockShards(res.OrderID, res.IdempotencyKey)
+	switch {
+	case r.reservationExists(res):
+		unlockShards(shards)
+		return domain.ErrReservationExists
+	case !complete:
+		unlockShards(shards)
+		return domain.ErrProductNotFound
+	}
+	for _, a := range res.Allocations {
+		e, _ := r.product(a.ProductID)
+		if ws, ok := e.stock[a.WarehouseID]; !ok || ws.Available < a.Quantity {
+			unlockShards(shards)
+			return domain.ErrInsufficientStock
+		}
+	}
+	r.shardOf(res.OrderID).reservations[res.OrderID] = cloneReserve(res)
+	if res.IdempotencyKey != "" {
+		r.shardOf(res.IdempotencyKey).byKey[res.IdempotencyKey] = res.OrderID
+	}
+	unlockShards(shards)
+
+	// Anyone releasing or committing the new reservation waits for the
+	// product locks, so it cannot see the stock before it is moved.
+	now := time.Now().UTC()
+	for _, a := range res.Allocations {
+		e, _ := r.product(a.ProductID)
+		ws := e.stock[a.WarehouseID]
+		ws.Available -= a.Quantity
+		ws.Reserved += a.Quantity
+		e.product.Available -= a.Quantity
+		e.product.Reserved += a.Quantity
+		e.product.UpdatedAt = now
+	}
+	return nil
+}
+
+// reservationExists reports whether res's order or idempotency key already
+// has a reservation. The caller holds the shards of both.
+func (r *Repository) reservationExists(res *domain.Reserve) bool {
+	if _, exists := r.shardOf(res.OrderID).reservations[res.OrderID]; exists {
+		return true
+	}
+	if res.IdempotencyKey == "" {
+		return false
+	}
+	_, taken := r.shardOf(res.IdempotencyKey).byKey[res.IdempotencyKey]
+	return taken
+}
+
+func (r *Repository) GetReservation(_ context.Context, orderID string) (*domain.Reserve, error) {
+	s := r.shardOf(orderID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.reservations[orderID]
+	if !ok {
+		return nil, domain.ErrReservationNotFound
+	}
+	return cloneReserve(res), nil
+}
+
+func (r *Repository) GetReservationByKey(ctx context.Context, key string) (*domain.Reserve, error) {
+	s := r.shardOf(key)
+	s.mu.Lock()
+	orderID, ok := s.byKey[key]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, domain.ErrReservationNotFound
+	}
+	return r.GetReservation(ctx, orderID)
+}
+
+func (r *Repository) Release(_ context.Context, orderID string) (*domain.Reserve, error) {
+	return r.release(orderID, func(*domain.Reserve) bool { return true })
+}
+
+func (r *Repository) ReleaseExpired(_ context.Context, orderID string, now time.Time) (*domain.Reserve, error) {
+	return r.release(orderID, func(res *domain.Reserve) bool { return res.Expired(now) })
+}
+
+// release returns the allocated units of the reservation of orderID to the
+// available stock of their warehouses and forgets it, unless releasable
+// says it must stay.
+func (r *Repository) release(orderID string, releasable func(*domain.Reserve) bool) (*domain.Reserve, error) {
+	res, locked, shards, err := r.lockReservation(orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockProducts(locked)
+
+	if !releasable(res) {
+		unlockShards(shards)
+		return nil, domain.ErrReservationNotFound
+	}
+	committed := res.Committed()
+	delete(r.shardOf(orderID).reservations, orderID)
+	if res.IdempotencyKey != "" {
+		delete(r.shardOf(res.IdempotencyKey).byKey, res.IdempotencyKey)
+	}
+	unlockShards(shards)
+
+	now := time.Now().UTC()
+	for _, a := range res.Allocations {
+		// Stock rows are never deleted, so reserved ones still exist.
+		e, _ := r.product(a.ProductID)
+		ws := e.stock[a.WarehouseID]
+		ws.Available += a.Quantity
+		e.product.Available += a.Quantity
+		if !committed {
+			ws.Reserved -= a.Quantity
+			e.product.Reserved -= a.Quantity
+		}
+		e.product.UpdatedAt = now
+	}
+	return res, nil
+}
+
+func (r *Repository) Commit(_ context.Context, orderID string, now time.Time) (*domain.Reserve, error) {
+	res, locked, shards, err := r.lockReservation(orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockProducts(locked)
+
+	switch {
+	case res.Committed():
+		c := cloneReserve(res)
+		unlockShards(shards)
+		return c, nil
+	case res.Expired(now):
+		unlockShards(shards)
+		return nil, domain.ErrReservationExpired
+	}
+	res.CommittedAt = now
+	c := cloneReserve(res)
+	unlockShards(shards)
+
+	for _, a := range res.Allocations {
+		e, _ := r.product(a.ProductID)
+		e.stock[a.WarehouseID].Reserved -= a.Quantity
+		e.product.Reserved -= a.Quantity
+		e.product.UpdatedAt = now
+	}
+	return c, nil
+}
+
+func (r *Repository) Expired(_ context.Context, now time.Time, limit int) ([]*domain.Reserve, error) {
+	var expired []*domain.Reserve
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		for _, res := range s.reservations {
+			if res.Expired(now) {
+				expired = append(expired, cloneReserve(res))
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	slices.SortFunc(expired, func(a, b *domain.Reserve) int { return a.ExpiresAt.Compare(b.ExpiresAt) })
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+// product returns the entry of product id.
+func (r *Repository) product(id string) (*productEntry, bool) {
+	v, ok := r.products.Load(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*productEntry), true
+}
+
+// lockProducts locks the known products res takes stock of by ascending ID
+// and returns them, and whether all of them are known. The caller unlocks
+// them with unlockProducts.
+func (r *Repository) lockProducts(res *domain.Reserve) ([]*productEntry, bool) {
+	ids := make([]string, len(res.Allocations))
+	for i, a := range res.Allocations {
+		ids[i] = a.ProductID
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	complete := true
+	entries := make([]*productEntry, 0, len(ids))
+	for _, id := range ids {
+		e, ok := r.product(id)
+		if !ok {
+			complete = false
+			continue
+		}
+		e.mu.Lock()
+		entries = append(entries, e)
+	}
+	return entries, complete
+}
+
+func unlockProducts(entries []*productEntry) {
+	for _, e := range entries {
+		e.mu.Unlock()
+	}
+}
+
+// shardIndex returns the index of the shard an order ID or idempotency key
+// hashes to.
+func (r *Repository) shardIndex(id string) uint64 {
+	return maphash.String(r.seed, id) % shardCount
+}
+
+func (r *Repository) shardOf(id string) *shard {
+	return &r.shards[r.shardIndex(id)]
+}
+
+// lockShards locks the shards of orderID and, unless it is empty, key by
+// ascending index. The caller unlocks them with unlockShards.
+func (r *Repository) lockShards(orderID, key string) [2]*shard {
+	i := r.shardIndex(orderID)
+	j := i
+	if key != "" {
+		j = r.shardIndex(key)
+	}
+	if j < i {
+		i, j = j, i
+	}
+
+	locked := [2]*shard{&r.shards[i]}
+	if j != i {
+		locked[1] = &r.shards[j]
+	}
+	for _, s := range locked {
+		if s != nil {
+			s.mu.Lock()
+		}
+	}
+	return locked
+}
+
+func unlockShards(locked [2]*shard) {
+	for _, s := range locked {
+		if s != nil {
+			s.mu.Unlock()
+		}
+	}
+}
+
+// lockReservation locks the products of the reservation of orderID and
+// then the shards of its order ID and idempotency key, and returns the
+// reservation and what it locked. The caller unlocks the shards as soon as
+// it is done with the reservation, and the products with unlockProducts.
+func (r *Repository) lockReservation(orderID string) (*domain.Reserve, []*productEntry, [2]*shard, error) {
+	s := r.shardOf(orderID)
+	for {
+		s.mu.Lock()
+		res, ok := s.reservations[orderID]
+		s.mu.Unlock()
+		if !ok {
+			return nil, nil, [2]*shard{}, domain.ErrReservationNotFound
+		}
+
+		// The allocations and key of a stored reservation never change, so
+		// they can be read without the shard lock. Its products exist.
+		locked, _ := r.lockProducts(res)
+		shards := r.lockShards(orderID, res.IdempotencyKey)
+		if s.reservations[orderID] == res {
+			return res, locked, shards, nil
+		}
+		// Released, and maybe made again, while the products were locked.
+		unlockShards(shards)
+		unlockProducts(locked)
+	}
+}
+
+func cloneReserve(res *domain.Reserve) *domain.Reserve {
+	c := *res
+	c.Items = make([]*domain.StockItem, len(res.Items))
+	for i, it := range res.Items {
+		item := *it
+		c.Items[i] = &item
+	}
+	c.Allocations = slices.Clone(res.Allocations)
+	return &c
+}
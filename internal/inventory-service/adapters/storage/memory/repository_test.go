@@ -0,0 +1,210 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/adapters/storage/memory"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+// newRepository returns a repository with n products of stock units each,
+// named p00, p01 and so on.
+func newRepository(tb testing.TB, n int, stock int32) (*memory.Repository, []string) {
+	tb.Helper()
+	repo := memory.New()
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("p%02d", i)
+		p, err := domain.NewProduct(ids[i], ids[i], stock, time.Now().UTC())
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := repo.CreateProduct(context.Background(), p); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return repo, ids
+}
+
+// reservation takes quantity units of each product from the default
+// warehouse.
+func reservation(orderID, key string, quantity int32, ids ...string) *domain.Reserve {
+	res := &domain.Reserve{OrderID: orderID, IdempotencyKey: key}
+	for _, id := range ids {
+		res.Items = append(res.Items, &domain.StockItem{ProductID: id, Quantity: quantity, Reserved: quantity})
+		res.Allocations = append(res.Allocations, domain.Allocation{ProductID: id, WarehouseID: domain.DefaultWarehouseID, Quantity: quantity})
+	}
+	return res
+}
+
+// TestRepository_ConcurrentReservations reserves, commits and releases
+// overlapping sets of products, listed in random order, from many
+// goroutines. Run it with -race.
+func TestRepository_ConcurrentReservations(t *testing.T) {
+	const (
+		stock   = 50
+		workers = 16
+		rounds  = 200
+	)
+	ctx := context.Background()
+	repo, ids := newRepository(t, 8, stock)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				picked := append([]string(nil), ids...)
+				rand.Shuffle(len(picked), func(a, b int) { picked[a], picked[b] = picked[b], picked[a] })
+				picked = picked[:1+rand.IntN(3)]
+
+				orderID := fmt.Sprintf("order-%d-%d", w, i)
+				err := repo.Reserve(ctx, reservation(orderID, orderID, 1+rand.Int32N(3), picked...))
+				if errors.Is(err, domain.ErrInsufficientStock) {
+					continue
+				}
+				if err != nil {
+					t.Errorf("reserve %s: %v", orderID, err)
+					return
+				}
+				if i%2 == 0 {
+					if _, err := repo.Commit(ctx, orderID, time.Now().UTC()); err != nil {
+						t.Errorf("commit %s: %v", orderID, err)
+						return
+					}
+				}
+				if _, err := repo.Release(ctx, orderID); err != nil {
+					t.Errorf("release %s: %v", orderID, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		p, err := repo.GetProduct(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Available != stock || p.Reserved != 0 {
+			t.Errorf("%s: available %d, reserved %d after releasing everything; want %d, 0", id, p.Available, p.Reserved, stock)
+		}
+	}
+}
+
+func TestRepository_ConcurrentReservationsDoNotOversell(t *testing.T) {
+	const stock = 20
+	ctx := context.Background()
+	repo, ids := newRepository(t, 1, stock)
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for i := range 5 * stock {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.Reserve(ctx, reservation(fmt.Sprintf("order-%d", i), "", 1, ids...))
+			switch {
+			case err == nil:
+				reserved.Add(1)
+			case !errors.Is(err, domain.ErrInsufficientStock):
+				t.Errorf("reserve: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := reserved.Load(); got != stock {
+		t.Errorf("%d reservations succeeded, want %d", got, stock)
+	}
+	p, err := repo.GetProduct(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Available != 0 || p.Reserved != stock {
+		t.Errorf("available %d, reserved %d; want 0, %d", p.Available, p.Reserved, stock)
+	}
+}
+
+func TestRepository_ConcurrentRetriesReserveOnce(t *testing.T) {
+	ctx := context.Background()
+	repo, ids := newRepository(t, 2, 100)
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Half the retries reuse the order, half only the key.
+			orderID := "order-1"
+			if i%2 == 1 {
+				orderID = fmt.Sprintf("retry-%d", i)
+			}
+			err := repo.Reserve(ctx, reservation(orderID, "key-1", 1, ids...))
+			switch {
+			case err == nil:
+				reserved.Add(1)
+			case !errors.Is(err, domain.ErrReservationExists):
+				t.Errorf("reserve: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := reserved.Load(); got != 1 {
+		t.Fatalf("%d reservations succeeded, want 1", got)
+	}
+	res, err := repo.GetReservationByKey(ctx, "key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		p, err := repo.GetProduct(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Reserved != 1 {
+			t.Errorf("%s: reserved %d for order %s, want 1", id, p.Reserved, res.OrderID)
+		}
+	}
+}
+
+// BenchmarkRepository_Reserve reserves and releases one unit of a product
+// from parallel goroutines, spread over a growing number of products. With
+// more than one CPU (-cpu), throughput grows with the number of products,
+// as reservations of different products do not wait for each other.
+func BenchmarkRepository_Reserve(b *testing.B) {
+	for _, n := range []int{1, 4, 16, 64} {
+		b.Run(fmt.Sprintf("products=%d", n), func(b *testing.B) {
+			ctx := context.Background()
+			repo, ids := newRepository(b, n, 1<<30)
+			var next atomic.Int64
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					i := next.Add(1)
+					orderID := fmt.Sprintf("order-%d", i)
+					if err := repo.Reserve(ctx, reservation(orderID, orderID, 1, ids[int(i)%n])); err != nil {
+						b.Error(err)
+						return
+					}
+					if _, err := repo.Release(ctx, orderID); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,913 @@
+// Package sqlite provides a SQLite-backed implementation of
+// domain.InventoryRepository so stock and reservations survive restarts.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/sqlitetime"
+
+	// Register the pure-Go SQLite driver (no CGO, see the saga log store).
+	_ "modernc.org/sqlite"
+)
+
+// schema is the DDL executed once on startup.
+const schema = `
+CREATE TABLE IF NOT EXISTS warehouses (
+    id          TEXT PRIMARY KEY,
+    name        TEXT    NOT NULL DEFAULT '',
+    latitude    REAL    NOT NULL DEFAULT 0,
+    longitude   REAL    NOT NULL DEFAULT 0,
+    -- Shipping cost per unit, in minor units of the shop currency.
+    unit_cost   INTEGER NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    created_at  TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id          TEXT PRIMARY KEY,
+    name        TEXT    NOT NULL DEFAULT '',
+    -- Totals over the product's rows in stock, kept in the same
+    -- transactions so that reads need no aggregation.
+    -- Units that can still be reserved.
+    available   INTEGER NOT NULL CHECK (available >= 0),
+    -- Units held by open reservations.
+    reserved    INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    -- 1 if the product may be ordered beyond its stock.
+    backorderable INTEGER NOT NULL DEFAULT 0,
+
+    -- Fixed-width RFC3339 TEXT, see sqlitetime.Layout.
+    created_at  TEXT NOT NULL,
+    updated_at  TEXT NOT NULL
+);
+
+-- Stock of a product in one warehouse.
+CREATE TABLE IF NOT EXISTS stock (
+    product_id    TEXT    NOT NULL REFERENCES products(id),
+    warehouse_id  TEXT    NOT NULL REFERENCES warehouses(id),
+    available     INTEGER NOT NULL CHECK (available >= 0),
+    reserved      INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    PRIMARY KEY (product_id, warehouse_id)
+);
+
+CREATE TABLE IF NOT EXISTS reservations (
+    order_id        TEXT PRIMARY KEY,
+    -- Client-supplied X-Idempotency-Key; '' when the request had none.
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    request_id      TEXT NOT NULL DEFAULT '',
+    created_at      TEXT NOT NULL,
+    -- '' never expires. Uncommitted reservations past it are reaped.
+    expires_at      TEXT NOT NULL DEFAULT '',
+    -- '' until the order is paid and the units are sold.
+    committed_at    TEXT NOT NULL DEFAULT '',
+    -- Request that made the reservation, for tracing automatic releases.
+    trace_id        TEXT NOT NULL DEFAULT '',
+    span_id         TEXT NOT NULL DEFAULT ''
+);
+
+-- At most one reservation per idempotency key. Requests without a key are exempt.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reservations_idempotency_key
+    ON reservations(idempotency_key) WHERE idempotency_key <> '';
+
+CREATE TABLE IF NOT EXISTS reservation_items (
+    order_id    TEXT    NOT NULL REFERENCES reservations(order_id) ON DELETE CASCADE,
+    -- Preserves the order in which the client listed the items.
+    position    INTEGER NOT NULL,
+    product_id  TEXT    NOT NULL REFERENCES products(id),
+    -- Units requested; reserved + backordered of them were accepted.
+    quantity    INTEGER NOT NULL,
+    reserved    INTEGER NOT NULL DEFAULT 0,
+    backordered INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (order_id, position)
+);
+
+-- Units a reservation takes from each warehouse; a release returns these.
+CREATE TABLE IF NOT EXISTS reservation_allocations (
+    order_id      TEXT    NOT NULL REFERENCES reservations(order_id) ON DELETE CASCADE,
+    product_id    TEXT    NOT NULL,
+    warehouse_id  TEXT    NOT NULL,
+    quantity      INTEGER NOT NULL,
+    PRIMARY KEY (order_id, product_id, warehouse_id),
+    FOREIGN KEY (product_id, warehouse_id) REFERENCES stock(product_id, warehouse_id)
+);
+`
+
+// indexes is executed after the migrations, as it covers migrated columns.
+const indexes = `
+-- Lets the reaper find expired reservations without a full scan.
+CREATE INDEX IF NOT EXISTS idx_reservations_expires_at
+    ON reservations(expires_at) WHERE committed_at = '' AND expires_at <> '';
+`
+
+// migrations adds columns introduced after a table was first released.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so databases
+// created by an older build get the new columns here. A migration may carry
+// statements that bring existing rows in line; they run in the transaction
+// that adds the column, so they take effect exactly once.
+var migrations = []struct {
+	table, column, ddl string
+}{
+	{"reservations", "expires_at", `ALTER TABLE reservations ADD COLUMN expires_at TEXT NOT NULL DEFAULT ''`},
+	// Older builds had no commit step: their reservations belong to orders
+	// that are long settled, so they are treated as committed rather than
+	// reaped, which would restock units that were sold. Committed units no
+	// longer count as reserved.
+	{"reservations", "committed_at", `
+		ALTER TABLE reservations ADD COLUMN committed_at TEXT NOT NULL DEFAULT '';
+		UPDATE reservations SET committed_at = created_at;
+		UPDATE products
+		SET    reserved = reserved - (
+		           SELECT COALESCE(SUM(quantity), 0)
+		           FROM   reservation_items
+		           WHERE  product_id = products.id
+		       )`},
+	{"reservations", "trace_id", `ALTER TABLE reservations ADD COLUMN trace_id TEXT NOT NULL DEFAULT ''`},
+	{"reservations", "span_id", `ALTER TABLE reservations ADD COLUMN span_id TEXT NOT NULL DEFAULT ''`},
+	{"products", "backorderable", `ALTER TABLE products ADD COLUMN backorderable INTEGER NOT NULL DEFAULT 0`},
+	// Reservations used to be all or nothing.
+	{"reservation_items", "reserved", `
+		ALTER TABLE reservation_items ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0;
+		UPDATE reservation_items SET reserved = quantity`},
+	{"reservation_items", "backordered", `ALTER TABLE reservation_items ADD COLUMN backordered INTEGER NOT NULL DEFAULT 0`},
+}
+
+// backfills bring data written by builds that predate warehouses in line
+// with the schema: their stock and reservations belong to the default
+// warehouse. Each statement only adds rows that are missing, so they run on
+// every start. The parameter is domain.DefaultWarehouseID.
+var backfills = []string{
+	`INSERT INTO stock (product_id, warehouse_id, available, reserved)
+	 SELECT id, ?, available, reserved
+	 FROM   products p
+	 WHERE  NOT EXISTS (SELECT 1 FROM stock s WHERE s.product_id = p.id)`,
+	`INSERT INTO reservation_allocations (order_id, product_id, warehouse_id, quantity)
+	 SELECT order_id, product_id, ?, SUM(reserved)
+	 FROM   reservation_items i
+	 WHERE  reserved > 0
+	   AND  NOT EXISTS (SELECT 1 FROM reservation_allocations a WHERE a.order_id = i.order_id)
+	 GROUP  BY order_id, product_id`,
+}
+
+var _ domain.InventoryRepository = (*Repository)(nil)
+
+// Repository is the SQLite implementation of domain.InventoryRepository.
+//
+// SQLite admits one writer at a time, so writes share a single connection
+// and queue for it instead of failing with SQLITE_BUSY: the transactions of
+// Reserve, Release and Commit run one after the other whatever products
+// they touch, and are kept to a few statements. Reads, among them
+// everything Reserve looks up to plan a reservation, use a pool of their
+// own and in WAL mode run concurrently with each other and with the
+// writer.
+type Repository struct {
+	db   *sql.DB // the writer
+	read *sql.DB
+}
+
+// Open opens (or creates) the SQLite database at path and applies the schema.
+//
+//	repo, err := sqlite.Open("./data/inventory.db")
+func Open(path string) (*Repository, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=foreign_keys(on)&_pragma=busy_timeout(5000)", path)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: open %q: %w", path, err)
+	}
+
+	// SQLite performs best with a single writer connection.
+	db.SetMaxOpenConns(1)
+
+	if err := applySchema(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	read, err := sql.Open("sqlite", dsn+"&_pragma=query_only(1)")
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("sqlite: open %q: %w", path, err)
+	}
+	read.SetMaxOpenConns(max(4, runtime.NumCPU()))
+
+	return &Repository{db: db, read: read}, nil
+}
+
+// Close releases the database connections. Call it with defer in main().
+func (r *Repository) Close() error {
+	return errors.Join(r.read.Close(), r.db.Close())
+}
+
+// CreateWarehouse inserts a warehouse. A clash on the ID is reported as
+// domain.ErrWarehouseExists.
+func (r *Repository) CreateWarehouse(ctx context.Context, w *domain.Warehouse) error {
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO warehouses (id, name, latitude, longitude, unit_cost, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		w.ID, w.Name, w.Location.Latitude, w.Location.Longitude, w.UnitCost, sqlitetime.Format(w.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: create warehouse %q: %w", w.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("sqlite: create warehouse %q: %w", w.ID, err)
+	} else if n == 0 {
+		return domain.ErrWarehouseExists
+	}
+	return nil
+}
+
+// ListWarehouses returns every warehouse ordered by ID.
+func (r *Repository) ListWarehouses(ctx context.Context) ([]*domain.Warehouse, error) {
+	rows, err := r.read.QueryContext(ctx, `
+		SELECT id, name, latitude, longitude, unit_cost, created_at
+		FROM   warehouses
+		ORDER  BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list warehouses: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*domain.Warehouse
+	for rows.Next() {
+		var w domain.Warehouse
+		var createdAt string
+		if err := rows.Scan(&w.ID, &w.Name, &w.Location.Latitude, &w.Location.Longitude, &w.UnitCost, &createdAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan warehouse: %w", err)
+		}
+		if w.CreatedAt, err = sqlitetime.Parse(createdAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate warehouses: %w", err)
+	}
+	return out, nil
+}
+
+// CreateProduct inserts a product and its stock in the default warehouse.
+// A clash on the ID is reported as domain.ErrProductExists.
+func (r *Repository) CreateProduct(ctx context.Context, p *domain.Product) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: create product %q: %w", p.ID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO products (id, name, available, reserved, backorderable, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		p.ID, p.Name, p.Available, p.Reserved, p.Backorderable, sqlitetime.Format(p.CreatedAt), sqlitetime.Format(p.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: create product %q: %w", p.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("sqlite: create product %q: %w", p.ID, err)
+	} else if n == 0 {
+		return domain.ErrProductExists
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO stock (product_id, warehouse_id, available, reserved)
+		VALUES (?, ?, ?, ?)`,
+		p.ID, domain.DefaultWarehouseID, p.Available, p.Reserved,
+	); err != nil {
+		return fmt.Errorf("sqlite: create stock of product %q: %w", p.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: create product %q: %w", p.ID, err)
+	}
+	return nil
+}
+
+// GetProduct returns the product with the given ID.
+func (r *Repository) GetProduct(ctx context.Context, id string) (*domain.Product, error) {
+	return getProduct(ctx, r.read, id)
+}
+
+// ListProducts returns one page of products using keyset pagination on id.
+func (r *Repository) ListProducts(ctx context.Context, filter domain.ProductFilter) (*domain.ProductPage, error) {
+	// Fetch one extra row to know whether another page follows.
+	limit := filter.PageLimit()
+	rows, err := r.read.QueryContext(ctx, `
+		SELECT `+productColumns+`
+		FROM   products
+		WHERE  id > ?
+		ORDER  BY id
+		LIMIT  ?`, filter.After, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list products: %w", err)
+	}
+	defer rows.Close()
+
+	page := &domain.ProductPage{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scan product: %w", err)
+		}
+		page.Products = append(page.Products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate products: %w", err)
+	}
+
+	if len(page.Products) > limit {
+		page.Products = page.Products[:limit]
+		page.NextCursor = page.Products[limit-1].ID
+	}
+	return page, nil
+}
+
+// SetStock overwrites the available units of a product in a warehouse.
+func (r *Repository) SetStock(ctx context.Context, id, warehouseID string, available int32) (*domain.Product, error) {
+	if available < 0 {
+		return nil, domain.ErrNegativeStock
+	}
+	return r.updateStock(ctx, id, warehouseID, func(int32) int32 { return available })
+}
+
+// AdjustStock adds delta to the available units of a product in a
+// warehouse.
+func (r *Repository) AdjustStock(ctx context.Context, id, warehouseID string, delta int32) (*domain.Product, error) {
+	return r.updateStock(ctx, id, warehouseID, func(old int32) int32 { return old + delta })
+}
+
+// updateStock sets the available units of product id in a warehouse to
+// what update makes of the current ones, creating the stock row if needed,
+// and moves the product total by the same amount in one transaction.
+func (r *Repository) updateStock(ctx context.Context, id, warehouseID string, update func(old int32) int32) (*domain.Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: update stock of %q: %w", id, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := getProduct(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	var exists bool
+	if err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM warehouses WHERE id = ?)`, warehouseID,
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("sqlite: update stock of %q: %w", id, err)
+	}
+	if !exists {
+		return nil, domain.ErrWarehouseNotFound
+	}
+
+	var old int32
+	err = tx.QueryRowContext(ctx,
+		`SELECT available FROM stock WHERE product_id = ? AND warehouse_id = ?`, id, warehouseID,
+	).Scan(&old)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("sqlite: update stock of %q: %w", id, err)
+	}
+	available := update(old)
+	if available < 0 {
+		return nil, domain.ErrInsufficientStock
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO stock (product_id, warehouse_id, available)
+		VALUES (?, ?, ?)
+		ON CONFLICT (product_id, warehouse_id) DO UPDATE SET available = excluded.available`,
+		id, warehouseID, available,
+	); err != nil {
+		return nil, fmt.Errorf("sqlite: update stock of %q: %w", id, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE products SET available = available + ?, updated_at = ? WHERE id = ?`,
+		available-old, sqlitetime.Format(time.Now()), id,
+	); err != nil {
+		return nil, fmt.Errorf("sqlite: update stock of %q: %w", id, err)
+	}
+
+	p, err := getProduct(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("sqlite: update stock of %q: %w", id, err)
+	}
+	return p, nil
+}
+
+// SetBackorderable sets whether a product may be backordered.
+func (r *Repository) SetBackorderable(ctx context.Context, id string, backorderable bool) (*domain.Product, error) {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE products SET backorderable = ?, updated_at = ? WHERE id = ?`,
+		backorderable, sqlitetime.Format(time.Now()), id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: set backorderable of %q: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("sqlite: set backorderable of %q: %w", id, err)
+	} else if n == 0 {
+		return nil, domain.ErrProductNotFound
+	}
+	return getProduct(ctx, r.db, id)
+}
+
+// Available returns the available units of the given known products.
+func (r *Repository) Available(ctx context.Context, ids []string) (map[string]int32, error) {
+	out := make(map[string]int32, len(ids))
+	for _, id := range ids {
+		var available int32
+		err := r.read.QueryRowContext(ctx, `SELECT available FROM products WHERE id = ?`, id).Scan(&available)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: stock of %q: %w", id, err)
+		}
+		out[id] = available
+	}
+	return out, nil
+}
+
+// Stock returns the per-warehouse stock of the given products.
+func (r *Repository) Stock(ctx context.Context, ids []string) ([]domain.WarehouseStock, error) {
+	var out []domain.WarehouseStock
+	for _, id := range slices.Sorted(slices.Values(ids)) {
+		if len(out) > 0 && out[len(out)-1].ProductID == id {
+			continue // listed twice
+		}
+		rows, err := r.read.QueryContext(ctx, `
+			SELECT product_id, warehouse_id, available, reserved
+			FROM   stock
+			WHERE  product_id = ?
+			ORDER  BY warehouse_id`, id)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: stock of %q: %w", id, err)
+		}
+		for rows.Next() {
+			var ws domain.WarehouseStock
+			if err := rows.Scan(&ws.ProductID, &ws.WarehouseID, &ws.Available, &ws.Reserved); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("sqlite: scan stock of %q: %w", id, err)
+			}
+			out = append(out, ws)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: iterate stock of %q: %w", id, err)
+		}
+	}
+	return out, nil
+}
+
+// Reserve stores the reservation and moves its allocations from available
+// to reserved in one transaction. Each UPDATE only applies if the warehouse
+// has enough units available, so concurrent reservations can never
+// oversell.
+func (r *Repository) Reserve(ctx context.Context, res *domain.Reserve) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: reserve for order %q: %w", res.OrderID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO reservations
+			(order_id, idempotency_key, request_id, created_at, expires_at, trace_id, span_id)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		res.OrderID, res.IdempotencyKey, res.RequestID, sqlitetime.Format(res.CreatedAt),
+		formatOptionalTime(res.ExpiresAt), res.TraceID, res.SpanID,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: reserve for order %q: %w", res.OrderID, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("sqlite: reserve for order %q: %w", res.OrderID, err)
+	} else if n == 0 {
+		return domain.ErrReservationExists
+	}
+
+	now := sqlitetime.Format(time.Now())
+	for _, a := range res.Allocations {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE stock
+			SET    available = available - ?, reserved = reserved + ?
+			WHERE  product_id = ? AND warehouse_id = ? AND available >= ?`,
+			a.Quantity, a.Quantity, a.ProductID, a.WarehouseID, a.Quantity,
+		)
+		if err != nil {
+			return fmt.Errorf("sqlite: reserve %q for order %q: %w", a.ProductID, res.OrderID, err)
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("sqlite: reserve %q for order %q: %w", a.ProductID, res.OrderID, err)
+		} else if n == 0 {
+			if _, err := getProduct(ctx, tx, a.ProductID); err != nil {
+				return err
+			}
+			return domain.ErrInsufficientStock
+		}
+		if err := moveProductStock(ctx, tx, a.ProductID, -a.Quantity, a.Quantity, now); err != nil {
+			return fmt.Errorf("sqlite: reserve %q for order %q: %w", a.ProductID, res.OrderID, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO reservation_allocations (order_id, product_id, warehouse_id, quantity)
+			VALUES (?, ?, ?, ?)`,
+			res.OrderID, a.ProductID, a.WarehouseID, a.Quantity,
+		); err != nil {
+			return fmt.Errorf("sqlite: allocate %q for order %q: %w", a.ProductID, res.OrderID, err)
+		}
+	}
+
+	for i, it := range res.Items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO reservation_items (order_id, position, product_id, quantity, reserved, backordered)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			res.OrderID, i, it.ProductID, it.Quantity, it.Reserved, it.Backordered,
+		); err != nil {
+			return fmt.Errorf("sqlite: reserve item %d for order %q: %w", i, res.OrderID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: reserve for order %q: %w", res.OrderID, err)
+	}
+	return nil
+}
+
+// GetReservation returns the reservation of an order.
+func (r *Repository) GetReservation(ctx context.Context, orderID string) (*domain.Reserve, error) {
+	return getReservation(ctx, r.read, "order_id = ?", orderID)
+}
+
+// GetReservationByKey returns the reservation made with the given key.
+func (r *Repository) GetReservationByKey(ctx context.Context, key string) (*domain.Reserve, error) {
+	if key == "" {
+		return nil, domain.ErrReservationNotFound
+	}
+	return getReservation(ctx, r.read, "idempotency_key = ?", key)
+}
+
+// Release returns the allocated units to the available stock of their
+// warehouses and deletes the reservation (its items and allocations
+// cascade) in one transaction.
+func (r *Repository) Release(ctx context.Context, orderID string) (*domain.Reserve, error) {
+	return r.release(ctx, orderID, func(*domain.Reserve) bool { return true })
+}
+
+// ReleaseExpired is Release guarded by a re-check of the expiry inside the
+// transaction, so a reservation committed meanwhile is left alone.
+func (r *Repository) ReleaseExpired(ctx context.Context, orderID string, now time.Time) (*domain.Reserve, error) {
+	return r.release(ctx, orderID, func(res *domain.Reserve) bool { return res.Expired(now) })
+}
+
+// release releases the reservation of orderID if ok accepts it, and
+// returns domain.ErrReservationNotFound otherwise.
+func (r *Repository) release(ctx context.Context, orderID string, ok func(*domain.Reserve) bool) (*domain.Reserve, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: release order %q: %w", orderID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := getReservation(ctx, tx, "order_id = ?", orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok(res) {
+		return nil, domain.ErrReservationNotFound
+	}
+
+	// Committed units already left reserved stock.
+	stillReserved := int32(1)
+	if res.Committed() {
+		stillReserved = 0
+	}
+	now := sqlitetime.Format(time.Now())
+	for _, a := range res.Allocations {
+		if err := moveStock(ctx, tx, a, a.Quantity, -a.Quantity*stillReserved, now); err != nil {
+			return nil, fmt.Errorf("sqlite: release %q of order %q: %w", a.ProductID, orderID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reservations WHERE order_id = ?`, orderID); err != nil {
+		return nil, fmt.Errorf("sqlite: release order %q: %w", orderID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("sqlite: release order %q: %w", orderID, err)
+	}
+	return res, nil
+}
+
+// Commit marks the reservation committed and takes its units out of
+// reserved stock in one transaction.
+func (r *Repository) Commit(ctx context.Context, orderID string, now time.Time) (*domain.Reserve, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: commit order %q: %w", orderID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := getReservation(ctx, tx, "order_id = ?", orderID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.Committed():
+		return res, nil
+	case res.Expired(now):
+		return nil, domain.ErrReservationExpired
+	}
+
+	at := sqlitetime.Format(now)
+	for _, a := range res.Allocations {
+		if err := moveStock(ctx, tx, a, 0, -a.Quantity, at); err != nil {
+			return nil, fmt.Errorf("sqlite: commit %q of order %q: %w", a.ProductID, orderID, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE reservations SET committed_at = ? WHERE order_id = ?`, at, orderID,
+	); err != nil {
+		return nil, fmt.Errorf("sqlite: commit order %q: %w", orderID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("sqlite: commit order %q: %w", orderID, err)
+	}
+	res.CommittedAt = now
+	return res, nil
+}
+
+// Expired returns uncommitted reservations whose expiry has passed, served
+// by idx_reservations_expires_at.
+func (r *Repository) Expired(ctx context.Context, now time.Time, limit int) ([]*domain.Reserve, error) {
+	rows, err := r.read.QueryContext(ctx, `
+		SELECT order_id
+		FROM   reservations
+		WHERE  committed_at = '' AND expires_at <> '' AND expires_at <= ?
+		ORDER  BY expires_at
+		LIMIT  ?`, sqlitetime.Format(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: expired reservations: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("sqlite: scan expired reservation: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: iterate expired reservations: %w", err)
+	}
+
+	// Loaded after the rows are closed, so that listing them holds one
+	// read connection at a time.
+	expired := make([]*domain.Reserve, 0, len(ids))
+	for _, id := range ids {
+		res, err := r.GetReservation(ctx, id)
+		if errors.Is(err, domain.ErrReservationNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		expired = append(expired, res)
+	}
+	return expired, nil
+}
+
+// moveStock adds to the available and reserved units of allocation a's
+// stock row and of its product's totals.
+func moveStock(ctx context.Context, tx *sql.Tx, a domain.Allocation, available, reserved int32, now string) error {
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stock
+		SET    available = available + ?, reserved = reserved + ?
+		WHERE  product_id = ? AND warehouse_id = ?`,
+		available, reserved, a.ProductID, a.WarehouseID,
+	); err != nil {
+		return err
+	}
+	return moveProductStock(ctx, tx, a.ProductID, available, reserved, now)
+}
+
+// moveProductStock adds to the available and reserved totals of a product.
+func moveProductStock(ctx context.Context, tx *sql.Tx, id string, available, reserved int32, now string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE products
+		SET    available = available + ?, reserved = reserved + ?, updated_at = ?
+		WHERE  id = ?`,
+		available, reserved, now, id,
+	)
+	return err
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// productColumns is the SELECT list shared by product reads; it matches
+// scanProduct.
+const productColumns = `id, name, available, reserved, backorderable, created_at, updated_at`
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanProduct reads a product row selected with productColumns.
+func scanProduct(row scanner) (*domain.Product, error) {
+	var p domain.Product
+	var createdAt, updatedAt string
+	if err := row.Scan(&p.ID, &p.Name, &p.Available, &p.Reserved, &p.Backorderable, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.CreatedAt, err = sqlitetime.Parse(createdAt); err != nil {
+		return nil, err
+	}
+	if p.UpdatedAt, err = sqlitetime.Parse(updatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// getProduct loads a product or returns domain.ErrProductNotFound.
+func getProduct(ctx context.Context, q querier, id string) (*domain.Product, error) {
+	p, err := scanProduct(q.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: get product %q: %w", id, err)
+	}
+	return p, nil
+}
+
+// getReservation loads a single reservation matching cond, then its items.
+func getReservation(ctx context.Context, q querier, cond string, arg any) (*domain.Reserve, error) {
+	var res domain.Reserve
+	var createdAt, expiresAt, committedAt string
+	err := q.QueryRowContext(ctx, `
+		SELECT order_id, idempotency_key, request_id, created_at, expires_at, committed_at, trace_id, span_id
+		FROM   reservations
+		WHERE  `+cond, arg,
+	).Scan(&res.OrderID, &res.IdempotencyKey, &res.RequestID, &createdAt, &expiresAt, &committedAt, &res.TraceID, &res.SpanID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: get reservation: %w", err)
+	}
+	if res.CreatedAt, err = sqlitetime.Parse(createdAt); err != nil {
+		return nil, err
+	}
+	if res.ExpiresAt, err = parseOptionalTime(expiresAt); err != nil {
+		return nil, err
+	}
+	if res.CommittedAt, err = parseOptionalTime(committedAt); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT product_id, quantity, reserved, backordered
+		FROM   reservation_items
+		WHERE  order_id = ?
+		ORDER  BY position`, res.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: items of reservation %q: %w", res.OrderID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var it domain.StockItem
+		if err := rows.Scan(&it.ProductID, &it.Quantity, &it.Reserved, &it.Backordered); err != nil {
+			return nil, fmt.Errorf("sqlite: scan item of reservation %q: %w", res.OrderID, err)
+		}
+		res.Items = append(res.Items, &it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate items of reservation %q: %w", res.OrderID, err)
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `
+		SELECT product_id, warehouse_id, quantity
+		FROM   reservation_allocations
+		WHERE  order_id = ?
+		ORDER  BY product_id, warehouse_id`, res.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: allocations of reservation %q: %w", res.OrderID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a domain.Allocation
+		if err := rows.Scan(&a.ProductID, &a.WarehouseID, &a.Quantity); err != nil {
+			return nil, fmt.Errorf("sqlite: scan allocation of reservation %q: %w", res.OrderID, err)
+		}
+		res.Allocations = append(res.Allocations, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate allocations of reservation %q: %w", res.OrderID, err)
+	}
+	return &res, nil
+}
+
+// applySchema runs the DDL once, then the migrations, the indexes and the
+// backfills.
+// Idempotent due to IF NOT EXISTS and to migrations only adding columns
+// that are missing.
+func applySchema(db *sql.DB) error {
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("sqlite: apply schema: %w", err)
+	}
+
+	for _, m := range migrations {
+		if err := migrate(db, m.table, m.column, m.ddl); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.Exec(indexes); err != nil {
+		return fmt.Errorf("sqlite: create indexes: %w", err)
+	}
+
+	if _, err := db.Exec(`
+		INSERT INTO warehouses (id, name, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		domain.DefaultWarehouseID, domain.DefaultWarehouseID, sqlitetime.Format(time.Now()),
+	); err != nil {
+		return fmt.Errorf("sqlite: create default warehouse: %w", err)
+	}
+	for _, b := range backfills {
+		if _, err := db.Exec(b, domain.DefaultWarehouseID); err != nil {
+			return fmt.Errorf("sqlite: backfill warehouses: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrate runs ddl in one transaction unless table already has column. A
+// migration that fails half-way leaves nothing behind and runs in full on
+// the next start.
+func migrate(db *sql.DB, table, column, ddl string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("sqlite: migrate %s.%s: %w", table, column, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	exists, err := hasColumn(tx, table, column)
+	if err != nil || exists {
+		return err
+	}
+	if _, err := tx.Exec(ddl); err != nil {
+		return fmt.Errorf("sqlite: migrate %s.%s: %w", table, column, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: migrate %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+// hasColumn reports whether table already has the given column.
+func hasColumn(db querier, table, column string) (bool, error) {
+	rows, err := db.QueryContext(context.Background(), `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: inspect %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, fmt.Errorf("sqlite: inspect %s: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
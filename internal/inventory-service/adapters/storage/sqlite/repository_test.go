@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+// legacySchema is the schema of the builds before reservations expired or
+// were committed.
+const legacySchema = `
+CREATE TABLE products (
+    id          TEXT PRIMARY KEY,
+    name        TEXT    NOT NULL DEFAULT '',
+    available   INTEGER NOT NULL CHECK (available >= 0),
+    reserved    INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    created_at  TEXT NOT NULL,
+    updated_at  TEXT NOT NULL
+);
+CREATE TABLE reservations (
+    order_id        TEXT PRIMARY KEY,
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    request_id      TEXT NOT NULL DEFAULT '',
+    created_at      TEXT NOT NULL
+);
+CREATE TABLE reservation_items (
+    order_id    TEXT    NOT NULL REFERENCES reservations(order_id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL,
+    product_id  TEXT    NOT NULL REFERENCES products(id),
+    quantity    INTEGER NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
+`
+
+// legacyDB writes a database of an old build holding one reservation of
+// 3 units of p1, whose reserved count is reserved.
+func legacyDB(t *testing.T, reserved int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "inventory.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, stmt := range []struct {
+		sql  string
+		args []any
+	}{
+		{legacySchema, nil},
+		{`INSERT INTO products (id, available, reserved, created_at, updated_at)
+		  VALUES ('p1', 7, ?, '2024-01-01T00:00:00.000000000Z', '2024-01-01T00:00:00.000000000Z')`, []any{reserved}},
+		{`INSERT INTO reservations (order_id, created_at) VALUES ('o1', '2024-01-02T00:00:00.000000000Z')`, nil},
+		{`INSERT INTO reservation_items (order_id, position, product_id, quantity) VALUES ('o1', 0, 'p1', 3)`, nil},
+	} {
+		if _, err := db.Exec(stmt.sql, stmt.args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestOpen_MigratesCommittedReservations(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved int
+		wantErr  bool
+	}{
+		{"reserved matches the reservations", 3, false},
+		// The fix-up would make reserved negative: the whole migration,
+		// the new column included, must be rolled back.
+		{"reserved lower than the reservations", 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := legacyDB(t, tt.reserved)
+
+			repo, err := Open(path)
+			if tt.wantErr {
+				if err == nil {
+					_ = repo.Close()
+					t.Fatal("Open succeeded, want the migration to fail")
+				}
+				db, err := sql.Open("sqlite", "file:"+path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer db.Close()
+				if exists, err := hasColumn(db, "reservations", "committed_at"); err != nil || exists {
+					t.Errorf("committed_at exists after the failed migration (%v)", err)
+				}
+				var reserved int
+				if err := db.QueryRow(`SELECT reserved FROM products WHERE id = 'p1'`).Scan(&reserved); err != nil {
+					t.Fatal(err)
+				}
+				if reserved != tt.reserved {
+					t.Errorf("reserved = %d after the failed migration, want %d", reserved, tt.reserved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Reopening must not run the fix-up again.
+			if err := repo.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if repo, err = Open(path); err != nil {
+				t.Fatal(err)
+			}
+			defer repo.Close()
+
+			p, err := repo.GetProduct(context.Background(), "p1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Available != 7 || p.Reserved != 0 {
+				t.Errorf("available %d, reserved %d; want 7, 0", p.Available, p.Reserved)
+			}
+			res, err := repo.GetReservation(context.Background(), "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !res.Committed() || len(res.Allocations) != 1 || res.Allocations[0].WarehouseID != domain.DefaultWarehouseID {
+				t.Errorf("reservation = %+v, want committed in the default warehouse", res)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/sqlitetime"
+)
+
+// formatOptionalTime is sqlitetime.Format, storing the zero time as an
+// empty string.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return sqlitetime.Format(t)
+}
+
+// parseOptionalTime is sqlitetime.Parse, reading an empty string as the
+// zero time.
+func parseOptionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return sqlitetime.Parse(s)
+}
@@ -0,0 +1,165 @@
+package inventoryservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	inventoryV1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/adapters/grpc/mappers"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+func (s *inventoryServer) CreateProduct(ctx context.Context, req *inventoryV1.CreateProductRequest) (*inventoryV1.CreateProductResponse, error) {
+	p, err := domain.NewProduct(req.GetProductId(), req.GetName(), req.GetInitialStock(), time.Now().UTC())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product: %v", err)
+	}
+	p.Backorderable = req.GetBackorderable()
+	if err := s.repo.CreateProduct(ctx, p); err != nil {
+		return nil, repoError(err, p.ID)
+	}
+
+	slog.InfoContext(ctx, "product created",
+		"product_id", p.ID,
+		"available", p.Available,
+		"backorderable", p.Backorderable,
+	)
+	return &inventoryV1.CreateProductResponse{Product: mappers.ProductToProto(p)}, nil
+}
+
+func (s *inventoryServer) GetStock(ctx context.Context, req *inventoryV1.GetStockRequest) (*inventoryV1.GetStockResponse, error) {
+	p, err := s.repo.GetProduct(ctx, req.GetProductId())
+	if err != nil {
+		return nil, repoError(err, req.GetProductId())
+	}
+	stock, err := s.repo.Stock(ctx, []string{p.ID})
+	if err != nil {
+		return nil, repoError(err, p.ID)
+	}
+	return &inventoryV1.GetStockResponse{
+		Product:    mappers.ProductToProto(p),
+		Warehouses: mappers.WarehouseStockToProto(stock),
+	}, nil
+}
+
+func (s *inventoryServer) SetStock(ctx context.Context, req *inventoryV1.SetStockRequest) (*inventoryV1.SetStockResponse, error) {
+	warehouseID := warehouseOrDefault(req.GetWarehouseId())
+	p, err := s.repo.SetStock(ctx, req.GetProductId(), warehouseID, req.GetAvailable())
+	if err != nil {
+		return nil, repoError(err, req.GetProductId())
+	}
+
+	slog.InfoContext(ctx, "stock set",
+		"product_id", p.ID,
+		"warehouse_id", warehouseID,
+		"warehouse_available", req.GetAvailable(),
+		"available", p.Available,
+	)
+	return &inventoryV1.SetStockResponse{Product: mappers.ProductToProto(p)}, nil
+}
+
+func (s *inventoryServer) SetBackorderable(ctx context.Context, req *inventoryV1.SetBackorderableRequest) (*inventoryV1.SetBackorderableResponse, error) {
+	p, err := s.repo.SetBackorderable(ctx, req.GetProductId(), req.GetBackorderable())
+	if err != nil {
+		return nil, repoError(err, req.GetProductId())
+	}
+
+	slog.InfoContext(ctx, "backorderable set", "product_id", p.ID, "backorderable", p.Backorderable)
+	return &inventoryV1.SetBackorderableResponse{Product: mappers.ProductToProto(p)}, nil
+}
+
+func (s *inventoryServer) AdjustStock(ctx context.Context, req *inventoryV1.AdjustStockRequest) (*inventoryV1.AdjustStockResponse, error) {
+	warehouseID := warehouseOrDefault(req.GetWarehouseId())
+	p, err := s.repo.AdjustStock(ctx, req.GetProductId(), warehouseID, req.GetDelta())
+	if err != nil {
+		return nil, repoError(err, req.GetProductId())
+	}
+
+	slog.InfoContext(ctx, "stock adjusted",
+		"product_id", p.ID,
+		"warehouse_id", warehouseID,
+		"delta", req.GetDelta(),
+		"available", p.Available,
+	)
+	return &inventoryV1.AdjustStockResponse{Product: mappers.ProductToProto(p)}, nil
+}
+
+func (s *inventoryServer) ListProducts(ctx context.Context, req *inventoryV1.ListProductsRequest) (*inventoryV1.ListProductsResponse, error) {
+	page, err := s.repo.ListProducts(ctx, domain.ProductFilter{
+		Limit: int(req.GetPageSize()),
+		After: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list products: %v", err)
+	}
+
+	products := make([]*inventoryV1.Product, len(page.Products))
+	for i, p := range page.Products {
+		products[i] = mappers.ProductToProto(p)
+	}
+	return &inventoryV1.ListProductsResponse{Products: products, NextPageToken: page.NextCursor}, nil
+}
+
+func (s *inventoryServer) CreateWarehouse(ctx context.Context, req *inventoryV1.CreateWarehouseRequest) (*inventoryV1.CreateWarehouseResponse, error) {
+	var loc domain.Location
+	if l := mappers.LocationFromProto(req.GetLocation()); l != nil {
+		loc = *l
+	}
+	w, err := domain.NewWarehouse(req.GetWarehouseId(), req.GetName(), loc, req.GetUnitCost(), time.Now().UTC())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid warehouse: %v", err)
+	}
+	if err := s.repo.CreateWarehouse(ctx, w); err != nil {
+		if errors.Is(err, domain.ErrWarehouseExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "warehouse %s already exists", w.ID)
+		}
+		return nil, status.Errorf(codes.Internal, "warehouse %s: %v", w.ID, err)
+	}
+
+	slog.InfoContext(ctx, "warehouse created", "warehouse_id", w.ID, "unit_cost", w.UnitCost)
+	return &inventoryV1.CreateWarehouseResponse{Warehouse: mappers.WarehouseToProto(w)}, nil
+}
+
+func (s *inventoryServer) ListWarehouses(ctx context.Context, _ *inventoryV1.ListWarehousesRequest) (*inventoryV1.ListWarehousesResponse, error) {
+	list, err := s.repo.ListWarehouses(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list warehouses: %v", err)
+	}
+
+	warehouses := make([]*inventoryV1.Warehouse, len(list))
+	for i, w := range list {
+		warehouses[i] = mappers.WarehouseToProto(w)
+	}
+	return &inventoryV1.ListWarehousesResponse{Warehouses: warehouses}, nil
+}
+
+// warehouseOrDefault returns id, or the default warehouse if it is empty.
+func warehouseOrDefault(id string) string {
+	if id == "" {
+		return domain.DefaultWarehouseID
+	}
+	return id
+}
+
+// repoError maps repository errors to gRPC status errors.
+func repoError(err error, productID string) error {
+	switch {
+	case errors.Is(err, domain.ErrProductNotFound):
+		return status.Errorf(codes.NotFound, "product %s not found", productID)
+	case errors.Is(err, domain.ErrWarehouseNotFound):
+		return status.Errorf(codes.NotFound, "product %s: %v", productID, err)
+	case errors.Is(err, domain.ErrProductExists):
+		return status.Errorf(codes.AlreadyExists, "product %s already exists", productID)
+	case errors.Is(err, domain.ErrNegativeStock):
+		return status.Errorf(codes.InvalidArgument, "product %s: %v", productID, err)
+	case errors.Is(err, domain.ErrInsufficientStock):
+		return status.Errorf(codes.FailedPrecondition, "product %s: %v", productID, err)
+	default:
+		return status.Errorf(codes.Internal, "product %s: %v", productID, err)
+	}
+}
@@ -2,10 +2,15 @@ package inventoryservice
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
-	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	inventoryV1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
 	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/adapters/grpc/mappers"
 	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
@@ -14,30 +19,51 @@ import (
 
 const idempotencyTTL = 60 * time.Second
 
+// reserveAttempts bounds how often Reserve re-checks stock that changed
+// between the check and the reservation.
+const reserveAttempts = 3
+
+// inventoryServer is the gRPC server implementation for the Inventory
+// service. Stock and reservations are persisted through a
+// domain.InventoryRepository.
 type inventoryServer struct {
 	inventoryV1.UnimplementedInventoryServer
-	stock        map[string]int32
-	mu           sync.Mutex
-	reservations map[string]*domain.Reserve
-	cache        cache.Cache
+	repo  domain.InventoryRepository
+	cache cache.Cache
+	// reservationTTL is how long a reservation holds stock before it must
+	// be committed. Zero means reservations never expire.
+	reservationTTL time.Duration
+	// strategy allocates reservations that do not ask for one.
+	strategy domain.AllocationStrategy
 }
 
 var _ inventoryV1.InventoryServer = (*inventoryServer)(nil)
 
-func NewClient(c cache.Cache) *inventoryServer {
+// NewClient creates a new inventory gRPC server backed by repo and a cache
+// for idempotency. Reservations expire after reservationTTL unless
+// committed (see Reaper) and are spread over warehouses by strategy unless
+// the request names another one.
+func NewClient(repo domain.InventoryRepository, c cache.Cache, reservationTTL time.Duration, strategy domain.AllocationStrategy) *inventoryServer {
 	return &inventoryServer{
-		stock: map[string]int32{
-			"prod_1": 15,
-			"prod_2": 10,
-			"prod_3": 0,
-		},
-		reservations: make(map[string]*domain.Reserve),
-		cache:        c,
+		repo:           repo,
+		cache:          c,
+		reservationTTL: reservationTTL,
+		strategy:       strategy,
 	}
 }
 
 func (s *inventoryServer) Reserve(ctx context.Context, req *inventoryV1.ReserveRequest) (*inventoryV1.ReserveResponse, error) {
 	newReserve := mappers.StockItemsFromProto(ctx, req)
+	newReserve.CreatedAt = time.Now().UTC()
+	if s.reservationTTL > 0 {
+		newReserve.ExpiresAt = newReserve.CreatedAt.Add(s.reservationTTL)
+	}
+	// Kept so that the reaper can log an automatic release under the trace
+	// of the request that made the reservation.
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		newReserve.TraceID = sc.TraceID().String()
+		newReserve.SpanID = sc.SpanID().String()
+	}
 
 	if newReserve.IdempotencyKey != "" {
 		cacheKey := s.cache.GenerateKey("reserve", newReserve.IdempotencyKey)
@@ -46,57 +72,128 @@ func (s *inventoryServer) Reserve(ctx context.Context, req *inventoryV1.ReserveR
 				"order_id", req.GetOrderId(),
 				"idempotency_key", newReserve.IdempotencyKey,
 			)
-			return &inventoryV1.ReserveResponse{Success: true}, nil
+			// The allocation is only in the store, and the reservation may
+			// have been released since.
+			return s.heldReservation(ctx, newReserve)
 		}
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	// Reservations are idempotent per order as well as per key.
+	if existing, err := s.existingReservation(ctx, newReserve); err == nil {
+		slog.InfoContext(ctx, "reserve: idempotent response from store", "order_id", req.GetOrderId())
+		return heldResponse(existing)
+	} else if !errors.Is(err, domain.ErrReservationNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to look up reservation: %v", err)
+	}
 
-	if newReserve.IdempotencyKey != "" {
-		for _, existing := range s.reservations {
-			if existing.IdempotencyKey == newReserve.IdempotencyKey {
-				slog.InfoContext(ctx, "reserve: idempotent response from memory",
-					"order_id", req.GetOrderId(),
-				)
-				return &inventoryV1.ReserveResponse{Success: true}, nil
-			}
+	strategy := s.strategy
+	if req.GetStrategy() != inventoryV1.AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED {
+		var ok bool
+		if strategy, ok = mappers.AllocationStrategyFromProto(req.GetStrategy()); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown allocation strategy %v", req.GetStrategy())
 		}
 	}
+	policy, ok := mappers.FulfillmentPolicyFromProto(req.GetFulfillmentPolicy())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown fulfillment policy %v", req.GetFulfillmentPolicy())
+	}
+	destination := mappers.LocationFromProto(req.GetDestination())
+	if destination != nil {
+		if err := destination.Validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid destination: %v", err)
+		}
+	}
+	warehouses, err := s.warehouses(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list warehouses: %v", err)
+	}
 
-	slog.InfoContext(ctx, "processing reservation", "order_id", req.GetOrderId())
+	slog.InfoContext(ctx, "processing reservation", "order_id", req.GetOrderId(), "fulfillment_policy", policy)
 
+	ids := make([]string, 0, len(newReserve.Items))
 	for _, item := range newReserve.Items {
-		currentStock, exists := s.stock[item.ProductID]
-		if !exists {
-			slog.WarnContext(ctx, "product not found", "product_id", item.ProductID)
-			return &inventoryV1.ReserveResponse{Success: false}, nil
+		ids = append(ids, item.ProductID)
+	}
+
+	// The check gives the caller every shortage at once; the store enforces
+	// it again atomically, so a reservation that slipped in between only
+	// costs another round.
+	for attempt := 1; ; attempt++ {
+		available, err := s.repo.Available(ctx, ids)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read stock: %v", err)
 		}
-		if currentStock < item.Quantity {
-			slog.WarnContext(ctx, "insufficient stock",
-				"product_id", item.ProductID,
-				"available", currentStock,
-				"requested", item.Quantity,
-			)
-			return &inventoryV1.ReserveResponse{Success: false}, nil
+		var backorderable map[string]bool
+		if policy == domain.FulfillAllowBackorder {
+			if backorderable, err = s.backorderable(ctx, newReserve, available); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to read products: %v", err)
+			}
+		}
+		if shortages := domain.PlanFulfillment(newReserve, policy, available, backorderable); len(shortages) > 0 {
+			for _, sh := range shortages {
+				slog.WarnContext(ctx, "insufficient stock",
+					"product_id", sh.ProductID,
+					"reason", sh.Reason,
+					"available", sh.Available,
+					"requested", sh.Requested,
+				)
+			}
+			return &inventoryV1.ReserveResponse{
+				Success:       false,
+				FailureReason: mappers.ReserveFailureToProto(shortages),
+				Shortages:     mappers.ShortagesToProto(shortages),
+			}, nil
+		}
+
+		newReserve.Allocations, err = s.allocate(ctx, strategy, domain.AllocationRequest{
+			Quantities:  newReserve.ReservedQuantities(),
+			Warehouses:  warehouses,
+			Destination: destination,
+		}, ids)
+		if err == nil {
+			err = s.repo.Reserve(ctx, newReserve)
+		}
+		if err == nil {
+			break
+		}
+		switch {
+		case errors.Is(err, domain.ErrReservationExists):
+			slog.InfoContext(ctx, "reserve: idempotent response from store", "order_id", req.GetOrderId())
+			return s.heldReservation(ctx, newReserve)
+		case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrProductNotFound):
+			if attempt < reserveAttempts {
+				continue
+			}
+			return nil, status.Errorf(codes.Aborted, "stock changed while reserving for order %s", req.GetOrderId())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to reserve stock: %v", err)
 		}
 	}
 
-	for _, item := range newReserve.Items {
-		s.stock[item.ProductID] -= item.Quantity
+	for _, a := range newReserve.Allocations {
 		slog.InfoContext(ctx, "stock reserved",
-			"product_id", item.ProductID,
-			"quantity", item.Quantity,
-			"remaining_stock", s.stock[item.ProductID],
+			"product_id", a.ProductID,
+			"warehouse_id", a.WarehouseID,
+			"quantity", a.Quantity,
+			"expires_at", newReserve.ExpiresAt,
 		)
 	}
-
-	s.reservations[newReserve.OrderID] = newReserve
+	for _, it := range newReserve.Items {
+		if it.Reserved < it.Quantity {
+			slog.InfoContext(ctx, "item not fully reserved",
+				"order_id", req.GetOrderId(),
+				"product_id", it.ProductID,
+				"requested", it.Quantity,
+				"reserved", it.Reserved,
+				"backordered", it.Backordered,
+			)
+		}
+	}
 
 	if newReserve.IdempotencyKey != "" {
 		cacheKey := s.cache.GenerateKey("reserve", newReserve.IdempotencyKey)
 		if err := s.cache.Set(ctx, cacheKey, "ok", idempotencyTTL); err != nil {
-			// Non-fatal: log and continue. The in-memory map is the fallback.
+			// Non-fatal: log and continue. The store is the fallback.
 			slog.WarnContext(ctx, "failed to persist idempotency key to cache",
 				"order_id", req.GetOrderId(),
 				"error", err,
@@ -104,7 +201,99 @@ func (s *inventoryServer) Reserve(ctx context.Context, req *inventoryV1.ReserveR
 		}
 	}
 
-	return &inventoryV1.ReserveResponse{Success: true}, nil
+	return reservedResponse(newReserve), nil
+}
+
+// reservedResponse reports a successful reservation with its allocation
+// and what became of each item.
+func reservedResponse(r *domain.Reserve) *inventoryV1.ReserveResponse {
+	return &inventoryV1.ReserveResponse{
+		Success:     true,
+		Allocations: mappers.AllocationsToProto(r.Allocations),
+		Items:       mappers.ItemFulfillmentsToProto(r.Items),
+	}
+}
+
+// heldReservation answers a retry with the reservation made by the first
+// attempt, which it looks up by order or key. A reservation released in
+// the meantime, by a rollback or by the reaper, holds no stock any more:
+// the retry fails with FailedPrecondition instead of reporting success
+// without an allocation.
+func (s *inventoryServer) heldReservation(ctx context.Context, r *domain.Reserve) (*inventoryV1.ReserveResponse, error) {
+	existing, err := s.existingReservation(ctx, r)
+	if errors.Is(err, domain.ErrReservationNotFound) {
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s has been released", r.OrderID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up reservation: %v", err)
+	}
+	return heldResponse(existing)
+}
+
+// heldResponse reports an existing reservation, unless it has expired and
+// is only waiting for the reaper to release it.
+func heldResponse(r *domain.Reserve) (*inventoryV1.ReserveResponse, error) {
+	if r.Expired(time.Now().UTC()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s expired at %s",
+			r.OrderID, r.ExpiresAt.Format(time.RFC3339))
+	}
+	return reservedResponse(r), nil
+}
+
+// backorderable looks up whether the products of r that available cannot
+// cover may be backordered. Products that are covered or unknown are left
+// out.
+func (s *inventoryServer) backorderable(ctx context.Context, r *domain.Reserve, available map[string]int32) (map[string]bool, error) {
+	out := make(map[string]bool)
+	for id, want := range r.Quantities() {
+		if have, known := available[id]; !known || have >= want {
+			continue
+		}
+		p, err := s.repo.GetProduct(ctx, id)
+		if errors.Is(err, domain.ErrProductNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[id] = p.Backorderable
+	}
+	return out, nil
+}
+
+// warehouses returns the known warehouses by ID.
+func (s *inventoryServer) warehouses(ctx context.Context) (map[string]*domain.Warehouse, error) {
+	list, err := s.repo.ListWarehouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Warehouse, len(list))
+	for _, w := range list {
+		byID[w.ID] = w
+	}
+	return byID, nil
+}
+
+// allocate reads the current stock of ids into req and runs strategy on
+// it. A strategy that cannot cover the request returns
+// domain.ErrInsufficientStock, like a reservation racing another one.
+func (s *inventoryServer) allocate(ctx context.Context, strategy domain.AllocationStrategy, req domain.AllocationRequest, ids []string) ([]domain.Allocation, error) {
+	stock, err := s.repo.Stock(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("read stock: %w", err)
+	}
+	req.Stock = stock
+	return strategy.Allocate(req)
+}
+
+// existingReservation returns the reservation already made for r's order
+// or idempotency key, or domain.ErrReservationNotFound.
+func (s *inventoryServer) existingReservation(ctx context.Context, r *domain.Reserve) (*domain.Reserve, error) {
+	existing, err := s.repo.GetReservation(ctx, r.OrderID)
+	if errors.Is(err, domain.ErrReservationNotFound) && r.IdempotencyKey != "" {
+		return s.repo.GetReservationByKey(ctx, r.IdempotencyKey)
+	}
+	return existing, err
 }
 
 func (s *inventoryServer) Release(ctx context.Context, req *inventoryV1.ReleaseRequest) (*inventoryV1.ReleaseResponse, error) {
@@ -114,29 +303,26 @@ func (s *inventoryServer) Release(ctx context.Context, req *inventoryV1.ReleaseR
 		return &inventoryV1.ReleaseResponse{Success: true}, nil
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	slog.InfoContext(ctx, "compensating reservation (release)", "order_id", req.GetOrderId())
 
-	reserve, exists := s.reservations[req.GetOrderId()]
-	if !exists {
+	reserve, err := s.repo.Release(ctx, req.GetOrderId())
+	if errors.Is(err, domain.ErrReservationNotFound) {
 		slog.WarnContext(ctx, "no reservation found to release", "order_id", req.GetOrderId())
 		// Treat as success to keep compensation idempotent.
 		return &inventoryV1.ReleaseResponse{Success: true}, nil
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to release reservation: %v", err)
+	}
 
-	for _, item := range reserve.Items {
-		s.stock[item.ProductID] += item.Quantity
+	for _, a := range reserve.Allocations {
 		slog.InfoContext(ctx, "stock restored",
-			"product_id", item.ProductID,
-			"quantity", item.Quantity,
-			"new_stock", s.stock[item.ProductID],
+			"product_id", a.ProductID,
+			"warehouse_id", a.WarehouseID,
+			"quantity", a.Quantity,
 		)
 	}
 
-	delete(s.reservations, req.GetOrderId())
-
 	// Mark this release as done in Redis to prevent double-release on retries.
 	if err := s.cache.Set(ctx, releaseCacheKey, "ok", idempotencyTTL); err != nil {
 		slog.WarnContext(ctx, "failed to persist release idempotency key to cache",
@@ -147,3 +333,21 @@ func (s *inventoryServer) Release(ctx context.Context, req *inventoryV1.ReleaseR
 
 	return &inventoryV1.ReleaseResponse{Success: true}, nil
 }
+
+func (s *inventoryServer) Commit(ctx context.Context, req *inventoryV1.CommitRequest) (*inventoryV1.CommitResponse, error) {
+	reserve, err := s.repo.Commit(ctx, req.GetOrderId(), time.Now().UTC())
+	switch {
+	case errors.Is(err, domain.ErrReservationNotFound):
+		return nil, status.Errorf(codes.NotFound, "no reservation found for order %s", req.GetOrderId())
+	case errors.Is(err, domain.ErrReservationExpired):
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s has expired", req.GetOrderId())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to commit reservation: %v", err)
+	}
+
+	slog.InfoContext(ctx, "reservation committed",
+		"order_id", req.GetOrderId(),
+		"committed_at", reserve.CommittedAt,
+	)
+	return &inventoryV1.CommitResponse{Success: true}, nil
+}
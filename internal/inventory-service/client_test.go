@@ -0,0 +1,241 @@
+package inventoryservice_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	inventoryV1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/inventory/v1"
+	inventoryservice "github.com/jcmexdev/ecommerce-sagas/internal/inventory-service"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/adapters/storage/memory"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/adapters/storage/sqlite"
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache/cachetest"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+)
+
+// stores opens an empty repository of each kind.
+var stores = []struct {
+	name string
+	open func(tb testing.TB) domain.InventoryRepository
+}{
+	{"memory", func(testing.TB) domain.InventoryRepository { return memory.New() }},
+	{"sqlite", func(tb testing.TB) domain.InventoryRepository {
+		repo, err := sqlite.Open(filepath.Join(tb.TempDir(), "inventory.db"))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tb.Cleanup(func() { _ = repo.Close() })
+		return repo
+	}},
+}
+
+// seed creates n products of stock units each, named p00, p01 and so on.
+func seed(tb testing.TB, repo domain.InventoryRepository, n int, stock int32) []string {
+	tb.Helper()
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("p%02d", i)
+		p, err := domain.NewProduct(ids[i], ids[i], stock, time.Now().UTC())
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := repo.CreateProduct(context.Background(), p); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return ids
+}
+
+func newServer(repo domain.InventoryRepository) inventoryV1.InventoryServer {
+	return inventoryservice.NewClient(repo, &cachetest.Map{}, time.Minute, domain.FewestSplitsStrategy{})
+}
+
+func reserveRequest(orderID string, quantity int32, ids ...string) *inventoryV1.ReserveRequest {
+	req := &inventoryV1.ReserveRequest{OrderId: orderID}
+	for _, id := range ids {
+		req.Items = append(req.Items, &inventoryV1.StockItem{ProductId: id, Quantity: quantity})
+	}
+	return req
+}
+
+func withIdempotencyKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(constants.HeaderXIdempotencyKey, key))
+}
+
+func TestReserve_ConcurrentOrdersDoNotOversell(t *testing.T) {
+	const stock = 20
+	for _, store := range stores {
+		t.Run(store.name, func(t *testing.T) {
+			repo := store.open(t)
+			ids := seed(t, repo, 1, stock)
+			srv := newServer(repo)
+
+			var wg sync.WaitGroup
+			var reserved atomic.Int32
+			for i := range 5 * stock {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					orderID := fmt.Sprintf("order-%d", i)
+					res, err := srv.Reserve(withIdempotencyKey(orderID), reserveRequest(orderID, 1, ids...))
+					if err != nil {
+						t.Errorf("reserve %s: %v", orderID, err)
+						return
+					}
+					if res.GetSuccess() {
+						reserved.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := reserved.Load(); got != stock {
+				t.Errorf("%d reservations succeeded, want %d", got, stock)
+			}
+			p, err := repo.GetProduct(context.Background(), ids[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Available != 0 || p.Reserved != stock {
+				t.Errorf("available %d, reserved %d; want 0, %d", p.Available, p.Reserved, stock)
+			}
+		})
+	}
+}
+
+func TestReserve_ConcurrentRetriesReserveOnce(t *testing.T) {
+	for _, store := range stores {
+		t.Run(store.name, func(t *testing.T) {
+			repo := store.open(t)
+			ids := seed(t, repo, 2, 10)
+			srv := newServer(repo)
+
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					res, err := srv.Reserve(withIdempotencyKey("key-1"), reserveRequest("order-1", 3, ids...))
+					if err != nil {
+						t.Errorf("reserve: %v", err)
+						return
+					}
+					if !res.GetSuccess() || len(res.GetAllocations()) != len(ids) {
+						t.Errorf("retry got %v, want the reservation", res)
+					}
+				}()
+			}
+			wg.Wait()
+
+			for _, id := range ids {
+				p, err := repo.GetProduct(context.Background(), id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if p.Available != 7 || p.Reserved != 3 {
+					t.Errorf("%s: available %d, reserved %d; want 7, 3", id, p.Available, p.Reserved)
+				}
+			}
+		})
+	}
+}
+
+// expiredRepo stores every reservation as already expired, as if the
+// retry came after its TTL.
+type expiredRepo struct {
+	domain.InventoryRepository
+}
+
+func (r expiredRepo) Reserve(ctx context.Context, res *domain.Reserve) error {
+	res.ExpiresAt = res.CreatedAt.Add(-time.Second)
+	return r.InventoryRepository.Reserve(ctx, res)
+}
+
+func TestReserve_RetryOfAReservationNoLongerHeld(t *testing.T) {
+	release := func(t *testing.T, srv inventoryV1.InventoryServer) {
+		if _, err := srv.Release(context.Background(), &inventoryV1.ReleaseRequest{OrderId: "order-1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name    string
+		expired bool
+		ctx     context.Context
+		// lose, if set, makes the first reservation stop holding stock.
+		lose func(t *testing.T, srv inventoryV1.InventoryServer)
+	}{
+		{"released, retried with the key", false, withIdempotencyKey("key-1"), release},
+		{"expired, retried with the key", true, withIdempotencyKey("key-1"), nil},
+		{"expired, retried without a key", true, context.Background(), nil},
+	}
+	for _, store := range stores {
+		for _, tt := range tests {
+			t.Run(store.name+"/"+tt.name, func(t *testing.T) {
+				repo := store.open(t)
+				ids := seed(t, repo, 1, 10)
+				if tt.expired {
+					repo = expiredRepo{repo}
+				}
+				srv := newServer(repo)
+
+				if res, err := srv.Reserve(tt.ctx, reserveRequest("order-1", 3, ids...)); err != nil || !res.GetSuccess() {
+					t.Fatalf("reserve: %v %v", res, err)
+				}
+				if tt.lose != nil {
+					tt.lose(t, srv)
+				}
+
+				res, err := srv.Reserve(tt.ctx, reserveRequest("order-1", 3, ids...))
+				if status.Code(err) != codes.FailedPrecondition {
+					t.Fatalf("retry got %v, %v; want FailedPrecondition", res, err)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkReserve reserves and releases one unit through the gRPC server,
+// as the saga does, from parallel goroutines spread over a growing number
+// of products. The sqlite store is the one the service runs with.
+func BenchmarkReserve(b *testing.B) {
+	// Every reservation logs; keep the results readable.
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+
+	for _, store := range stores {
+		for _, n := range []int{1, 4, 16, 64} {
+			b.Run(fmt.Sprintf("store=%s/products=%d", store.name, n), func(b *testing.B) {
+				repo := store.open(b)
+				ids := seed(b, repo, n, 1<<30)
+				srv := newServer(repo)
+				var next atomic.Int64
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						i := next.Add(1)
+						orderID := fmt.Sprintf("order-%d", i)
+						res, err := srv.Reserve(withIdempotencyKey(orderID), reserveRequest(orderID, 1, ids[int(i)%n]))
+						if err != nil || !res.GetSuccess() {
+							b.Errorf("reserve %s: %v %v", orderID, res, err)
+							return
+						}
+						if _, err := srv.Release(context.Background(), &inventoryV1.ReleaseRequest{OrderId: orderID}); err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				})
+			})
+		}
+	}
+}
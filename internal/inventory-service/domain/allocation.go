@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Strategy names, as accepted by StrategyByName.
+const (
+	StrategyClosest      = "closest"
+	StrategyCheapest     = "cheapest"
+	StrategyFewestSplits = "fewest_splits"
+)
+
+// Allocation is the part of a reservation taken from one warehouse. A
+// reservation has at most one allocation per product and warehouse.
+type Allocation struct {
+	ProductID   string
+	WarehouseID string
+	Quantity    int32
+}
+
+// AllocationRequest is the input of an AllocationStrategy.
+type AllocationRequest struct {
+	// Quantities are the units to allocate per product.
+	Quantities map[string]int32
+
+	// Stock lists the stock of the requested products per warehouse.
+	Stock []WarehouseStock
+
+	// Warehouses are the known warehouses by ID.
+	Warehouses map[string]*Warehouse
+
+	// Destination is where the order ships to; nil if unknown.
+	Destination *Location
+}
+
+// AllocationStrategy decides which warehouses a reservation takes its units
+// from. It returns ErrInsufficientStock if the stock cannot cover the
+// request; allocations are ordered by product, then warehouse.
+type AllocationStrategy interface {
+	Allocate(req AllocationRequest) ([]Allocation, error)
+}
+
+// StrategyByName returns the built-in strategy with the given name.
+func StrategyByName(name string) (AllocationStrategy, error) {
+	switch name {
+	case StrategyClosest:
+		return ClosestStrategy{}, nil
+	case StrategyCheapest:
+		return CheapestStrategy{}, nil
+	case StrategyFewestSplits:
+		return FewestSplitsStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown allocation strategy %q", name)
+	}
+}
+
+// ClosestStrategy takes each product from the warehouses nearest to the
+// destination first. Without a destination every warehouse is equally far.
+type ClosestStrategy struct{}
+
+func (ClosestStrategy) Allocate(req AllocationRequest) ([]Allocation, error) {
+	return allocateRanked(req, func(a, b *Warehouse) int {
+		return cmp.Compare(distance(a, req.Destination), distance(b, req.Destination))
+	})
+}
+
+// CheapestStrategy takes each product from the warehouses with the lowest
+// unit cost first, the closest one breaking ties.
+type CheapestStrategy struct{}
+
+func (CheapestStrategy) Allocate(req AllocationRequest) ([]Allocation, error) {
+	return allocateRanked(req, func(a, b *Warehouse) int {
+		if c := cmp.Compare(a.UnitCost, b.UnitCost); c != 0 {
+			return c
+		}
+		return cmp.Compare(distance(a, req.Destination), distance(b, req.Destination))
+	})
+}
+
+// FewestSplitsStrategy ships the order from as few warehouses as possible:
+// it keeps picking the warehouse that covers most of what is left, so an
+// order one warehouse can fill entirely is never split.
+type FewestSplitsStrategy struct{}
+
+func (FewestSplitsStrategy) Allocate(req AllocationRequest) ([]Allocation, error) {
+	remaining := make(map[string]int32, len(req.Quantities))
+	for id, qty := range req.Quantities {
+		if qty > 0 {
+			remaining[id] = qty
+		}
+	}
+	byWarehouse := make(map[string]map[string]int32)
+	for _, s := range req.Stock {
+		if _, known := req.Warehouses[s.WarehouseID]; !known || s.Available <= 0 {
+			continue
+		}
+		if byWarehouse[s.WarehouseID] == nil {
+			byWarehouse[s.WarehouseID] = make(map[string]int32)
+		}
+		byWarehouse[s.WarehouseID][s.ProductID] = s.Available
+	}
+	candidates := sortedKeys(byWarehouse)
+
+	var out []Allocation
+	for len(remaining) > 0 {
+		best, bestUnits := -1, int32(0)
+		for i, wh := range candidates {
+			var units int32
+			for id, qty := range remaining {
+				units += min(qty, byWarehouse[wh][id])
+			}
+			if units > bestUnits {
+				best, bestUnits = i, units
+			}
+		}
+		if best < 0 {
+			return nil, ErrInsufficientStock
+		}
+
+		wh := candidates[best]
+		candidates = slices.Delete(candidates, best, best+1)
+		for _, id := range sortedKeys(remaining) {
+			take := min(remaining[id], byWarehouse[wh][id])
+			if take == 0 {
+				continue
+			}
+			out = append(out, Allocation{ProductID: id, WarehouseID: wh, Quantity: take})
+			if remaining[id] -= take; remaining[id] == 0 {
+				delete(remaining, id)
+			}
+		}
+	}
+	sortAllocations(out)
+	return out, nil
+}
+
+// allocateRanked fills each product from its warehouses in the order given
+// by rank, best first. Ties go to the lower warehouse ID.
+func allocateRanked(req AllocationRequest, rank func(a, b *Warehouse) int) ([]Allocation, error) {
+	stock := make(map[string][]WarehouseStock)
+	for _, s := range req.Stock {
+		if _, known := req.Warehouses[s.WarehouseID]; known && s.Available > 0 {
+			stock[s.ProductID] = append(stock[s.ProductID], s)
+		}
+	}
+
+	var out []Allocation
+	for _, id := range sortedKeys(req.Quantities) {
+		want := req.Quantities[id]
+		candidates := stock[id]
+		slices.SortFunc(candidates, func(a, b WarehouseStock) int {
+			if c := rank(req.Warehouses[a.WarehouseID], req.Warehouses[b.WarehouseID]); c != 0 {
+				return c
+			}
+			return strings.Compare(a.WarehouseID, b.WarehouseID)
+		})
+		for _, s := range candidates {
+			if want == 0 {
+				break
+			}
+			take := min(want, s.Available)
+			out = append(out, Allocation{ProductID: id, WarehouseID: s.WarehouseID, Quantity: take})
+			want -= take
+		}
+		if want > 0 {
+			return nil, ErrInsufficientStock
+		}
+	}
+	sortAllocations(out)
+	return out, nil
+}
+
+// distance is how far w is from dest, or 0 for every warehouse if the
+// destination is unknown.
+func distance(w *Warehouse, dest *Location) float64 {
+	if dest == nil {
+		return 0
+	}
+	return w.Location.DistanceKm(*dest)
+}
+
+func sortAllocations(a []Allocation) {
+	slices.SortFunc(a, func(x, y Allocation) int {
+		if c := strings.Compare(x.ProductID, y.ProductID); c != 0 {
+			return c
+		}
+		return strings.Compare(x.WarehouseID, y.WarehouseID)
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+var (
+	lisbon = &Location{Latitude: 38.72, Longitude: -9.14}
+
+	warehouses = map[string]*Warehouse{
+		"madrid": {ID: "madrid", Location: Location{Latitude: 40.42, Longitude: -3.70}, UnitCost: 300},
+		"paris":  {ID: "paris", Location: Location{Latitude: 48.86, Longitude: 2.35}, UnitCost: 200},
+		"berlin": {ID: "berlin", Location: Location{Latitude: 52.52, Longitude: 13.40}, UnitCost: 200},
+	}
+
+	stock = []WarehouseStock{
+		{ProductID: "p1", WarehouseID: "madrid", Available: 2},
+		{ProductID: "p1", WarehouseID: "paris", Available: 5},
+		{ProductID: "p1", WarehouseID: "berlin", Available: 5},
+		// Stock in a warehouse that is not known is never allocated.
+		{ProductID: "p1", WarehouseID: "closed", Available: 100},
+		{ProductID: "p2", WarehouseID: "madrid", Available: 1},
+		{ProductID: "p2", WarehouseID: "berlin", Available: 3},
+		{ProductID: "p2", WarehouseID: "paris", Available: 0},
+	}
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    string
+		quantities  map[string]int32
+		destination *Location
+		want        []Allocation
+		wantErr     error
+	}{
+		{
+			name: "closest fills from the nearest warehouse first", strategy: StrategyClosest,
+			quantities: map[string]int32{"p1": 3}, destination: lisbon,
+			want: []Allocation{{"p1", "madrid", 2}, {"p1", "paris", 1}},
+		},
+		{
+			name: "closest without destination goes by warehouse ID", strategy: StrategyClosest,
+			quantities: map[string]int32{"p1": 3},
+			want:       []Allocation{{"p1", "berlin", 3}},
+		},
+		{
+			name: "cheapest breaks cost ties by distance", strategy: StrategyCheapest,
+			quantities: map[string]int32{"p1": 3, "p2": 2}, destination: lisbon,
+			want: []Allocation{{"p1", "paris", 3}, {"p2", "berlin", 2}},
+		},
+		{
+			name: "cheapest falls back to dearer warehouses", strategy: StrategyCheapest,
+			quantities: map[string]int32{"p1": 11}, destination: lisbon,
+			want: []Allocation{{"p1", "berlin", 5}, {"p1", "madrid", 1}, {"p1", "paris", 5}},
+		},
+		{
+			name: "fewest splits keeps an order in one warehouse", strategy: StrategyFewestSplits,
+			quantities: map[string]int32{"p1": 3, "p2": 1}, destination: lisbon,
+			want: []Allocation{{"p1", "berlin", 3}, {"p2", "berlin", 1}},
+		},
+		{
+			name: "fewest splits takes the rest from the next best", strategy: StrategyFewestSplits,
+			quantities: map[string]int32{"p1": 6, "p2": 3},
+			want:       []Allocation{{"p1", "berlin", 5}, {"p1", "madrid", 1}, {"p2", "berlin", 3}},
+		},
+		{
+			name: "zero quantities are skipped", strategy: StrategyFewestSplits,
+			quantities: map[string]int32{"p1": 1, "p2": 0},
+			want:       []Allocation{{"p1", "berlin", 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := StrategyByName(tt.strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := strategy.Allocate(AllocationRequest{
+				Quantities:  tt.quantities,
+				Stock:       slices.Clone(stock),
+				Warehouses:  warehouses,
+				Destination: tt.destination,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocate_InsufficientStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		quantities map[string]int32
+	}{
+		{"more than every warehouse holds", map[string]int32{"p2": 5}},
+		{"stock of unknown warehouses does not count", map[string]int32{"p1": 13}},
+		{"product without stock", map[string]int32{"p3": 1}},
+	}
+	for _, name := range []string{StrategyClosest, StrategyCheapest, StrategyFewestSplits} {
+		strategy, err := StrategyByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				got, err := strategy.Allocate(AllocationRequest{
+					Quantities:  tt.quantities,
+					Stock:       slices.Clone(stock),
+					Warehouses:  warehouses,
+					Destination: lisbon,
+				})
+				if !errors.Is(err, ErrInsufficientStock) || got != nil {
+					t.Errorf("got %v, %v; want ErrInsufficientStock", got, err)
+				}
+			})
+		}
+	}
+}
+
+func TestStrategyByName_Unknown(t *testing.T) {
+	if _, err := StrategyByName("random"); err == nil {
+		t.Error("unknown strategy accepted")
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import "slices"
+
+// FulfillmentPolicy says what Reserve does with items it cannot cover from
+// stock.
+type FulfillmentPolicy string
+
+const (
+	// FulfillAllOrNothing reserves nothing unless every item is in stock.
+	FulfillAllOrNothing FulfillmentPolicy = "all_or_nothing"
+
+	// FulfillPartial reserves what is in stock and drops the rest. It only
+	// fails if nothing at all can be reserved.
+	FulfillPartial FulfillmentPolicy = "partial"
+
+	// FulfillAllowBackorder reserves what is in stock and backorders the
+	// rest of backorderable products. A short product that cannot be
+	// backordered fails the reservation as with FulfillAllOrNothing.
+	FulfillAllowBackorder FulfillmentPolicy = "allow_backorder"
+)
+
+// PlanFulfillment decides, under policy, how many units of each item of r
+// are reserved and backordered, given available, the units available per
+// product, and backorderable, the products that may be backordered.
+// Products missing from available are unknown and fail the reservation
+// under every policy.
+//
+// It sets Reserved and Backordered on the items of r and returns the
+// shortages that prevent the reservation, in the order the products first
+// appear in r; when it returns any, r must not be reserved. An item listed
+// twice is served in order: the first one is filled first.
+func PlanFulfillment(r *Reserve, policy FulfillmentPolicy, available map[string]int32, backorderable map[string]bool) []Shortage {
+	quantities := r.Quantities()
+	reserved := make(map[string]int32, len(quantities))
+	backordered := make(map[string]int32, len(quantities))
+
+	var out []Shortage
+	var anyReserved bool
+	for _, it := range r.Items {
+		id := it.ProductID
+		if _, planned := reserved[id]; planned || slices.ContainsFunc(out, func(s Shortage) bool { return s.ProductID == id }) {
+			continue
+		}
+		want := quantities[id]
+		have, known := available[id]
+		short := Shortage{ProductID: id, Reason: ShortageOutOfStock, Requested: want, Available: have}
+		switch {
+		case !known:
+			short.Reason = ShortageUnknownProduct
+			out = append(out, short)
+			continue
+		case have >= want:
+			reserved[id] = want
+		case policy == FulfillPartial:
+			reserved[id] = max(have, 0)
+		case policy == FulfillAllowBackorder && backorderable[id]:
+			reserved[id] = max(have, 0)
+			backordered[id] = want - reserved[id]
+		default:
+			out = append(out, short)
+			continue
+		}
+		if reserved[id] > 0 {
+			anyReserved = true
+		}
+	}
+
+	// A partial reservation of nothing is no reservation: report every
+	// product that came up short.
+	if policy == FulfillPartial && len(out) == 0 && !anyReserved {
+		for _, it := range r.Items {
+			id := it.ProductID
+			if have := available[id]; have < quantities[id] && !slices.ContainsFunc(out, func(s Shortage) bool { return s.ProductID == id }) {
+				out = append(out, Shortage{ProductID: id, Reason: ShortageOutOfStock, Requested: quantities[id], Available: have})
+			}
+		}
+	}
+
+	for _, it := range r.Items {
+		it.Reserved = min(it.Quantity, reserved[it.ProductID])
+		reserved[it.ProductID] -= it.Reserved
+		it.Backordered = min(it.Quantity-it.Reserved, backordered[it.ProductID])
+		backordered[it.ProductID] -= it.Backordered
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlanFulfillment(t *testing.T) {
+	available := map[string]int32{"a": 5, "b": 2, "c": 0}
+	backorderable := map[string]bool{"b": true}
+
+	tests := []struct {
+		name   string
+		policy FulfillmentPolicy
+		// items are the requested lines; want are the same lines with the
+		// Reserved and Backordered units expected when nothing is short.
+		items         []StockItem
+		want          []StockItem
+		wantShortages []Shortage
+	}{
+		{
+			name:   "everything in stock",
+			policy: FulfillAllOrNothing,
+			items:  []StockItem{{ProductID: "a", Quantity: 3}, {ProductID: "b", Quantity: 2}},
+			want:   []StockItem{{"a", 3, 3, 0}, {"b", 2, 2, 0}},
+		},
+		{
+			name:          "all or nothing fails on a short item",
+			policy:        FulfillAllOrNothing,
+			items:         []StockItem{{ProductID: "a", Quantity: 3}, {ProductID: "b", Quantity: 4}},
+			wantShortages: []Shortage{{"b", ShortageOutOfStock, 4, 2}},
+		},
+		{
+			name:   "partial drops what is missing",
+			policy: FulfillPartial,
+			items:  []StockItem{{ProductID: "a", Quantity: 9}, {ProductID: "b", Quantity: 4}, {ProductID: "c", Quantity: 1}},
+			want:   []StockItem{{"a", 9, 5, 0}, {"b", 4, 2, 0}, {"c", 1, 0, 0}},
+		},
+		{
+			name:          "partial of nothing fails on every short product",
+			policy:        FulfillPartial,
+			items:         []StockItem{{ProductID: "c", Quantity: 2}, {ProductID: "c", Quantity: 1}},
+			wantShortages: []Shortage{{"c", ShortageOutOfStock, 3, 0}},
+		},
+		{
+			name:   "backorder the rest of backorderable products",
+			policy: FulfillAllowBackorder,
+			items:  []StockItem{{ProductID: "a", Quantity: 3}, {ProductID: "b", Quantity: 4}},
+			want:   []StockItem{{"a", 3, 3, 0}, {"b", 4, 2, 2}},
+		},
+		{
+			name:          "backorder fails on a product that cannot be backordered",
+			policy:        FulfillAllowBackorder,
+			items:         []StockItem{{ProductID: "b", Quantity: 4}, {ProductID: "c", Quantity: 1}},
+			wantShortages: []Shortage{{"c", ShortageOutOfStock, 1, 0}},
+		},
+		{
+			name:   "unknown products fail every policy, in item order",
+			policy: FulfillPartial,
+			items:  []StockItem{{ProductID: "x", Quantity: 1}, {ProductID: "a", Quantity: 1}, {ProductID: "y", Quantity: 2}},
+			wantShortages: []Shortage{
+				{"x", ShortageUnknownProduct, 1, 0},
+				{"y", ShortageUnknownProduct, 2, 0},
+			},
+		},
+		{
+			name:   "a product listed twice fills the first line first",
+			policy: FulfillPartial,
+			items:  []StockItem{{ProductID: "a", Quantity: 3}, {ProductID: "a", Quantity: 4}},
+			want:   []StockItem{{"a", 3, 3, 0}, {"a", 4, 2, 0}},
+		},
+		{
+			name:   "a product listed twice is backordered on the later line",
+			policy: FulfillAllowBackorder,
+			items:  []StockItem{{ProductID: "b", Quantity: 1}, {ProductID: "b", Quantity: 3}},
+			want:   []StockItem{{"b", 1, 1, 0}, {"b", 3, 1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reserve{OrderID: "o1"}
+			for _, it := range tt.items {
+				r.Items = append(r.Items, &it)
+			}
+
+			shortages := PlanFulfillment(r, tt.policy, available, backorderable)
+			if !slices.Equal(shortages, tt.wantShortages) {
+				t.Fatalf("shortages %+v, want %+v", shortages, tt.wantShortages)
+			}
+			for i, want := range tt.want {
+				if got := *r.Items[i]; got != want {
+					t.Errorf("item %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
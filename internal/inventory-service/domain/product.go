@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultPageSize is used when ProductFilter.Limit is zero.
+	DefaultPageSize = 20
+
+	// MaxPageSize caps ProductFilter.Limit.
+	MaxPageSize = 100
+)
+
+// ErrNegativeStock is returned for a stock level below zero.
+var ErrNegativeStock = errors.New("stock cannot be negative")
+
+// Product is a catalogue entry. Available units can be reserved; Reserved
+// units are held by open reservations and return to Available on release.
+// A Backorderable product may be ordered beyond its stock under
+// FulfillAllowBackorder.
+type Product struct {
+	ID            string
+	Name          string
+	Available     int32
+	Reserved      int32
+	Backorderable bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewProduct validates and builds a product with initial available units.
+func NewProduct(id, name string, stock int32, now time.Time) (*Product, error) {
+	if id == "" {
+		return nil, errors.New("product_id is required")
+	}
+	if stock < 0 {
+		return nil, ErrNegativeStock
+	}
+	return &Product{
+		ID:        id,
+		Name:      name,
+		Available: stock,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ProductFilter pages through the catalogue for InventoryRepository.List.
+type ProductFilter struct {
+	// Limit is the page size; see PageLimit.
+	Limit int
+
+	// After continues a previous call; pass ProductPage.NextCursor.
+	After string
+}
+
+// PageLimit returns the effective page size for f.
+func (f ProductFilter) PageLimit() int {
+	switch {
+	case f.Limit <= 0:
+		return DefaultPageSize
+	case f.Limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return f.Limit
+	}
+}
+
+// ProductPage is one page of products, ordered by ID.
+type ProductPage struct {
+	Products []*Product
+
+	// NextCursor is empty on the last page.
+	NextCursor string
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrProductNotFound is returned when no product matches the ID.
+	ErrProductNotFound = errors.New("product not found")
+
+	// ErrProductExists is returned by CreateProduct for a taken ID.
+	ErrProductExists = errors.New("product already exists")
+
+	// ErrInsufficientStock is returned when an operation would take a
+	// product's available units below zero. Nothing is changed.
+	ErrInsufficientStock = errors.New("insufficient stock")
+
+	// ErrWarehouseNotFound is returned when no warehouse matches the ID.
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+
+	// ErrWarehouseExists is returned by CreateWarehouse for a taken ID.
+	ErrWarehouseExists = errors.New("warehouse already exists")
+
+	// ErrReservationNotFound is returned when an order has no reservation.
+	ErrReservationNotFound = errors.New("reservation not found")
+
+	// ErrReservationExists is returned by Reserve when the order, or the
+	// idempotency key, already has a reservation.
+	ErrReservationExists = errors.New("reservation already exists")
+
+	// ErrReservationExpired is returned by Commit for a reservation that
+	// expired before it was committed.
+	ErrReservationExpired = errors.New("reservation expired")
+)
+
+// InventoryRepository is the port for persisting the catalogue, stock
+// levels and reservations. The gRPC server depends on this abstraction so
+// storage can be SQLite in production and in-memory in tests.
+type InventoryRepository interface {
+	// CreateWarehouse stores a new warehouse or returns ErrWarehouseExists.
+	CreateWarehouse(ctx context.Context, w *Warehouse) error
+
+	// ListWarehouses returns every warehouse ordered by ID, the default one
+	// included.
+	ListWarehouses(ctx context.Context) ([]*Warehouse, error)
+
+	// CreateProduct stores a new product or returns ErrProductExists. Its
+	// available units are put in the default warehouse.
+	CreateProduct(ctx context.Context, p *Product) error
+
+	// GetProduct returns the product with the given ID or
+	// ErrProductNotFound.
+	GetProduct(ctx context.Context, id string) (*Product, error)
+
+	// ListProducts returns one page of products ordered by ID.
+	ListProducts(ctx context.Context, filter ProductFilter) (*ProductPage, error)
+
+	// SetStock overwrites the available units of a product in a warehouse
+	// and returns the product with its new totals. It returns
+	// ErrProductNotFound or ErrWarehouseNotFound for unknown IDs.
+	SetStock(ctx context.Context, id, warehouseID string, available int32) (*Product, error)
+
+	// AdjustStock adds delta to the available units of a product in a
+	// warehouse, or returns ErrInsufficientStock if that would go below
+	// zero.
+	AdjustStock(ctx context.Context, id, warehouseID string, delta int32) (*Product, error)
+
+	// SetBackorderable sets whether a product may be backordered and
+	// returns the product, or ErrProductNotFound.
+	SetBackorderable(ctx context.Context, id string, backorderable bool) (*Product, error)
+
+	// Available returns the available units of the given products over all
+	// warehouses. Unknown products are left out.
+	Available(ctx context.Context, ids []string) (map[string]int32, error)
+
+	// Stock returns the stock of the given products per warehouse, ordered
+	// by product and warehouse. Unknown products are left out.
+	Stock(ctx context.Context, ids []string) ([]WarehouseStock, error)
+
+	// Reserve moves the allocations of r from available to reserved in
+	// their warehouses and stores r, atomically. If any product is unknown
+	// or a warehouse is short it returns ErrProductNotFound or
+	// ErrInsufficientStock and changes nothing.
+	Reserve(ctx context.Context, r *Reserve) error
+
+	// GetReservation returns the reservation of an order or
+	// ErrReservationNotFound.
+	GetReservation(ctx context.Context, orderID string) (*Reserve, error)
+
+	// GetReservationByKey returns the reservation made with an idempotency
+	// key or ErrReservationNotFound.
+	GetReservationByKey(ctx context.Context, key string) (*Reserve, error)
+
+	// Release returns the allocated units of an order to the available
+	// stock of their warehouses and deletes its reservation, atomically,
+	// whether it was committed or not. It returns the released reservation
+	// or ErrReservationNotFound.
+	Release(ctx context.Context, orderID string) (*Reserve, error)
+
+	// Commit makes the reservation of an order permanent: its units leave
+	// reserved stock and it no longer expires. Committing twice is a no-op.
+	// It returns ErrReservationNotFound, or ErrReservationExpired if the
+	// reservation expired at now.
+	Commit(ctx context.Context, orderID string, now time.Time) (*Reserve, error)
+
+	// Expired returns up to limit reservations that are expired at now,
+	// oldest expiry first.
+	Expired(ctx context.Context, now time.Time, limit int) ([]*Reserve, error)
+
+	// ReleaseExpired is Release for a reservation that is still expired at
+	// now. If it was committed or released meanwhile it returns
+	// ErrReservationNotFound and changes nothing.
+	ReleaseExpired(ctx context.Context, orderID string, now time.Time) (*Reserve, error)
+}
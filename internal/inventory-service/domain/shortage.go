@@ -0,0 +1,17 @@
+package domain
+
+// ShortageReason says why an item could not be reserved.
+type ShortageReason string
+
+const (
+	ShortageOutOfStock     ShortageReason = "out_of_stock"
+	ShortageUnknownProduct ShortageReason = "unknown_product"
+)
+
+// Shortage is a product a reservation cannot cover.
+type Shortage struct {
+	ProductID string
+	Reason    ShortageReason
+	Requested int32
+	Available int32
+}
@@ -1,13 +1,74 @@
 package domain
 
+import "time"
+
+// Reserve holds stock for an order until it is committed or released.
+// Uncommitted reservations expire so that a saga that never finishes cannot
+// hold stock forever.
 type Reserve struct {
 	OrderID        string
 	Items          []*StockItem
 	IdempotencyKey string
 	RequestID      string
+	CreatedAt      time.Time
+
+	// Allocations says which warehouses the items are taken from; releasing
+	// the reservation returns exactly these units.
+	Allocations []Allocation
+
+	// ExpiresAt is when an uncommitted reservation may be released
+	// automatically; zero means never.
+	ExpiresAt time.Time
+
+	// CommittedAt is set once the order is paid: the units are sold and the
+	// reservation no longer expires.
+	CommittedAt time.Time
+
+	// TraceID and SpanID identify the request that made the reservation, so
+	// that an automatic release can be traced back to it.
+	TraceID string
+	SpanID  string
 }
 
+// StockItem is one line of a reservation. Quantity is what was asked for;
+// PlanFulfillment splits it into Reserved units, held in stock, and
+// Backordered units, to be shipped once restocked. Any remainder was
+// dropped.
 type StockItem struct {
-	ProductID string
-	Quantity  int32
+	ProductID   string
+	Quantity    int32
+	Reserved    int32
+	Backordered int32
+}
+
+// Quantities sums the requested units per product, so that an order listing
+// a product twice is checked against its stock once.
+func (r *Reserve) Quantities() map[string]int32 {
+	q := make(map[string]int32, len(r.Items))
+	for _, it := range r.Items {
+		q[it.ProductID] += it.Quantity
+	}
+	return q
+}
+
+// ReservedQuantities sums the reserved units per product; these are the
+// units allocated from warehouses. Products with none are left out.
+func (r *Reserve) ReservedQuantities() map[string]int32 {
+	q := make(map[string]int32, len(r.Items))
+	for _, it := range r.Items {
+		if it.Reserved > 0 {
+			q[it.ProductID] += it.Reserved
+		}
+	}
+	return q
+}
+
+// Committed reports whether the reservation was made permanent.
+func (r *Reserve) Committed() bool {
+	return !r.CommittedAt.IsZero()
+}
+
+// Expired reports whether r is uncommitted and past its expiry at now.
+func (r *Reserve) Expired(now time.Time) bool {
+	return !r.Committed() && !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
 }
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// DefaultWarehouseID is the warehouse that always exists. Stock given
+// without a warehouse, and stock recorded before warehouses were tracked,
+// is kept there.
+const DefaultWarehouseID = "default"
+
+// earthRadiusKm is the mean radius used by Location.DistanceKm.
+const earthRadiusKm = 6371.0
+
+// Location is a point on the globe in decimal degrees.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Validate reports whether l is a valid coordinate.
+func (l Location) Validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return errors.New("latitude must be within ±90 and longitude within ±180")
+	}
+	return nil
+}
+
+// DistanceKm returns the great-circle distance between l and to.
+func (l Location) DistanceKm(to Location) float64 {
+	lat1, lat2 := radians(l.Latitude), radians(to.Latitude)
+	dLat, dLon := lat2-lat1, radians(to.Longitude-l.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+// Warehouse is a location that holds stock.
+type Warehouse struct {
+	ID       string
+	Name     string
+	Location Location
+	// UnitCost is what shipping one unit from the warehouse costs, in minor
+	// units of the shop currency.
+	UnitCost  int64
+	CreatedAt time.Time
+}
+
+// NewWarehouse validates and builds a warehouse.
+func NewWarehouse(id, name string, loc Location, unitCost int64, now time.Time) (*Warehouse, error) {
+	if id == "" {
+		return nil, errors.New("warehouse_id is required")
+	}
+	if err := loc.Validate(); err != nil {
+		return nil, err
+	}
+	if unitCost < 0 {
+		return nil, errors.New("unit_cost cannot be negative")
+	}
+	return &Warehouse{
+		ID:        id,
+		Name:      name,
+		Location:  loc,
+		UnitCost:  unitCost,
+		CreatedAt: now,
+	}, nil
+}
+
+// WarehouseStock is the stock of one product in one warehouse. The
+// Product totals are the sums over its warehouses.
+type WarehouseStock struct {
+	ProductID   string
+	WarehouseID string
+	Available   int32
+	Reserved    int32
+}
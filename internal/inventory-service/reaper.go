@@ -0,0 +1,114 @@
+package inventoryservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/inventory-service/domain"
+)
+
+// reaperBatchSize bounds how many expired reservations one poll releases.
+const reaperBatchSize = 100
+
+// Reaper releases reservations that expired before they were committed, so
+// that stock held by abandoned or crashed orders returns to sale.
+type Reaper struct {
+	repo     domain.InventoryRepository
+	interval time.Duration
+}
+
+// NewReaper creates a reaper that polls repo every interval.
+func NewReaper(repo domain.InventoryRepository, interval time.Duration) *Reaper {
+	return &Reaper{
+		repo:     repo,
+		interval: interval,
+	}
+}
+
+// Run polls for expired reservations until ctx is cancelled.
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ReleaseExpired(ctx); err != nil {
+			slog.ErrorContext(ctx, "reservation reaper poll failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReleaseExpired releases every reservation that is currently expired and
+// returns how many it released.
+func (r *Reaper) ReleaseExpired(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	expired, err := r.repo.Expired(ctx, now, reaperBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, res := range expired {
+		if r.release(ctx, res, now) {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// release releases one reservation under a span that continues the trace
+// of the request that made it, so the release shows up next to the order.
+func (r *Reaper) release(ctx context.Context, res *domain.Reserve, now time.Time) bool {
+	ctx, span := otel.Tracer("inventory-service").Start(
+		reservationContext(ctx, res), "inventory.ReleaseExpired",
+		trace.WithAttributes(attribute.String("order_id", res.OrderID)),
+	)
+	defer span.End()
+
+	released, err := r.repo.ReleaseExpired(ctx, res.OrderID, now)
+	if errors.Is(err, domain.ErrReservationNotFound) {
+		// Committed or released since it was listed.
+		return false
+	}
+	if err != nil {
+		span.RecordError(err)
+		slog.ErrorContext(ctx, "failed to release expired reservation", "order_id", res.OrderID, "error", err)
+		return false
+	}
+
+	slog.InfoContext(ctx, "reservation expired, stock released",
+		"order_id", released.OrderID,
+		"expired_at", released.ExpiresAt,
+		"items", released.ReservedQuantities(),
+	)
+	return true
+}
+
+// reservationContext returns ctx carrying the span context stored with res
+// as its remote parent, or ctx itself if res was made outside a trace.
+func reservationContext(ctx context.Context, res *domain.Reserve) context.Context {
+	traceID, err := trace.TraceIDFromHex(res.TraceID)
+	if err != nil {
+		return ctx
+	}
+	spanID, err := trace.SpanIDFromHex(res.SpanID)
+	if err != nil {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
@@ -2,34 +2,79 @@ package mappers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
 	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/domain"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
 )
 
-func OrderFromProto(ctx context.Context, req *orderv1.CreateOrderRequest) *domain.Order {
+// OrderFromProto builds a new PENDING order. It fails if the currency is
+// invalid or an item is priced in a currency other than the order's.
+func OrderFromProto(ctx context.Context, req *orderv1.CreateOrderRequest) (*domain.Order, error) {
 	if req == nil {
-		return nil
+		return nil, fmt.Errorf("missing request")
+	}
+
+	items, err := mapItemsFromProto(req.GetItems())
+	if err != nil {
+		return nil, err
+	}
+
+	currency := strings.ToUpper(req.GetCurrencyCode())
+	if currency == "" {
+		currency = money.DefaultCurrency
+		if len(items) > 0 {
+			currency = items[0].UnitPrice.CurrencyCode
+		}
+	}
+	if err := money.ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+	for i, it := range items {
+		if it.UnitPrice.CurrencyCode != currency {
+			return nil, fmt.Errorf("item %d: %w: priced in %s, order is in %s",
+				i, money.ErrCurrencyMismatch, it.UnitPrice.CurrencyCode, currency)
+		}
+	}
+	total, err := domain.OrderTotal(currency, items)
+	if err != nil {
+		return nil, err
+	}
+	policy := domain.FulfillmentPolicy(req.GetFulfillmentPolicy().String())
+	if !policy.IsValid() {
+		return nil, fmt.Errorf("unknown fulfillment policy %v", req.GetFulfillmentPolicy())
 	}
 
 	idempKey := interceptors.GetMetadataValue(ctx, constants.HeaderXIdempotencyKey)
 	reqID := interceptors.GetMetadataValue(ctx, constants.HeaderXRequestId)
 
+	id := req.GetId()
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	now := time.Now().UTC()
 	return &domain.Order{
-		ID:             uuid.NewString(),
+		ID:             id,
 		CustomerID:     req.GetCustomerId(),
-		Items:          mapItemsFromProto(req.GetItems()),
-		TotalAmount:    calculateTotal(req.GetItems()),
+		Items:          items,
+		Total:          total,
 		Status:         domain.StatusPending,
 		IdempotencyKey: idempKey,
 		RequestID:      reqID,
-		CreatedAt:      time.Now(),
-	}
+		CreatedAt:      now,
+		UpdatedAt:      now,
+
+		FulfillmentPolicy: policy,
+	}, nil
 }
 
 func OrderToProto(o *domain.Order) *orderv1.OrderInfo {
@@ -41,41 +86,114 @@ func OrderToProto(o *domain.Order) *orderv1.OrderInfo {
 		Id:          o.ID,
 		CustomerId:  o.CustomerID,
 		Items:       mapItemsToProto(o.Items),
-		TotalAmount: o.TotalAmount,
+		TotalAmount: o.Total.Major(),
+		TotalMoney:  o.Total.ToProto(),
 		Status:      mapStatusToProto(o.Status),
+		Reason:      o.Reason,
+		CreatedAt:   timestamppb.New(o.CreatedAt),
+		UpdatedAt:   timestamppb.New(o.UpdatedAt),
+
+		FulfillmentPolicy:   mapFulfillmentPolicyToProto(o.FulfillmentPolicy),
+		Fulfillment:         fulfillmentToProto[o.Fulfillment],
+		FulfilledTotalMoney: o.FulfilledTotal().ToProto(),
+	}
+}
+
+func ItemFulfillmentsFromProto(items []*orderv1.ItemFulfillment) []domain.ItemFulfillment {
+	out := make([]domain.ItemFulfillment, len(items))
+	for i, it := range items {
+		out[i] = domain.ItemFulfillment{
+			ProductID:   it.GetProductId(),
+			Reserved:    int(it.GetReservedQuantity()),
+			Backordered: int(it.GetBackorderedQuantity()),
+		}
+	}
+	return out
+}
+
+func StatusChangesToProto(changes []domain.StatusChange) []*orderv1.StatusChange {
+	out := make([]*orderv1.StatusChange, len(changes))
+	for i, c := range changes {
+		out[i] = &orderv1.StatusChange{
+			From:      mapStatusToProto(c.From),
+			To:        mapStatusToProto(c.To),
+			Reason:    c.Reason,
+			Actor:     c.Actor,
+			RequestId: c.RequestID,
+			ChangedAt: timestamppb.New(c.At),
+		}
+	}
+	return out
+}
+
+func ListFilterFromProto(req *orderv1.ListOrdersRequest) (domain.ListFilter, error) {
+	if req.GetPageSize() < 0 {
+		return domain.ListFilter{}, fmt.Errorf("page_size must not be negative")
 	}
+
+	filter := domain.ListFilter{
+		CustomerID:  req.GetCustomerId(),
+		OldestFirst: req.GetSort() == orderv1.ListOrdersSort_OLDEST_FIRST,
+		Limit:       int(req.GetPageSize()),
+		Cursor:      req.GetPageToken(),
+	}
+	for _, st := range req.GetStatuses() {
+		filter.Statuses = append(filter.Statuses, domain.OrderStatus(st.String()))
+	}
+	if ts := req.GetCreatedAfter(); ts != nil {
+		filter.CreatedFrom = ts.AsTime()
+	}
+	if ts := req.GetCreatedBefore(); ts != nil {
+		filter.CreatedTo = ts.AsTime()
+	}
+	return filter, nil
 }
 
-func mapItemsFromProto(pbItems []*orderv1.OrderItem) []domain.OrderItem {
+func mapItemsFromProto(pbItems []*orderv1.OrderItem) ([]domain.OrderItem, error) {
 	items := make([]domain.OrderItem, len(pbItems))
 	for i, item := range pbItems {
+		price, err := money.FromProtoOrMajor(item.GetUnitPriceMoney(), item.GetUnitPrice())
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
 		items[i] = domain.OrderItem{
 			ProductID: item.GetProductId(),
 			Quantity:  int(item.GetQuantity()),
-			UnitPrice: item.GetUnitPrice(),
+			UnitPrice: price,
 		}
 	}
-	return items
+	return items, nil
 }
 
 func mapItemsToProto(domainItems []domain.OrderItem) []*orderv1.OrderItem {
 	pbItems := make([]*orderv1.OrderItem, len(domainItems))
 	for i, item := range domainItems {
 		pbItems[i] = &orderv1.OrderItem{
-			ProductId: item.ProductID,
-			Quantity:  int32(item.Quantity),
-			UnitPrice: item.UnitPrice,
+			ProductId:      item.ProductID,
+			Quantity:       int32(item.Quantity),
+			UnitPrice:      item.UnitPrice.Major(),
+			UnitPriceMoney: item.UnitPrice.ToProto(),
+
+			ReservedQuantity:    int32(item.Reserved),
+			BackorderedQuantity: int32(item.Backordered),
 		}
 	}
 	return pbItems
 }
 
-func calculateTotal(items []*orderv1.OrderItem) float64 {
-	var total float64
-	for _, item := range items {
-		total += float64(item.GetQuantity()) * item.GetUnitPrice()
+// fulfillmentToProto maps fulfillments to their wire values; an unknown
+// fulfillment maps to FULFILLMENT_UNSPECIFIED.
+var fulfillmentToProto = map[domain.Fulfillment]orderv1.Fulfillment{
+	domain.FulfillmentComplete:    orderv1.Fulfillment_FULFILLMENT_COMPLETE,
+	domain.FulfillmentBackordered: orderv1.Fulfillment_FULFILLMENT_BACKORDERED,
+	domain.FulfillmentPartial:     orderv1.Fulfillment_FULFILLMENT_PARTIAL,
+}
+
+func mapFulfillmentPolicyToProto(p domain.FulfillmentPolicy) orderv1.FulfillmentPolicy {
+	if val, ok := orderv1.FulfillmentPolicy_value[string(p)]; ok {
+		return orderv1.FulfillmentPolicy(val)
 	}
-	return total
+	return orderv1.FulfillmentPolicy_ALL_OR_NOTHING
 }
 
 func mapStatusToProto(s domain.OrderStatus) orderv1.Status {
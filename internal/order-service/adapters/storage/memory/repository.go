@@ -0,0 +1,179 @@
+// Package memory provides an in-memory implementation of
+// domain.OrderRepository, intended for tests and local experiments.
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/domain"
+)
+
+var _ domain.OrderRepository = (*Repository)(nil)
+
+// Repository keeps orders in a map. Orders are copied on the way in and out
+// so callers can never mutate the stored state by accident.
+type Repository struct {
+	mu      sync.RWMutex
+	orders  map[string]*domain.Order
+	byKey   map[string]string // idempotency key -> order ID
+	history map[string][]domain.StatusChange
+}
+
+// New creates an empty in-memory repository.
+func New() *Repository {
+	return &Repository{
+		orders:  make(map[string]*domain.Order),
+		byKey:   make(map[string]string),
+		history: make(map[string][]domain.StatusChange),
+	}
+}
+
+func (r *Repository) Create(_ context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if order.IdempotencyKey != "" {
+		if _, taken := r.byKey[order.IdempotencyKey]; taken {
+			return domain.ErrDuplicateIdempotencyKey
+		}
+	}
+	if _, taken := r.orders[order.ID]; taken {
+		return domain.ErrDuplicateOrderID
+	}
+	if order.IdempotencyKey != "" {
+		r.byKey[order.IdempotencyKey] = order.ID
+	}
+	r.orders[order.ID] = clone(order)
+	r.history[order.ID] = []domain.StatusChange{order.CreationChange()}
+	return nil
+}
+
+func (r *Repository) Get(_ context.Context, id string) (*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	return clone(order), nil
+}
+
+func (r *Repository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Order, error) {
+	r.mu.RLock()
+	id, ok := r.byKey[key]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	return r.Get(ctx, id)
+}
+
+func (r *Repository) UpdateStatus(_ context.Context, change domain.StatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[change.OrderID]
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	if order.Status != change.From {
+		return domain.ErrStatusConflict
+	}
+	order.Status = change.To
+	order.Reason = change.Reason
+	order.UpdatedAt = change.At
+	r.history[change.OrderID] = append(r.history[change.OrderID], change)
+	return nil
+}
+
+func (r *Repository) RecordFulfillment(_ context.Context, o *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[o.ID]
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	order.Fulfillment = o.Fulfillment
+	for i, it := range o.Items {
+		if i < len(order.Items) {
+			order.Items[i].Reserved = it.Reserved
+			order.Items[i].Backordered = it.Backordered
+		}
+	}
+	return nil
+}
+
+func (r *Repository) List(_ context.Context, filter domain.ListFilter) (*domain.OrderPage, error) {
+	var after *domain.Cursor
+	if filter.Cursor != "" {
+		c, err := domain.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &c
+	}
+
+	r.mu.RLock()
+	var matches []*domain.Order
+	for _, o := range r.orders {
+		if matchesFilter(o, filter, after) {
+			matches = append(matches, clone(o))
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(matches, func(a, b *domain.Order) int {
+		c := a.CreatedAt.Compare(b.CreatedAt)
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if !filter.OldestFirst {
+			c = -c
+		}
+		return c
+	})
+
+	page := &domain.OrderPage{Orders: matches}
+	if limit := filter.PageLimit(); len(matches) > limit {
+		page.Orders = matches[:limit]
+		page.NextCursor = domain.CursorAfter(page.Orders[limit-1]).Encode()
+	}
+	return page, nil
+}
+
+// matchesFilter reports whether o belongs in a List result.
+func matchesFilter(o *domain.Order, f domain.ListFilter, after *domain.Cursor) bool {
+	switch {
+	case f.CustomerID != "" && o.CustomerID != f.CustomerID:
+		return false
+	case len(f.Statuses) > 0 && !slices.Contains(f.Statuses, o.Status):
+		return false
+	case !f.CreatedFrom.IsZero() && o.CreatedAt.Before(f.CreatedFrom):
+		return false
+	case !f.CreatedTo.IsZero() && !o.CreatedAt.Before(f.CreatedTo):
+		return false
+	}
+	return after == nil || after.Follows(o, f.OldestFirst)
+}
+
+func (r *Repository) History(_ context.Context, id string) ([]domain.StatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.orders[id]; !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	return slices.Clone(r.history[id]), nil
+}
+
+func clone(o *domain.Order) *domain.Order {
+	c := *o
+	c.Items = slices.Clone(o.Items)
+	return &c
+}
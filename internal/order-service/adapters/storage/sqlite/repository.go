@@ -0,0 +1,585 @@
+// Package sqlite provides a SQLite-backed implementation of
+// domain.OrderRepository so orders survive service restarts.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/sqlitetime"
+
+	// Register the pure-Go SQLite driver (no CGO, see the saga log store).
+	_ "modernc.org/sqlite"
+)
+
+// schema is the DDL executed once on startup.
+const schema = `
+CREATE TABLE IF NOT EXISTS orders (
+    id              TEXT PRIMARY KEY,
+    customer_id     TEXT NOT NULL,
+    -- Deprecated: float total kept for older readers; use total_minor.
+    total_amount    REAL NOT NULL,
+    -- Exact total in minor units of currency, see money.Money.
+    total_minor     INTEGER NOT NULL DEFAULT 0,
+    currency        TEXT NOT NULL DEFAULT 'USD',
+    status          TEXT NOT NULL,
+
+    -- Reason given for the latest status change.
+    reason          TEXT NOT NULL DEFAULT '',
+
+    -- Client-supplied X-Idempotency-Key; '' when the request had none.
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    request_id      TEXT NOT NULL DEFAULT '',
+
+    -- What to do with items short of stock, see domain.FulfillmentPolicy.
+    fulfillment_policy TEXT NOT NULL DEFAULT 'ALL_OR_NOTHING',
+    -- '' until the stock reservation is recorded, see domain.Fulfillment.
+    fulfillment        TEXT NOT NULL DEFAULT '',
+
+    -- Fixed-width RFC3339 TEXT, see sqlitetime.Layout.
+    created_at      TEXT NOT NULL,
+    updated_at      TEXT NOT NULL
+);
+
+-- Keyset pagination for ListOrders, globally and per customer.
+CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders(customer_id, created_at, id);
+
+-- At most one order per idempotency key. Requests without a key are exempt.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_idempotency_key
+    ON orders(idempotency_key) WHERE idempotency_key <> '';
+
+CREATE TABLE IF NOT EXISTS order_items (
+    order_id    TEXT    NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    -- Preserves the order in which the client listed the items.
+    position    INTEGER NOT NULL,
+    product_id  TEXT    NOT NULL,
+    quantity    INTEGER NOT NULL,
+    -- Deprecated: use unit_price_minor, in the currency of the order.
+    unit_price       REAL    NOT NULL,
+    unit_price_minor INTEGER NOT NULL DEFAULT 0,
+    -- Units reserved and backordered of quantity, once recorded.
+    reserved    INTEGER NOT NULL DEFAULT 0,
+    backordered INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (order_id, position)
+);
+
+-- Append-only audit trail: one row per status change, including creation.
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id    TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    -- '' for the creation entry.
+    from_status TEXT NOT NULL,
+    to_status   TEXT NOT NULL,
+    reason      TEXT NOT NULL DEFAULT '',
+    actor       TEXT NOT NULL DEFAULT '',
+    request_id  TEXT NOT NULL DEFAULT '',
+    changed_at  TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id
+    ON order_status_history(order_id, id);
+`
+
+// migrations adds columns introduced after a table was first released.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so databases
+// created by an older build get the new columns here.
+var migrations = []struct {
+	table, column, ddl string
+}{
+	{"orders", "reason", `ALTER TABLE orders ADD COLUMN reason TEXT NOT NULL DEFAULT ''`},
+	{"orders", "currency", `ALTER TABLE orders ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD'`},
+	// Rows written before exact amounts were all USD, two decimals.
+	{"orders", "total_minor", `
+		ALTER TABLE orders ADD COLUMN total_minor INTEGER NOT NULL DEFAULT 0;
+		UPDATE orders SET total_minor = CAST(ROUND(total_amount * 100) AS INTEGER)`},
+	{"order_items", "unit_price_minor", `
+		ALTER TABLE order_items ADD COLUMN unit_price_minor INTEGER NOT NULL DEFAULT 0;
+		UPDATE order_items SET unit_price_minor = CAST(ROUND(unit_price * 100) AS INTEGER)`},
+	{"orders", "fulfillment_policy", `ALTER TABLE orders ADD COLUMN fulfillment_policy TEXT NOT NULL DEFAULT 'ALL_OR_NOTHING'`},
+	{"orders", "fulfillment", `ALTER TABLE orders ADD COLUMN fulfillment TEXT NOT NULL DEFAULT ''`},
+	{"order_items", "reserved", `ALTER TABLE order_items ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0`},
+	{"order_items", "backordered", `ALTER TABLE order_items ADD COLUMN backordered INTEGER NOT NULL DEFAULT 0`},
+}
+
+var _ domain.OrderRepository = (*Repository)(nil)
+
+// Repository is the SQLite implementation of domain.OrderRepository.
+type Repository struct {
+	db *sql.DB
+}
+
+// Open opens (or creates) the SQLite database at path and applies the schema.
+//
+//	repo, err := sqlite.Open("./data/orders.db")
+func Open(path string) (*Repository, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=foreign_keys(on)&_pragma=busy_timeout(5000)", path)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: open %q: %w", path, err)
+	}
+
+	// SQLite performs best with a single writer connection.
+	db.SetMaxOpenConns(1)
+
+	if err := applySchema(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Repository{db: db}, nil
+}
+
+// Close releases the database connection. Call it with defer in main().
+func (r *Repository) Close() error {
+	return r.db.Close()
+}
+
+// Create inserts the order and its items in one transaction. A clash on the
+// idempotency key is reported as domain.ErrDuplicateIdempotencyKey, one on
+// the ID alone as domain.ErrDuplicateOrderID.
+func (r *Repository) Create(ctx context.Context, order *domain.Order) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: create order %q: %w", order.ID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO orders
+			(id, customer_id, total_amount, total_minor, currency, status, reason,
+			 idempotency_key, request_id, fulfillment_policy, fulfillment, created_at, updated_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		order.ID,
+		order.CustomerID,
+		order.Total.Major(),
+		order.Total.MinorUnits,
+		order.Total.CurrencyCode,
+		string(order.Status),
+		order.Reason,
+		order.IdempotencyKey,
+		order.RequestID,
+		string(order.FulfillmentPolicy),
+		string(order.Fulfillment),
+		sqlitetime.Format(order.CreatedAt),
+		sqlitetime.Format(order.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: create order %q: %w", order.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("sqlite: create order %q: %w", order.ID, err)
+	} else if n == 0 {
+		// Callers may choose the ID, so the conflict is not necessarily on
+		// the key. A retry clashes on both and must be told about the key.
+		var keyTaken bool
+		if err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS(SELECT 1 FROM orders WHERE idempotency_key = ? AND idempotency_key <> '')`,
+			order.IdempotencyKey,
+		).Scan(&keyTaken); err != nil {
+			return fmt.Errorf("sqlite: create order %q: %w", order.ID, err)
+		}
+		if keyTaken {
+			return domain.ErrDuplicateIdempotencyKey
+		}
+		return domain.ErrDuplicateOrderID
+	}
+
+	for i, item := range order.Items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO order_items
+				(order_id, position, product_id, quantity, unit_price, unit_price_minor, reserved, backordered)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			order.ID, i, item.ProductID, item.Quantity, item.UnitPrice.Major(), item.UnitPrice.MinorUnits,
+			item.Reserved, item.Backordered,
+		); err != nil {
+			return fmt.Errorf("sqlite: create item %d of order %q: %w", i, order.ID, err)
+		}
+	}
+
+	if err := insertChange(ctx, tx, order.CreationChange()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: create order %q: %w", order.ID, err)
+	}
+	return nil
+}
+
+// Get returns the order with the given ID.
+func (r *Repository) Get(ctx context.Context, id string) (*domain.Order, error) {
+	return r.getWhere(ctx, "id = ?", id)
+}
+
+// GetByIdempotencyKey returns the order created with the given key.
+func (r *Repository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Order, error) {
+	if key == "" {
+		return nil, domain.ErrOrderNotFound
+	}
+	return r.getWhere(ctx, "idempotency_key = ?", key)
+}
+
+// UpdateStatus moves the order from one status to another and appends the
+// change to its history in one transaction. The WHERE clause on the current
+// status makes the check-and-set atomic.
+func (r *Repository) UpdateStatus(ctx context.Context, change domain.StatusChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: update status of %q: %w", change.OrderID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE orders SET status = ?, reason = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		string(change.To), change.Reason, sqlitetime.Format(change.At), change.OrderID, string(change.From),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: update status of %q: %w", change.OrderID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlite: update status of %q: %w", change.OrderID, err)
+	}
+	if n == 0 {
+		// Tell a missing order apart from a lost race.
+		if err := orderExists(ctx, tx, change.OrderID); err != nil {
+			return err
+		}
+		return domain.ErrStatusConflict
+	}
+
+	if err := insertChange(ctx, tx, change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: update status of %q: %w", change.OrderID, err)
+	}
+	return nil
+}
+
+// RecordFulfillment stores the order's fulfillment and the units reserved
+// and backordered per item in one transaction.
+func (r *Repository) RecordFulfillment(ctx context.Context, order *domain.Order) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: record fulfillment of %q: %w", order.ID, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE orders SET fulfillment = ? WHERE id = ?`, string(order.Fulfillment), order.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: record fulfillment of %q: %w", order.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("sqlite: record fulfillment of %q: %w", order.ID, err)
+	} else if n == 0 {
+		return domain.ErrOrderNotFound
+	}
+
+	for i, item := range order.Items {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE order_items SET reserved = ?, backordered = ?
+			WHERE  order_id = ? AND position = ?`,
+			item.Reserved, item.Backordered, order.ID, i,
+		); err != nil {
+			return fmt.Errorf("sqlite: record fulfillment of item %d of order %q: %w", i, order.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: record fulfillment of %q: %w", order.ID, err)
+	}
+	return nil
+}
+
+// History returns the order's status changes, oldest first.
+func (r *Repository) History(ctx context.Context, id string) ([]domain.StatusChange, error) {
+	if err := orderExists(ctx, r.db, id); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT order_id, from_status, to_status, reason, actor, request_id, changed_at
+		FROM   order_status_history
+		WHERE  order_id = ?
+		ORDER  BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: history of %q: %w", id, err)
+	}
+	defer rows.Close()
+
+	changes := []domain.StatusChange{}
+	for rows.Next() {
+		var c domain.StatusChange
+		var changedAt string
+		if err := rows.Scan(&c.OrderID, &c.From, &c.To, &c.Reason, &c.Actor, &c.RequestID, &changedAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan history of %q: %w", id, err)
+		}
+		if c.At, err = sqlitetime.Parse(changedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate history of %q: %w", id, err)
+	}
+	return changes, nil
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// insertChange appends a row to the status history.
+func insertChange(ctx context.Context, q querier, c domain.StatusChange) error {
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO order_status_history
+			(order_id, from_status, to_status, reason, actor, request_id, changed_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?)`,
+		c.OrderID, string(c.From), string(c.To), c.Reason, c.Actor, c.RequestID, sqlitetime.Format(c.At),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: record status change of %q: %w", c.OrderID, err)
+	}
+	return nil
+}
+
+// orderExists returns domain.ErrOrderNotFound if no order has the given ID.
+func orderExists(ctx context.Context, q querier, id string) error {
+	var one int
+	err := q.QueryRowContext(ctx, `SELECT 1 FROM orders WHERE id = ?`, id).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("sqlite: look up order %q: %w", id, err)
+	}
+	return nil
+}
+
+// getWhere loads a single order matching cond, then its items.
+func (r *Repository) getWhere(ctx context.Context, cond string, arg any) (*domain.Order, error) {
+	q := `SELECT ` + orderColumns + ` FROM orders WHERE ` + cond
+
+	o, err := scanOrder(r.db.QueryRowContext(ctx, q, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: get order: %w", err)
+	}
+
+	if o.Items, err = r.items(ctx, o.ID, o.Currency()); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// List returns one page of orders using keyset pagination on
+// (created_at, id), served by idx_orders_created_at and
+// idx_orders_customer_id.
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) (*domain.OrderPage, error) {
+	where := []string{"1 = 1"}
+	var args []any
+
+	if filter.CustomerID != "" {
+		where = append(where, "customer_id = ?")
+		args = append(args, filter.CustomerID)
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(filter.Statuses)), ",")
+		where = append(where, "status IN ("+placeholders+")")
+		for _, st := range filter.Statuses {
+			args = append(args, string(st))
+		}
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, sqlitetime.Format(filter.CreatedFrom))
+	}
+	if !filter.CreatedTo.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, sqlitetime.Format(filter.CreatedTo))
+	}
+
+	cmp, dir := "<", "DESC"
+	if filter.OldestFirst {
+		cmp, dir = ">", "ASC"
+	}
+	if filter.Cursor != "" {
+		c, err := domain.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		at := sqlitetime.Format(c.CreatedAt)
+		where = append(where, "(created_at "+cmp+" ? OR (created_at = ? AND id "+cmp+" ?))")
+		args = append(args, at, at, c.ID)
+	}
+
+	// Fetch one extra row to know whether another page follows.
+	limit := filter.PageLimit()
+	q := `
+		SELECT ` + orderColumns + `
+		FROM   orders
+		WHERE  ` + strings.Join(where, " AND ") + `
+		ORDER  BY created_at ` + dir + `, id ` + dir + `
+		LIMIT  ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list orders: %w", err)
+	}
+	page := &domain.OrderPage{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("sqlite: scan order: %w", err)
+		}
+		page.Orders = append(page.Orders, o)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: iterate orders: %w", err)
+	}
+
+	if len(page.Orders) > limit {
+		page.Orders = page.Orders[:limit]
+		page.NextCursor = domain.CursorAfter(page.Orders[limit-1]).Encode()
+	}
+
+	// Items are loaded after the rows are closed: with a single connection
+	// a nested query would otherwise wait forever for it.
+	for _, o := range page.Orders {
+		if o.Items, err = r.items(ctx, o.ID, o.Currency()); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// orderColumns is the SELECT list shared by order reads; it matches scanOrder.
+const orderColumns = `id, customer_id, total_minor, currency, status, reason, idempotency_key, request_id,
+	fulfillment_policy, fulfillment, created_at, updated_at`
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanOrder reads an order row selected with orderColumns, without items.
+func scanOrder(row scanner) (*domain.Order, error) {
+	var o domain.Order
+	var createdAt, updatedAt string
+	err := row.Scan(
+		&o.ID,
+		&o.CustomerID,
+		&o.Total.MinorUnits,
+		&o.Total.CurrencyCode,
+		&o.Status,
+		&o.Reason,
+		&o.IdempotencyKey,
+		&o.RequestID,
+		&o.FulfillmentPolicy,
+		&o.Fulfillment,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.CreatedAt, err = sqlitetime.Parse(createdAt); err != nil {
+		return nil, err
+	}
+	if o.UpdatedAt, err = sqlitetime.Parse(updatedAt); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// items returns an order's items in their original order. Items are priced
+// in the currency of their order.
+func (r *Repository) items(ctx context.Context, orderID, currency string) ([]domain.OrderItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT product_id, quantity, unit_price_minor, reserved, backordered
+		FROM   order_items
+		WHERE  order_id = ?
+		ORDER  BY position`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: items of %q: %w", orderID, err)
+	}
+	defer rows.Close()
+
+	items := []domain.OrderItem{}
+	for rows.Next() {
+		it := domain.OrderItem{UnitPrice: money.Zero(currency)}
+		if err := rows.Scan(&it.ProductID, &it.Quantity, &it.UnitPrice.MinorUnits, &it.Reserved, &it.Backordered); err != nil {
+			return nil, fmt.Errorf("sqlite: scan item of %q: %w", orderID, err)
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterate items of %q: %w", orderID, err)
+	}
+	return items, nil
+}
+
+// applySchema runs the DDL once and then the migrations. Idempotent due to
+// IF NOT EXISTS and to migrations only adding columns that are missing.
+func applySchema(db *sql.DB) error {
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("sqlite: apply schema: %w", err)
+	}
+
+	for _, m := range migrations {
+		exists, err := hasColumn(db, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(m.ddl); err != nil {
+			return fmt.Errorf("sqlite: migrate %s.%s: %w", m.table, m.column, err)
+		}
+	}
+	return nil
+}
+
+// hasColumn reports whether table already has the given column.
+func hasColumn(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: inspect %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, fmt.Errorf("sqlite: inspect %s: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
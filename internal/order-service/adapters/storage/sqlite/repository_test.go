@@ -0,0 +1,328 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+func open(t *testing.T, path string) *Repository {
+	t.Helper()
+	repo, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+var epoch = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// newOrder returns a pending order created sec seconds after epoch.
+func newOrder(id, customer, key string, sec int) *domain.Order {
+	at := epoch.Add(time.Duration(sec) * time.Second)
+	items := []domain.OrderItem{
+		{ProductID: "p1", Quantity: 3, UnitPrice: money.New(1999, "EUR")},
+		{ProductID: "p2", Quantity: 1, UnitPrice: money.New(1, "EUR")},
+	}
+	total, _ := domain.OrderTotal("EUR", items)
+	return &domain.Order{
+		ID:                id,
+		CustomerID:        customer,
+		Items:             items,
+		Total:             total,
+		Status:            domain.StatusPending,
+		IdempotencyKey:    key,
+		RequestID:         "req-" + id,
+		FulfillmentPolicy: domain.FulfillPartial,
+		CreatedAt:         at,
+		UpdatedAt:         at,
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		second  *domain.Order
+		wantErr error
+	}{
+		{"another order", newOrder("o2", "c1", "key-2", 1), nil},
+		{"orders without a key", newOrder("o2", "c1", "", 1), nil},
+		{"same key", newOrder("o2", "c1", "key-1", 1), domain.ErrDuplicateIdempotencyKey},
+		{"same ID, other key", newOrder("o1", "c1", "key-2", 1), domain.ErrDuplicateOrderID},
+		{"same ID and key", newOrder("o1", "c1", "key-1", 1), domain.ErrDuplicateIdempotencyKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t, filepath.Join(t.TempDir(), "orders.db"))
+			first := newOrder("o1", "c1", "key-1", 0)
+			if tt.second.IdempotencyKey == "" {
+				first.IdempotencyKey = ""
+			}
+			if err := repo.Create(context.Background(), first); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.Create(context.Background(), tt.second); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("second Create error = %v, want %v", err, tt.wantErr)
+			}
+			// A rejected order must leave the stored one untouched.
+			got, err := repo.Get(context.Background(), "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.IdempotencyKey != first.IdempotencyKey || got.CreatedAt != first.CreatedAt {
+				t.Errorf("stored o1 = %+v, want the first order", got)
+			}
+		})
+	}
+}
+
+func TestCreate_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.db")
+	want := newOrder("o1", "c1", "key-1", 0)
+	if err := open(t, path).Create(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening reads what the first connection wrote.
+	repo := open(t, path)
+	for _, get := range []func() (*domain.Order, error){
+		func() (*domain.Order, error) { return repo.Get(context.Background(), "o1") },
+		func() (*domain.Order, error) { return repo.GetByIdempotencyKey(context.Background(), "key-1") },
+	} {
+		got, err := get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != want.ID || got.Total != want.Total || got.Status != want.Status ||
+			got.FulfillmentPolicy != want.FulfillmentPolicy || !got.CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+		if !slices.Equal(got.Items, want.Items) {
+			t.Errorf("items %+v, want %+v", got.Items, want.Items)
+		}
+	}
+
+	history, err := repo.History(context.Background(), "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0] != want.CreationChange() {
+		t.Errorf("history %+v, want the creation entry", history)
+	}
+
+	for _, err := range []error{
+		func() error { _, err := repo.Get(context.Background(), "missing"); return err }(),
+		func() error { _, err := repo.GetByIdempotencyKey(context.Background(), ""); return err }(),
+		func() error { _, err := repo.History(context.Background(), "missing"); return err }(),
+	} {
+		if !errors.Is(err, domain.ErrOrderNotFound) {
+			t.Errorf("error = %v, want ErrOrderNotFound", err)
+		}
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     domain.StatusChange
+		wantErr    error
+		wantStatus domain.OrderStatus
+	}{
+		{"from the stored status", domain.StatusChange{OrderID: "o1", From: domain.StatusPending, To: domain.StatusConfirmed}, nil, domain.StatusConfirmed},
+		{"from a stale status", domain.StatusChange{OrderID: "o1", From: domain.StatusPaid, To: domain.StatusConfirmed}, domain.ErrStatusConflict, domain.StatusPending},
+		{"unknown order", domain.StatusChange{OrderID: "missing", From: domain.StatusPending, To: domain.StatusConfirmed}, domain.ErrOrderNotFound, domain.StatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t, filepath.Join(t.TempDir(), "orders.db"))
+			if err := repo.Create(context.Background(), newOrder("o1", "c1", "key-1", 0)); err != nil {
+				t.Fatal(err)
+			}
+			tt.change.Reason, tt.change.Actor, tt.change.At = "because", "saga", epoch.Add(time.Minute)
+
+			if err := repo.UpdateStatus(context.Background(), tt.change); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			got, err := repo.Get(context.Background(), "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status %s, want %s", got.Status, tt.wantStatus)
+			}
+
+			history, err := repo.History(context.Background(), "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != nil {
+				if len(history) != 1 {
+					t.Errorf("history %+v, want only the creation entry", history)
+				}
+				return
+			}
+			if got.Reason != "because" || !got.UpdatedAt.Equal(tt.change.At) {
+				t.Errorf("reason %q, updated %v; want the change's", got.Reason, got.UpdatedAt)
+			}
+			if len(history) != 2 || history[1] != tt.change {
+				t.Errorf("history %+v, want the change appended", history)
+			}
+		})
+	}
+}
+
+func TestRecordFulfillment(t *testing.T) {
+	repo := open(t, filepath.Join(t.TempDir(), "orders.db"))
+	order := newOrder("o1", "c1", "key-1", 0)
+	if err := repo.Create(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.ApplyFulfillment([]domain.ItemFulfillment{
+		{ProductID: "p1", Reserved: 2},
+		{ProductID: "p2", Reserved: 1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordFulfillment(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.Get(context.Background(), "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Fulfillment != domain.FulfillmentPartial || got.Status != domain.StatusPending {
+		t.Errorf("fulfillment %s, status %s; want PARTIAL, PENDING", got.Fulfillment, got.Status)
+	}
+	if !slices.Equal(got.Items, order.Items) {
+		t.Errorf("items %+v, want %+v", got.Items, order.Items)
+	}
+
+	if err := repo.RecordFulfillment(context.Background(), newOrder("missing", "c1", "", 0)); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Errorf("error = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter domain.ListFilter
+		want   []string
+	}{
+		{"newest first", domain.ListFilter{Limit: 2}, []string{"o5", "o4", "o3", "o2", "o1"}},
+		{"oldest first", domain.ListFilter{Limit: 2, OldestFirst: true}, []string{"o1", "o2", "o3", "o4", "o5"}},
+		{"one page", domain.ListFilter{}, []string{"o5", "o4", "o3", "o2", "o1"}},
+		{"customer", domain.ListFilter{CustomerID: "c2", Limit: 1}, []string{"o4", "o2"}},
+		{"status", domain.ListFilter{Statuses: []domain.OrderStatus{domain.StatusCancelled}}, []string{"o3"}},
+		{"created range", domain.ListFilter{CreatedFrom: epoch.Add(time.Second), CreatedTo: epoch.Add(3 * time.Second)}, []string{"o4", "o3", "o2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t, filepath.Join(t.TempDir(), "orders.db"))
+			// o2, o3 and o4 share a timestamp: the ID breaks the tie.
+			for _, o := range []*domain.Order{
+				newOrder("o1", "c1", "", 0),
+				newOrder("o3", "c1", "", 1),
+				newOrder("o2", "c2", "", 1),
+				newOrder("o4", "c2", "", 1),
+				newOrder("o5", "c1", "", 3),
+			} {
+				if err := repo.Create(context.Background(), o); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := repo.UpdateStatus(context.Background(), domain.StatusChange{
+				OrderID: "o3", From: domain.StatusPending, To: domain.StatusCancelled, At: epoch.Add(time.Hour),
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			filter := tt.filter
+			for {
+				page, err := repo.List(context.Background(), filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, o := range page.Orders {
+					if len(o.Items) != 2 {
+						t.Errorf("order %s listed with %d items, want 2", o.ID, len(o.Items))
+					}
+					got = append(got, o.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				filter.Cursor = page.NextCursor
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_InvalidCursor(t *testing.T) {
+	repo := open(t, filepath.Join(t.TempDir(), "orders.db"))
+	tampered := base64.RawURLEncoding.EncodeToString([]byte("2026-01-02T03:04:05Z"))
+	for _, cursor := range []string{"not-a-cursor", tampered} {
+		if _, err := repo.List(context.Background(), domain.ListFilter{Cursor: cursor}); !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("List with cursor %q: error = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+}
+
+// TestList_PagesThroughEqualTimestamps pages one order at a time through
+// orders created in the same instant, inserted out of ID order, with a new
+// order of that instant stored between pages.
+func TestList_PagesThroughEqualTimestamps(t *testing.T) {
+	for _, oldestFirst := range []bool{false, true} {
+		t.Run(fmt.Sprintf("oldest first %v", oldestFirst), func(t *testing.T) {
+			repo := open(t, filepath.Join(t.TempDir(), "orders.db"))
+			for _, id := range []string{"o3", "o1", "o4", "o2"} {
+				if err := repo.Create(context.Background(), newOrder(id, "c1", "", 0)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var got []string
+			filter := domain.ListFilter{Limit: 1, OldestFirst: oldestFirst}
+			for {
+				page, err := repo.List(context.Background(), filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, o := range page.Orders {
+					got = append(got, o.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				if len(got) == 2 {
+					// Sorts before the cursor when oldest first, after it otherwise.
+					if err := repo.Create(context.Background(), newOrder("o0", "c1", "", 0)); err != nil {
+						t.Fatal(err)
+					}
+				}
+				filter.Cursor = page.NextCursor
+			}
+
+			want := []string{"o4", "o3", "o2", "o1", "o0"}
+			if oldestFirst {
+				want = []string{"o1", "o2", "o3", "o4"}
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ package app
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
-	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -14,30 +14,34 @@ import (
 	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/adapters/grpc/mappers"
 	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/domain"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
 )
 
 // orderServer is the gRPC server implementation for the Order service.
-// It uses an in-memory map as storage for demonstration purposes.
+// Orders are persisted through a domain.OrderRepository.
 type orderServer struct {
 	orderv1.UnimplementedOrderServer
-	mu     sync.RWMutex
-	orders map[string]*domain.Order
-	cache  cache.Cache
+	repo  domain.OrderRepository
+	cache cache.Cache
 }
 
 // Ensure orderServer implements the gRPC interface at compile time.
 var _ orderv1.OrderServer = (*orderServer)(nil)
 
-// NewOrderServer creates a new in-memory order gRPC server.
-func NewOrderServer(cacheProvider cache.Cache) *orderServer {
+// NewOrderServer creates a new order gRPC server backed by repo.
+func NewOrderServer(repo domain.OrderRepository, cacheProvider cache.Cache) *orderServer {
 	return &orderServer{
-		orders: make(map[string]*domain.Order),
-		cache:  cacheProvider,
+		repo:  repo,
+		cache: cacheProvider,
 	}
 }
 
 func (s *orderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (*orderv1.CreateOrderResponse, error) {
-	newOrder := mappers.OrderFromProto(ctx, req)
+	newOrder, err := mappers.OrderFromProto(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order: %v", err)
+	}
 
 	// Idempotency check via cache (fast path).
 	cacheKey := s.cache.GenerateKey("create", newOrder.IdempotencyKey)
@@ -52,21 +56,23 @@ func (s *orderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderR
 		}
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Idempotency check via in-memory map (slow path, handles cache misses).
-	if newOrder.IdempotencyKey != "" {
-		for _, existing := range s.orders {
-			if existing.IdempotencyKey == newOrder.IdempotencyKey {
-				return &orderv1.CreateOrderResponse{Order: mappers.OrderToProto(existing)}, nil
-			}
+	// Idempotency check via the store (slow path, handles cache misses).
+	// The unique index on the key makes this safe across concurrent requests.
+	if err := s.repo.Create(ctx, newOrder); err != nil {
+		if errors.Is(err, domain.ErrDuplicateOrderID) {
+			return nil, status.Errorf(codes.AlreadyExists, "order %s already exists", newOrder.ID)
+		}
+		if !errors.Is(err, domain.ErrDuplicateIdempotencyKey) {
+			return nil, status.Errorf(codes.Internal, "failed to store order: %v", err)
+		}
+		existing, err := s.repo.GetByIdempotencyKey(ctx, newOrder.IdempotencyKey)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to load order for idempotency key: %v", err)
 		}
+		return &orderv1.CreateOrderResponse{Order: mappers.OrderToProto(existing)}, nil
 	}
 
-	s.orders[newOrder.ID] = newOrder
-
-	// Populate cache asynchronously to avoid blocking the response.
+	// Populate cache so retries take the fast path.
 	if newOrder.IdempotencyKey != "" {
 		if jsonValue, err := json.Marshal(newOrder); err == nil {
 			_ = s.cache.Set(ctx, cacheKey, jsonValue, 30*time.Second)
@@ -79,33 +85,128 @@ func (s *orderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderR
 }
 
 func (s *orderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	order, ok := s.orders[req.GetId()]
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "order %s not found", req.GetId())
+	order, err := s.repo.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, repoError(err, req.GetId())
 	}
 
 	return &orderv1.GetOrderResponse{Order: mappers.OrderToProto(order)}, nil
 }
 
 func (s *orderServer) UpdateOrderStatus(ctx context.Context, req *orderv1.UpdateOrderStatusRequest) (*orderv1.UpdateOrderStatusResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	newStatus := domain.OrderStatus(req.GetStatus().String())
+	if !newStatus.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown order status %q", req.GetStatus())
+	}
 
-	order, exists := s.orders[req.GetId()]
-	if !exists {
-		return nil, status.Errorf(codes.NotFound, "order %s not found", req.GetId())
+	order, err := s.repo.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, repoError(err, req.GetId())
 	}
 
-	order.Status = domain.OrderStatus(req.GetStatus().String())
+	if err := order.Status.ValidateTransition(newStatus); err != nil {
+		slog.WarnContext(ctx, "order status transition rejected",
+			"order_id", order.ID,
+			"from", order.Status,
+			"to", newStatus,
+		)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// Re-applying the current status is a no-op, so retries are idempotent.
+	if order.Status == newStatus {
+		return &orderv1.UpdateOrderStatusResponse{Success: true}, nil
+	}
+
+	change := domain.StatusChange{
+		OrderID:   order.ID,
+		From:      order.Status,
+		To:        newStatus,
+		Reason:    req.GetReason(),
+		Actor:     req.GetActor(),
+		RequestID: interceptors.GetMetadataValue(ctx, constants.HeaderXRequestId),
+		At:        time.Now().UTC(),
+	}
+	if err := s.repo.UpdateStatus(ctx, change); err != nil {
+		return nil, repoError(err, order.ID)
+	}
 
 	slog.InfoContext(ctx, "order status updated",
 		"order_id", order.ID,
-		"new_status", order.Status,
-		"request_id", order.RequestID,
+		"old_status", change.From,
+		"new_status", change.To,
+		"reason", change.Reason,
+		"actor", change.Actor,
+		"request_id", change.RequestID,
 	)
 
 	return &orderv1.UpdateOrderStatusResponse{Success: true}, nil
 }
+
+func (s *orderServer) RecordFulfillment(ctx context.Context, req *orderv1.RecordFulfillmentRequest) (*orderv1.RecordFulfillmentResponse, error) {
+	order, err := s.repo.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, repoError(err, req.GetId())
+	}
+	if order.Status.IsTerminal() {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is %s", order.ID, order.Status)
+	}
+
+	if err := order.ApplyFulfillment(mappers.ItemFulfillmentsFromProto(req.GetItems())); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.repo.RecordFulfillment(ctx, order); err != nil {
+		return nil, repoError(err, order.ID)
+	}
+
+	slog.InfoContext(ctx, "order fulfillment recorded",
+		"order_id", order.ID,
+		"fulfillment_policy", order.FulfillmentPolicy,
+		"fulfillment", order.Fulfillment,
+		"fulfilled_total", order.FulfilledTotal().String(),
+	)
+
+	return &orderv1.RecordFulfillmentResponse{Order: mappers.OrderToProto(order)}, nil
+}
+
+func (s *orderServer) GetOrderHistory(ctx context.Context, req *orderv1.GetOrderHistoryRequest) (*orderv1.GetOrderHistoryResponse, error) {
+	changes, err := s.repo.History(ctx, req.GetId())
+	if err != nil {
+		return nil, repoError(err, req.GetId())
+	}
+
+	return &orderv1.GetOrderHistoryResponse{Changes: mappers.StatusChangesToProto(changes)}, nil
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, req *orderv1.ListOrdersRequest) (*orderv1.ListOrdersResponse, error) {
+	filter, err := mappers.ListFilterFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+	}
+
+	orders := make([]*orderv1.OrderInfo, len(page.Orders))
+	for i, o := range page.Orders {
+		orders[i] = mappers.OrderToProto(o)
+	}
+	return &orderv1.ListOrdersResponse{Orders: orders, NextPageToken: page.NextCursor}, nil
+}
+
+// repoError maps repository errors to gRPC status errors.
+func repoError(err error, orderID string) error {
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return status.Errorf(codes.NotFound, "order %s not found", orderID)
+	}
+	if errors.Is(err, domain.ErrStatusConflict) {
+		// Aborted tells the caller to re-read and retry.
+		return status.Errorf(codes.Aborted, "order %s: %v", orderID, err)
+	}
+	return status.Errorf(codes.Internal, "order %s: %v", orderID, err)
+}
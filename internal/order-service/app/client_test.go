@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	orderv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/order/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/adapters/storage/memory"
+	"github.com/jcmexdev/ecommerce-sagas/internal/order-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache/cachetest"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/interceptors/constants"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+func TestUpdateOrderStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		// steps are applied in order; only the last one is checked.
+		steps       []orderv1.Status
+		wantCode    codes.Code
+		wantStatus  domain.OrderStatus
+		wantHistory int
+	}{
+		{"pending to confirmed", []orderv1.Status{orderv1.Status_CONFIRMED}, codes.OK, domain.StatusConfirmed, 2},
+		{"retry of the same status", []orderv1.Status{orderv1.Status_CONFIRMED, orderv1.Status_CONFIRMED}, codes.OK, domain.StatusConfirmed, 2},
+		{"late confirm of a cancelled order", []orderv1.Status{orderv1.Status_CANCELLED, orderv1.Status_CONFIRMED}, codes.FailedPrecondition, domain.StatusCancelled, 2},
+		{"ship a pending order", []orderv1.Status{orderv1.Status_SHIPPED}, codes.FailedPrecondition, domain.StatusPending, 1},
+		{"cancel a confirmed order", []orderv1.Status{orderv1.Status_CONFIRMED, orderv1.Status_CANCELLED}, codes.OK, domain.StatusCancelled, 3},
+		{"unknown status", []orderv1.Status{orderv1.Status(99)}, codes.InvalidArgument, domain.StatusPending, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.New()
+			srv := NewOrderServer(repo, &cachetest.Map{})
+			at := time.Now().UTC()
+			if err := repo.Create(context.Background(), &domain.Order{
+				ID: "o1", CustomerID: "c1", Total: money.New(100, "USD"), Status: domain.StatusPending,
+				CreatedAt: at, UpdatedAt: at,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			var err error
+			for _, st := range tt.steps {
+				_, err = srv.UpdateOrderStatus(context.Background(), &orderv1.UpdateOrderStatusRequest{Id: "o1", Status: st, Reason: "test"})
+			}
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code %v (%v), want %v", got, err, tt.wantCode)
+			}
+
+			order, err := repo.Get(context.Background(), "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if order.Status != tt.wantStatus {
+				t.Errorf("status %s, want %s", order.Status, tt.wantStatus)
+			}
+			history, err := repo.History(context.Background(), "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(history) != tt.wantHistory {
+				t.Errorf("%d history entries, want %d", len(history), tt.wantHistory)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatus_UnknownOrder(t *testing.T) {
+	srv := NewOrderServer(memory.New(), &cachetest.Map{})
+	_, err := srv.UpdateOrderStatus(context.Background(), &orderv1.UpdateOrderStatusRequest{Id: "missing", Status: orderv1.Status_CONFIRMED})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("code %v (%v), want NotFound", got, err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	repo := memory.New()
+	srv := NewOrderServer(repo, &cachetest.Map{})
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := repo.Create(context.Background(), &domain.Order{
+		ID: "o1", CustomerID: "c1", Total: money.New(100, "USD"), Status: domain.StatusPending,
+		RequestID: "req-create", CreatedAt: created, UpdatedAt: created,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	updates := []*orderv1.UpdateOrderStatusRequest{
+		{Id: "o1", Status: orderv1.Status_CONFIRMED, Reason: "payment secured", Actor: "saga-orchestrator"},
+		// Refused: it must leave no trace in the history.
+		{Id: "o1", Status: orderv1.Status_PENDING, Reason: "going back", Actor: "admin"},
+		{Id: "o1", Status: orderv1.Status_CANCELLED, Reason: "customer changed their mind", Actor: "admin"},
+	}
+	for i, req := range updates {
+		ctx := metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs(constants.HeaderXRequestId, fmt.Sprintf("req-%d", i)))
+		_, _ = srv.UpdateOrderStatus(ctx, req)
+	}
+
+	res, err := srv.GetOrderHistory(context.Background(), &orderv1.GetOrderHistoryRequest{Id: "o1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type entry struct {
+		from, to             orderv1.Status
+		reason, actor, reqID string
+	}
+	want := []entry{
+		{orderv1.Status_PENDING, orderv1.Status_PENDING, "order created", "c1", "req-create"},
+		{orderv1.Status_PENDING, orderv1.Status_CONFIRMED, "payment secured", "saga-orchestrator", "req-0"},
+		{orderv1.Status_CONFIRMED, orderv1.Status_CANCELLED, "customer changed their mind", "admin", "req-2"},
+	}
+	var got []entry
+	for _, c := range res.GetChanges() {
+		got = append(got, entry{c.GetFrom(), c.GetTo(), c.GetReason(), c.GetActor(), c.GetRequestId()})
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("history\n got %+v\nwant %+v", got, want)
+	}
+	if at := res.GetChanges()[0].GetChangedAt().AsTime(); !at.Equal(created) {
+		t.Errorf("creation recorded at %v, want %v", at, created)
+	}
+
+	order, err := repo.Get(context.Background(), "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Reason != "customer changed their mind" {
+		t.Errorf("order reason %q, want the one of the last change", order.Reason)
+	}
+}
+
+func TestGetOrderHistory_UnknownOrder(t *testing.T) {
+	srv := NewOrderServer(memory.New(), &cachetest.Map{})
+	_, err := srv.GetOrderHistory(context.Background(), &orderv1.GetOrderHistoryRequest{Id: "missing"})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("code %v (%v), want NotFound", got, err)
+	}
+}
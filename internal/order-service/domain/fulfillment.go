@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// ErrFulfillmentMismatch is returned by ApplyFulfillment when the recorded
+// items do not match the order's.
+var ErrFulfillmentMismatch = errors.New("fulfillment does not match the order items")
+
+// FulfillmentPolicy says what the inventory does with items short of stock.
+// The values match the names in the inventory and order APIs.
+type FulfillmentPolicy string
+
+const (
+	// FulfillAllOrNothing fails the order unless every item is in stock.
+	FulfillAllOrNothing FulfillmentPolicy = "ALL_OR_NOTHING"
+
+	// FulfillPartial ships what is in stock and drops the rest.
+	FulfillPartial FulfillmentPolicy = "PARTIAL"
+
+	// FulfillAllowBackorder ships what is in stock and the rest of
+	// backorderable products once restocked.
+	FulfillAllowBackorder FulfillmentPolicy = "ALLOW_BACKORDER"
+)
+
+// IsValid reports whether p is a known policy.
+func (p FulfillmentPolicy) IsValid() bool {
+	switch p {
+	case FulfillAllOrNothing, FulfillPartial, FulfillAllowBackorder:
+		return true
+	default:
+		return false
+	}
+}
+
+// Fulfillment summarises how much of an order the inventory accepted.
+type Fulfillment string
+
+const (
+	// FulfillmentComplete means every unit is reserved.
+	FulfillmentComplete Fulfillment = "COMPLETE"
+
+	// FulfillmentBackordered means every unit is reserved or backordered,
+	// and some are backordered.
+	FulfillmentBackordered Fulfillment = "BACKORDERED"
+
+	// FulfillmentPartial means some units were dropped and will not ship.
+	FulfillmentPartial Fulfillment = "PARTIAL"
+)
+
+// ItemFulfillment is what the inventory did with one order item.
+type ItemFulfillment struct {
+	ProductID   string
+	Reserved    int
+	Backordered int
+}
+
+// ApplyFulfillment records, per item and in item order, the units the
+// inventory reserved and backordered, and sets o.Fulfillment. It fails
+// with ErrFulfillmentMismatch, changing nothing, if items do not line up
+// with the order's or account for more units than were ordered.
+func (o *Order) ApplyFulfillment(items []ItemFulfillment) error {
+	if len(items) != len(o.Items) {
+		return fmt.Errorf("%w: %d items for an order of %d", ErrFulfillmentMismatch, len(items), len(o.Items))
+	}
+	for i, f := range items {
+		it := o.Items[i]
+		switch {
+		case f.ProductID != it.ProductID:
+			return fmt.Errorf("%w: item %d is %s, not %s", ErrFulfillmentMismatch, i, it.ProductID, f.ProductID)
+		case f.Reserved < 0, f.Backordered < 0, f.Reserved+f.Backordered > it.Quantity:
+			return fmt.Errorf("%w: item %d: %d reserved and %d backordered of %d",
+				ErrFulfillmentMismatch, i, f.Reserved, f.Backordered, it.Quantity)
+		}
+	}
+
+	o.Fulfillment = FulfillmentComplete
+	for i, f := range items {
+		o.Items[i].Reserved = f.Reserved
+		o.Items[i].Backordered = f.Backordered
+		switch {
+		case f.Reserved+f.Backordered < o.Items[i].Quantity:
+			o.Fulfillment = FulfillmentPartial
+		case f.Backordered > 0 && o.Fulfillment == FulfillmentComplete:
+			o.Fulfillment = FulfillmentBackordered
+		}
+	}
+	return nil
+}
+
+// FulfilledTotal is what the customer pays for the units that will ship,
+// reserved or backordered. It is the order total until the fulfillment is
+// recorded.
+func (o *Order) FulfilledTotal() money.Money {
+	if o.Fulfillment == "" {
+		return o.Total
+	}
+	total := money.Zero(o.Currency())
+	for _, it := range o.Items {
+		// Items are priced in the order's currency and ship at most their
+		// quantity, so this cannot fail where OrderTotal succeeded.
+		subtotal, _ := it.UnitPrice.Mul(int64(it.Reserved + it.Backordered))
+		total, _ = total.Add(subtotal)
+	}
+	return total
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+func order() *Order {
+	items := []OrderItem{
+		{ProductID: "a", Quantity: 3, UnitPrice: money.New(1000, "EUR")},
+		{ProductID: "b", Quantity: 2, UnitPrice: money.New(250, "EUR")},
+	}
+	total, _ := OrderTotal("EUR", items)
+	return &Order{ID: "o1", Items: items, Total: total, Status: StatusPending, FulfillmentPolicy: FulfillAllowBackorder}
+}
+
+func TestApplyFulfillment(t *testing.T) {
+	tests := []struct {
+		name            string
+		items           []ItemFulfillment
+		wantErr         error
+		wantFulfillment Fulfillment
+		wantTotal       int64
+	}{
+		{"complete", []ItemFulfillment{{"a", 3, 0}, {"b", 2, 0}}, nil, FulfillmentComplete, 3500},
+		{"backordered", []ItemFulfillment{{"a", 1, 2}, {"b", 2, 0}}, nil, FulfillmentBackordered, 3500},
+		{"partial", []ItemFulfillment{{"a", 2, 0}, {"b", 2, 0}}, nil, FulfillmentPartial, 2500},
+		{"partial wins over backordered", []ItemFulfillment{{"a", 1, 2}, {"b", 0, 0}}, nil, FulfillmentPartial, 3000},
+		{"item missing", []ItemFulfillment{{"a", 3, 0}}, ErrFulfillmentMismatch, "", 3500},
+		{"items out of order", []ItemFulfillment{{"b", 2, 0}, {"a", 3, 0}}, ErrFulfillmentMismatch, "", 3500},
+		{"more than ordered", []ItemFulfillment{{"a", 3, 1}, {"b", 2, 0}}, ErrFulfillmentMismatch, "", 3500},
+		{"negative units", []ItemFulfillment{{"a", -1, 0}, {"b", 2, 0}}, ErrFulfillmentMismatch, "", 3500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := order()
+			before := slices.Clone(o.Items)
+
+			err := o.ApplyFulfillment(tt.items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if o.Fulfillment != tt.wantFulfillment {
+				t.Errorf("fulfillment %q, want %q", o.Fulfillment, tt.wantFulfillment)
+			}
+			if got := o.FulfilledTotal(); got != money.New(tt.wantTotal, "EUR") {
+				t.Errorf("fulfilled total %v, want %d EUR minor units", got, tt.wantTotal)
+			}
+			if err != nil {
+				if !slices.Equal(o.Items, before) {
+					t.Errorf("items changed by a rejected fulfillment: %+v", o.Items)
+				}
+				return
+			}
+			for i, f := range tt.items {
+				if it := o.Items[i]; it.Reserved != f.Reserved || it.Backordered != f.Backordered {
+					t.Errorf("item %d = %+v, want %+v", i, it, f)
+				}
+			}
+		})
+	}
+}
+
+func TestFulfillmentPolicy_IsValid(t *testing.T) {
+	for p, want := range map[FulfillmentPolicy]bool{
+		FulfillAllOrNothing:   true,
+		FulfillPartial:        true,
+		FulfillAllowBackorder: true,
+		"partial":             false,
+		"":                    false,
+	} {
+		if got := p.IsValid(); got != want {
+			t.Errorf("%q.IsValid() = %v, want %v", p, got, want)
+		}
+	}
+}
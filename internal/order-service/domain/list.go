@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPageSize is used when ListFilter.Limit is zero.
+	DefaultPageSize = 20
+
+	// MaxPageSize caps ListFilter.Limit.
+	MaxPageSize = 100
+)
+
+// ErrInvalidCursor is returned by List for a cursor it did not issue.
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// ListFilter selects orders for OrderRepository.List. Zero values mean "no
+// constraint".
+type ListFilter struct {
+	CustomerID string
+	Statuses   []OrderStatus
+
+	// CreatedFrom is inclusive, CreatedTo exclusive.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	// OldestFirst reverses the default newest-first order.
+	OldestFirst bool
+
+	// Limit is the page size; see PageLimit.
+	Limit int
+
+	// Cursor continues a previous List call; pass OrderPage.NextCursor.
+	Cursor string
+}
+
+// PageLimit returns the effective page size for f.
+func (f ListFilter) PageLimit() int {
+	switch {
+	case f.Limit <= 0:
+		return DefaultPageSize
+	case f.Limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return f.Limit
+	}
+}
+
+// OrderPage is one page of List results.
+type OrderPage struct {
+	Orders []*Order
+
+	// NextCursor is empty on the last page.
+	NextCursor string
+}
+
+// Cursor identifies the position after the last order of a page. Orders
+// are sorted by (CreatedAt, ID), so the pair is a stable keyset position
+// even when several orders share a timestamp.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// CursorAfter returns the cursor positioned after o.
+func CursorAfter(o *Order) Cursor {
+	return Cursor{CreatedAt: o.CreatedAt, ID: o.ID}
+}
+
+// Encode renders c as an opaque, URL-safe token.
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+func DecodeCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// Compare returns -1 if o sorts before the cursor position in ascending
+// (CreatedAt, ID) order, +1 if it sorts after and 0 if it is the order the
+// cursor was taken from.
+func (c Cursor) Compare(o *Order) int {
+	if n := o.CreatedAt.Compare(c.CreatedAt); n != 0 {
+		return n
+	}
+	return strings.Compare(o.ID, c.ID)
+}
+
+// Follows reports whether o belongs on a page after the cursor, given the
+// sort direction.
+func (c Cursor) Follows(o *Order, oldestFirst bool) bool {
+	if oldestFirst {
+		return c.Compare(o) > 0
+	}
+	return c.Compare(o) < 0
+}
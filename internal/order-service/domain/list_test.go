@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CET", 3600))
+	for _, c := range []Cursor{
+		{CreatedAt: at, ID: "o1"},
+		{CreatedAt: at.Truncate(time.Second), ID: "order|with|bars"},
+	} {
+		got, err := DecodeCursor(c.Encode())
+		if err != nil {
+			t.Fatalf("DecodeCursor(Encode(%+v)): %v", c, err)
+		}
+		if !got.CreatedAt.Equal(c.CreatedAt) || got.ID != c.ID {
+			t.Errorf("DecodeCursor(Encode(%+v)) = %+v", c, got)
+		}
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	token := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	for name, tok := range map[string]string{
+		"not base64":    "not a cursor!",
+		"no separator":  token("2026-01-02T03:04:05Z"),
+		"no order ID":   token("2026-01-02T03:04:05Z|"),
+		"bad timestamp": token("yesterday|o1"),
+		"padded base64": base64.URLEncoding.EncodeToString([]byte("2026-01-02T03:04:05Z|o1")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeCursor(tok); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", tok, err)
+			}
+		})
+	}
+}
+
+func TestCursor_Follows(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := CursorAfter(&Order{ID: "o2", CreatedAt: at})
+
+	tests := []struct {
+		name        string
+		order       *Order
+		newestFirst bool
+		oldestFirst bool
+	}{
+		{"older", &Order{ID: "o9", CreatedAt: at.Add(-time.Second)}, true, false},
+		{"newer", &Order{ID: "o0", CreatedAt: at.Add(time.Second)}, false, true},
+		{"same time, lower ID", &Order{ID: "o1", CreatedAt: at}, true, false},
+		{"same time, higher ID", &Order{ID: "o3", CreatedAt: at}, false, true},
+		{"the cursor's own order", &Order{ID: "o2", CreatedAt: at}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Follows(tt.order, false); got != tt.newestFirst {
+				t.Errorf("newest first: Follows = %v, want %v", got, tt.newestFirst)
+			}
+			if got := c.Follows(tt.order, true); got != tt.oldestFirst {
+				t.Errorf("oldest first: Follows = %v, want %v", got, tt.oldestFirst)
+			}
+		})
+	}
+}
+
+func TestListFilter_PageLimit(t *testing.T) {
+	for limit, want := range map[int]int{-1: DefaultPageSize, 0: DefaultPageSize, 1: 1, MaxPageSize: MaxPageSize, MaxPageSize + 1: MaxPageSize} {
+		if got := (ListFilter{Limit: limit}).PageLimit(); got != want {
+			t.Errorf("PageLimit with Limit %d = %d, want %d", limit, got, want)
+		}
+	}
+}
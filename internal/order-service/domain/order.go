@@ -1,32 +1,71 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
 
 type Order struct {
 	ID             string
 	CustomerID     string
 	Items          []OrderItem
-	TotalAmount    float64
+	Total          money.Money
 	Status         OrderStatus
+	Reason         string // why the order reached its current status
 	IdempotencyKey string
 	RequestID      string
 	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// FulfillmentPolicy is what the customer accepts when items are short
+	// of stock.
+	FulfillmentPolicy FulfillmentPolicy
+	// Fulfillment is empty until the stock reservation is recorded, see
+	// ApplyFulfillment.
+	Fulfillment Fulfillment
 }
 
 type OrderItem struct {
 	ProductID string
 	Quantity  int
-	UnitPrice float64
+	UnitPrice money.Money
+
+	// Reserved units are held in stock, Backordered ones ship once
+	// restocked; the rest of Quantity will not ship. Both are zero until
+	// the fulfillment is recorded.
+	Reserved    int
+	Backordered int
+}
+
+func (i OrderItem) Subtotal() (money.Money, error) {
+	return i.UnitPrice.Mul(int64(i.Quantity))
+}
+
+// OrderTotal sums the subtotals of items in the order's currency. It fails
+// with money.ErrCurrencyMismatch if any item is priced in another currency,
+// and with money.ErrOverflow if the total does not fit in minor units.
+func OrderTotal(currency string, items []OrderItem) (money.Money, error) {
+	subtotals := make([]money.Money, len(items))
+	for i, it := range items {
+		var err error
+		if subtotals[i], err = it.Subtotal(); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return money.Sum(currency, subtotals...)
 }
 
-func (i OrderItem) Subtotal() float64 {
-	return float64(i.Quantity) * i.UnitPrice
+// Currency returns the currency the order is priced in.
+func (o *Order) Currency() string {
+	return o.Total.CurrencyCode
 }
 
 type OrderStatus string
 
 const (
 	StatusPending   OrderStatus = "PENDING"
+	StatusConfirmed OrderStatus = "CONFIRMED"
 	StatusPaid      OrderStatus = "PAID"
 	StatusShipped   OrderStatus = "SHIPPED"
 	StatusCancelled OrderStatus = "CANCELLED"
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrOrderNotFound is returned when no order matches the requested ID.
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrStatusConflict is returned by UpdateStatus when the order's status
+	// changed concurrently since it was read.
+	ErrStatusConflict = errors.New("order status changed concurrently")
+
+	// ErrDuplicateIdempotencyKey is returned by Create when another order was
+	// already stored with the same idempotency key.
+	ErrDuplicateIdempotencyKey = errors.New("idempotency key already used")
+
+	// ErrDuplicateOrderID is returned by Create when the caller chose an
+	// order ID that is already taken by an order with another key.
+	ErrDuplicateOrderID = errors.New("order ID already used")
+)
+
+// OrderRepository is the port for persisting orders. The gRPC server depends
+// on this abstraction so storage can be SQLite in production and in-memory
+// in tests.
+type OrderRepository interface {
+	// Create stores a new order together with its items and records its
+	// CreationChange in the history. It returns ErrDuplicateIdempotencyKey
+	// if the order's non-empty idempotency key is already taken, otherwise
+	// ErrDuplicateOrderID if its ID is.
+	Create(ctx context.Context, order *Order) error
+
+	// Get returns the order with the given ID or ErrOrderNotFound.
+	Get(ctx context.Context, id string) (*Order, error)
+
+	// GetByIdempotencyKey returns the order created with key or
+	// ErrOrderNotFound.
+	GetByIdempotencyKey(ctx context.Context, key string) (*Order, error)
+
+	// UpdateStatus moves the order from change.From to change.To, sets its
+	// Reason and UpdatedAt and appends change to the history, atomically.
+	// It is a compare-and-swap: if the stored status is no longer
+	// change.From it returns ErrStatusConflict. Callers validate the
+	// transition.
+	UpdateStatus(ctx context.Context, change StatusChange) error
+
+	// RecordFulfillment stores order.Fulfillment and the Reserved and
+	// Backordered units of its items, or returns ErrOrderNotFound. The
+	// status and everything else is left alone.
+	RecordFulfillment(ctx context.Context, order *Order) error
+
+	// List returns one page of orders matching filter, newest first unless
+	// filter.OldestFirst is set. It returns ErrInvalidCursor for a cursor
+	// it did not issue.
+	List(ctx context.Context, filter ListFilter) (*OrderPage, error)
+
+	// History returns the order's status changes, oldest first, or
+	// ErrOrderNotFound.
+	History(ctx context.Context, id string) ([]StatusChange, error)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrInvalidTransition is returned when a status change is not allowed by
+// the order lifecycle, e.g. a late retry trying to confirm a cancelled order.
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// transitions is the order lifecycle. A status missing from the map, or
+// mapped to no targets, is terminal.
+//
+//	PENDING ──► PAID ──► CONFIRMED ──► SHIPPED
+//	   │         │           │
+//	   │         └───────────┴──► CANCELLED
+//	   ├──► CONFIRMED / CANCELLED
+//	   └──► FAILED
+var transitions = map[OrderStatus][]OrderStatus{
+	StatusPending:   {StatusPaid, StatusConfirmed, StatusCancelled, StatusFailed},
+	StatusPaid:      {StatusConfirmed, StatusCancelled, StatusFailed},
+	StatusConfirmed: {StatusShipped, StatusCancelled},
+	StatusShipped:   {},
+	StatusCancelled: {},
+	StatusFailed:    {},
+}
+
+// IsValid reports whether s is a known order status.
+func (s OrderStatus) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are possible from s.
+func (s OrderStatus) IsTerminal() bool {
+	return len(transitions[s]) == 0
+}
+
+// CanTransitionTo reports whether an order may move from s to next.
+// Staying in the same status is always allowed so that retried requests
+// are idempotent.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	if s == next {
+		return s.IsValid()
+	}
+	return slices.Contains(transitions[s], next)
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidTransition if the
+// order may not move from s to next.
+func (s OrderStatus) ValidateTransition(next OrderStatus) error {
+	if !s.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, s, next)
+	}
+	return nil
+}
+
+// StatusChange is one entry in an order's audit trail. From is empty for the
+// entry recorded when the order is created.
+type StatusChange struct {
+	OrderID   string
+	From      OrderStatus
+	To        OrderStatus
+	Reason    string
+	Actor     string
+	RequestID string
+	At        time.Time
+}
+
+// CreationChange returns the audit entry recorded when o is stored. The
+// customer placing the order is its actor.
+func (o *Order) CreationChange() StatusChange {
+	return StatusChange{
+		OrderID:   o.ID,
+		To:        o.Status,
+		Reason:    "order created",
+		Actor:     o.CustomerID,
+		RequestID: o.RequestID,
+		At:        o.CreatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTransition(t *testing.T) {
+	allowed := map[OrderStatus][]OrderStatus{
+		StatusPending:   {StatusPending, StatusPaid, StatusConfirmed, StatusCancelled, StatusFailed},
+		StatusPaid:      {StatusPaid, StatusConfirmed, StatusCancelled, StatusFailed},
+		StatusConfirmed: {StatusConfirmed, StatusShipped, StatusCancelled},
+		StatusShipped:   {StatusShipped},
+		StatusCancelled: {StatusCancelled},
+		StatusFailed:    {StatusFailed},
+	}
+	all := []OrderStatus{StatusPending, StatusPaid, StatusConfirmed, StatusShipped, StatusCancelled, StatusFailed}
+
+	for _, from := range all {
+		for _, to := range all {
+			want := false
+			for _, ok := range allowed[from] {
+				want = want || ok == to
+			}
+			err := from.ValidateTransition(to)
+			if (err == nil) != want {
+				t.Errorf("%s -> %s: error = %v, want allowed %v", from, to, err, want)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTransition) {
+				t.Errorf("%s -> %s: error = %v, want ErrInvalidTransition", from, to, err)
+			}
+		}
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	tests := []struct {
+		status       OrderStatus
+		wantValid    bool
+		wantTerminal bool
+	}{
+		{StatusPending, true, false},
+		{StatusPaid, true, false},
+		{StatusConfirmed, true, false},
+		{StatusShipped, true, true},
+		{StatusCancelled, true, true},
+		{StatusFailed, true, true},
+		{"UNKNOWN", false, true},
+		{"", false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.status.IsValid(); got != tt.wantValid {
+			t.Errorf("%q.IsValid() = %v, want %v", tt.status, got, tt.wantValid)
+		}
+		if got := tt.status.IsTerminal(); got != tt.wantTerminal {
+			t.Errorf("%q.IsTerminal() = %v, want %v", tt.status, got, tt.wantTerminal)
+		}
+	}
+	// An unknown status may not even stay where it is.
+	if OrderStatus("UNKNOWN").CanTransitionTo("UNKNOWN") {
+		t.Error("UNKNOWN -> UNKNOWN allowed")
+	}
+}
@@ -0,0 +1,38 @@
+package simulator
+
+import (
+	"context"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// ChargeLimits caps the amount of a single charge, per currency. A currency
+// without its own entry is capped at Base converted through the rates
+// provider; a currency the provider cannot price is not accepted at all.
+type ChargeLimits struct {
+	Base        money.Money
+	PerCurrency map[string]money.Money
+}
+
+// DefaultChargeLimits keeps the historical 500.00 USD cap and rounds it to
+// friendly figures in the currencies we sell in most.
+var DefaultChargeLimits = ChargeLimits{
+	Base: money.New(500_00, "USD"),
+	PerCurrency: map[string]money.Money{
+		"EUR": money.New(450_00, "EUR"),
+		"GBP": money.New(400_00, "GBP"),
+		"MXN": money.New(8_500_00, "MXN"),
+		"JPY": money.New(75_000, "JPY"),
+	},
+}
+
+// limitFor returns the largest charge allowed in currency.
+func (l ChargeLimits) limitFor(ctx context.Context, rates money.RatesProvider, currency string) (money.Money, error) {
+	if currency == l.Base.CurrencyCode {
+		return l.Base, nil
+	}
+	if limit, ok := l.PerCurrency[currency]; ok {
+		return limit, nil
+	}
+	return money.Convert(ctx, rates, l.Base, currency)
+}
@@ -0,0 +1,88 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+func testRates(t *testing.T) money.RatesProvider {
+	t.Helper()
+	r, err := money.NewStaticRates("USD", map[string]string{"EUR": "0.5", "CAD": "1.25"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestChargeLimits_LimitFor(t *testing.T) {
+	limits := ChargeLimits{
+		Base:        money.New(100_00, "USD"),
+		PerCurrency: map[string]money.Money{"EUR": money.New(80_00, "EUR")},
+	}
+	tests := []struct {
+		name     string
+		currency string
+		want     money.Money
+		wantErr  error
+	}{
+		{"base currency", "USD", money.New(100_00, "USD"), nil},
+		{"own limit wins over the rate", "EUR", money.New(80_00, "EUR"), nil},
+		{"converted from the base", "CAD", money.New(125_00, "CAD"), nil},
+		{"unpriceable currency", "XYZ", money.Money{}, money.ErrNoRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := limits.limitFor(context.Background(), testRates(t), tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("limitFor(%s) error = %v, want %v", tt.currency, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("limitFor(%s) = %v, want %v", tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCharge_AmountLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Limits = ChargeLimits{
+		Base:        money.New(100_00, "USD"),
+		PerCurrency: map[string]money.Money{"EUR": money.New(80_00, "EUR")},
+	}
+
+	tests := []struct {
+		name         string
+		amount       money.Money
+		wantApproved bool
+		wantErr      error
+	}{
+		{"at the limit", money.New(100_00, "USD"), true, nil},
+		{"over the limit", money.New(100_01, "USD"), false, nil},
+		{"over its own limit", money.New(80_01, "EUR"), false, nil},
+		{"within the converted limit", money.New(125_00, "CAD"), true, nil},
+		{"over the converted limit", money.New(125_01, "CAD"), false, nil},
+		{"unpriceable currency", money.New(1_00, "XYZ"), false, domain.ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := New(cfg, testRates(t))
+			res, err := sim.Charge(context.Background(), domain.ChargeRequest{OrderID: "o1", Amount: tt.amount, IdempotencyKey: "o1"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Charge(%v) error = %v, want %v", tt.amount, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if res.Approved != tt.wantApproved {
+				t.Fatalf("Charge(%v) approved = %v, want %v", tt.amount, res.Approved, tt.wantApproved)
+			}
+			if !res.Approved && res.DeclineCode != domain.DeclineAmountLimit {
+				t.Errorf("Charge(%v) decline code = %s, want %s", tt.amount, res.DeclineCode, domain.DeclineAmountLimit)
+			}
+		})
+	}
+}
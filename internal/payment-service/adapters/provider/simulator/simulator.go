@@ -0,0 +1,401 @@
+// Package simulator provides a local domain.PaymentProvider that behaves
+// like a real card processor: it can be slow, decline charges with specific
+// codes, time out and deliver the same webhook more than once. Every
+// behaviour is driven by Config so tests and local runs need no outside
+// service.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// Config tunes the simulated provider. Rates are probabilities in [0, 1].
+type Config struct {
+	// Latency is added to every call, plus a random extra up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// DeclineRate is the share of charges declined at random, with a code
+	// picked from DeclineCodes (DeclineGeneric if empty).
+	DeclineRate  float64
+	DeclineCodes []domain.DeclineCode
+
+	// ForceDeclineCode, when set, declines every charge with that code.
+	ForceDeclineCode domain.DeclineCode
+
+	// TimeoutRate is the share of calls that hang for Timeout (or until the
+	// caller gives up) and then fail with domain.ErrProviderTimeout. The
+	// operation is applied before the hang, like a response lost on the
+	// way back, so a retry with the same key returns the original result.
+	TimeoutRate float64
+	Timeout     time.Duration
+
+	// DuplicateWebhookRate is the share of webhooks delivered twice.
+	// Webhooks are sent WebhookDelay after the call that caused them.
+	DuplicateWebhookRate float64
+	WebhookDelay         time.Duration
+
+	// Limits caps a single charge; larger ones are declined with
+	// domain.DeclineAmountLimit.
+	Limits ChargeLimits
+
+	// Seed makes the random behaviour reproducible. Zero picks a random seed.
+	Seed uint64
+}
+
+// DefaultConfig is a well-behaved provider: no latency, no random failures
+// and the DefaultChargeLimits.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:      10 * time.Second,
+		WebhookDelay: 100 * time.Millisecond,
+		Limits:       DefaultChargeLimits,
+	}
+}
+
+// txState is the lifecycle of an approved transaction:
+// authorized -> captured -> refunded, or authorized -> voided. A captured
+// transaction stays captured until its partial refunds add up to it.
+type txState string
+
+const (
+	stateAuthorized txState = "authorized"
+	stateCaptured   txState = "captured"
+	stateVoided     txState = "voided"
+	stateRefunded   txState = "refunded"
+)
+
+type transaction struct {
+	orderID  string
+	amount   money.Money
+	refunded money.Money
+	state    txState
+}
+
+var _ domain.PaymentProvider = (*Simulator)(nil)
+
+// Simulator is an in-memory domain.PaymentProvider. Results are remembered
+// per idempotency key, as a real provider would.
+type Simulator struct {
+	cfg   Config
+	rates money.RatesProvider
+	seq   atomic.Int64
+
+	mu        sync.Mutex
+	rnd       *rand.Rand
+	charges   map[string]*domain.ChargeResult // charges and authorizations
+	refunds   map[string]*domain.RefundResult
+	txns      map[string]*transaction // approved ones, by transaction ID
+	onWebhook domain.WebhookHandler
+}
+
+// New creates a Simulator. rates prices currencies that have no charge
+// limit of their own.
+//
+//	sim := simulator.New(simulator.DefaultConfig(), rates)
+//	sim.OnWebhook(server.HandleWebhook)
+func New(cfg Config, rates money.RatesProvider) *Simulator {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Simulator{
+		cfg:     cfg,
+		rates:   rates,
+		rnd:     rand.New(rand.NewPCG(seed, seed)),
+		charges: make(map[string]*domain.ChargeResult),
+		refunds: make(map[string]*domain.RefundResult),
+		txns:    make(map[string]*transaction),
+	}
+}
+
+// OnWebhook registers the receiver of webhooks. Without one, webhooks are
+// dropped.
+func (s *Simulator) OnWebhook(h domain.WebhookHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onWebhook = h
+}
+
+func (s *Simulator) Charge(ctx context.Context, req domain.ChargeRequest) (*domain.ChargeResult, error) {
+	return s.open(ctx, req, stateCaptured)
+}
+
+func (s *Simulator) Authorize(ctx context.Context, req domain.ChargeRequest) (*domain.ChargeResult, error) {
+	return s.open(ctx, req, stateAuthorized)
+}
+
+func (s *Simulator) Capture(ctx context.Context, req domain.SettleRequest) error {
+	return s.settle(ctx, req, stateCaptured, domain.WebhookChargeCaptured)
+}
+
+func (s *Simulator) Void(ctx context.Context, req domain.SettleRequest) error {
+	return s.settle(ctx, req, stateVoided, domain.WebhookAuthorizationVoided)
+}
+
+// open decides a new charge or authorization; approved ones start in state.
+func (s *Simulator) open(ctx context.Context, req domain.ChargeRequest, state txState) (*domain.ChargeResult, error) {
+	if err := s.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	limit, err := s.cfg.Limits.limitFor(ctx, s.rates, req.Amount.CurrencyCode)
+	if errors.Is(err, money.ErrNoRate) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedCurrency, req.Amount.CurrencyCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	res, seen := s.charges[req.IdempotencyKey]
+	if !seen {
+		res = s.decide(req, limit)
+		if req.IdempotencyKey != "" {
+			s.charges[req.IdempotencyKey] = res
+		}
+		if res.Approved {
+			s.txns[res.TransactionID] = &transaction{
+				orderID:  req.OrderID,
+				amount:   req.Amount,
+				refunded: money.Zero(req.Amount.CurrencyCode),
+				state:    state,
+			}
+		}
+	}
+	timeout := s.chance(s.cfg.TimeoutRate)
+	s.mu.Unlock()
+
+	if seen {
+		return res, nil
+	}
+
+	hookType := domain.WebhookChargeSucceeded
+	if state == stateAuthorized {
+		hookType = domain.WebhookChargeAuthorized
+	}
+	hook := domain.Webhook{
+		ID:            s.nextID("evt"),
+		Type:          hookType,
+		OrderID:       req.OrderID,
+		TransactionID: res.TransactionID,
+		Amount:        req.Amount,
+		At:            time.Now().UTC(),
+	}
+	if !res.Approved {
+		hook.Type, hook.DeclineCode = domain.WebhookChargeDeclined, res.DeclineCode
+	}
+	s.sendWebhook(ctx, hook)
+
+	if timeout {
+		return nil, s.hang(ctx)
+	}
+	return res, nil
+}
+
+func (s *Simulator) Refund(ctx context.Context, req domain.RefundRequest) (*domain.RefundResult, error) {
+	if err := s.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	res, seen := s.refunds[req.IdempotencyKey]
+	if !seen {
+		t, ok := s.txns[req.TransactionID]
+		if !ok || t.state != stateCaptured {
+			s.mu.Unlock()
+			return nil, s.stateError("refund", req.TransactionID, t)
+		}
+		refunded, err := t.refunded.Add(req.Amount)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		if over, _ := refunded.Cmp(t.amount); over > 0 {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("%w: %s already refunded of %s, %s requested",
+				domain.ErrRefundExceedsCapture, t.refunded, t.amount, req.Amount)
+		}
+		t.refunded = refunded
+		if refunded == t.amount {
+			t.state = stateRefunded
+		}
+		res = &domain.RefundResult{RefundID: s.nextID("re")}
+		if req.IdempotencyKey != "" {
+			s.refunds[req.IdempotencyKey] = res
+		}
+	}
+	timeout := s.chance(s.cfg.TimeoutRate)
+	s.mu.Unlock()
+
+	if seen {
+		return res, nil
+	}
+
+	s.sendWebhook(ctx, domain.Webhook{
+		ID:            s.nextID("evt"),
+		Type:          domain.WebhookRefundSucceeded,
+		OrderID:       req.OrderID,
+		TransactionID: req.TransactionID,
+		Amount:        req.Amount,
+		At:            time.Now().UTC(),
+	})
+
+	if timeout {
+		return nil, s.hang(ctx)
+	}
+	return res, nil
+}
+
+// settle moves an authorization to captured or voided. Repeating the same
+// move is a no-op, as it would be with a real provider and the same key.
+func (s *Simulator) settle(ctx context.Context, req domain.SettleRequest, to txState, hookType domain.WebhookType) error {
+	if err := s.delay(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	t, ok := s.txns[req.TransactionID]
+	if ok && t.state == to {
+		s.mu.Unlock()
+		return nil
+	}
+	if !ok || t.state != stateAuthorized {
+		s.mu.Unlock()
+		return s.stateError(string(to), req.TransactionID, t)
+	}
+	t.state = to
+	if to == stateCaptured && req.Amount != nil {
+		// A partial capture releases the rest of the hold.
+		t.amount = *req.Amount
+	}
+	amount := t.amount
+	timeout := s.chance(s.cfg.TimeoutRate)
+	s.mu.Unlock()
+
+	s.sendWebhook(ctx, domain.Webhook{
+		ID:            s.nextID("evt"),
+		Type:          hookType,
+		OrderID:       req.OrderID,
+		TransactionID: req.TransactionID,
+		Amount:        amount,
+		At:            time.Now().UTC(),
+	})
+
+	if timeout {
+		return s.hang(ctx)
+	}
+	return nil
+}
+
+// stateError describes why op cannot be applied to the transaction id,
+// which is nil if unknown.
+func (s *Simulator) stateError(op, id string, t *transaction) error {
+	if t == nil {
+		return fmt.Errorf("%w: %s: unknown transaction %s", domain.ErrInvalidTransactionState, op, id)
+	}
+	return fmt.Errorf("%w: %s: transaction %s is %s", domain.ErrInvalidTransactionState, op, id, t.state)
+}
+
+// decide approves or declines a new charge. Callers hold s.mu.
+func (s *Simulator) decide(req domain.ChargeRequest, limit money.Money) *domain.ChargeResult {
+	res := &domain.ChargeResult{TransactionID: s.nextID("ch")}
+
+	switch over, _ := req.Amount.Cmp(limit); {
+	case s.cfg.ForceDeclineCode != "":
+		res.DeclineCode = s.cfg.ForceDeclineCode
+	case over > 0:
+		res.DeclineCode = domain.DeclineAmountLimit
+		res.Message = fmt.Sprintf("amount %s exceeds the limit of %s", req.Amount, limit)
+	case s.chance(s.cfg.DeclineRate):
+		res.DeclineCode = domain.DeclineGeneric
+		if n := len(s.cfg.DeclineCodes); n > 0 {
+			res.DeclineCode = s.cfg.DeclineCodes[s.rnd.IntN(n)]
+		}
+	default:
+		res.Approved = true
+	}
+	if !res.Approved && res.Message == "" {
+		res.Message = "card declined: " + string(res.DeclineCode)
+	}
+	return res
+}
+
+// chance reports true with probability p. Callers hold s.mu.
+func (s *Simulator) chance(p float64) bool {
+	return p > 0 && s.rnd.Float64() < p
+}
+
+// delay sleeps for the configured latency or until ctx is done.
+func (s *Simulator) delay(ctx context.Context) error {
+	d := s.cfg.Latency
+	if s.cfg.Jitter > 0 {
+		s.mu.Lock()
+		d += time.Duration(s.rnd.Int64N(int64(s.cfg.Jitter)))
+		s.mu.Unlock()
+	}
+	return sleep(ctx, d)
+}
+
+// hang simulates a provider that stops answering.
+func (s *Simulator) hang(ctx context.Context) error {
+	if err := sleep(ctx, s.cfg.Timeout); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrProviderTimeout, err)
+	}
+	return domain.ErrProviderTimeout
+}
+
+// sendWebhook delivers w after WebhookDelay, sometimes twice, without
+// blocking the call that caused it.
+func (s *Simulator) sendWebhook(ctx context.Context, w domain.Webhook) {
+	s.mu.Lock()
+	h := s.onWebhook
+	deliveries := 1
+	if s.chance(s.cfg.DuplicateWebhookRate) {
+		deliveries = 2
+	}
+	s.mu.Unlock()
+
+	if h == nil {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		for range deliveries {
+			if err := sleep(ctx, s.cfg.WebhookDelay); err != nil {
+				return
+			}
+			slog.DebugContext(ctx, "simulator: delivering webhook", "webhook_id", w.ID, "type", w.Type)
+			h(ctx, w)
+		}
+	}()
+}
+
+func (s *Simulator) nextID(prefix string) string {
+	return fmt.Sprintf("sim_%s_%06d", prefix, s.seq.Add(1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
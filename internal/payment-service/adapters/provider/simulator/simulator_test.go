@@ -0,0 +1,310 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// testConfig is DefaultConfig with webhooks delivered right away.
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.WebhookDelay = 0
+	cfg.Seed = 1
+	return cfg
+}
+
+// webhooks registers a receiver on sim and returns its deliveries.
+func webhooks(sim *Simulator) <-chan domain.Webhook {
+	ch := make(chan domain.Webhook, 8)
+	sim.OnWebhook(func(_ context.Context, w domain.Webhook) { ch <- w })
+	return ch
+}
+
+func receive(t *testing.T, ch <-chan domain.Webhook) domain.Webhook {
+	t.Helper()
+	select {
+	case w := <-ch:
+		return w
+	case <-time.After(time.Second):
+		t.Fatal("no webhook delivered")
+		return domain.Webhook{}
+	}
+}
+
+func chargeReq(key string) domain.ChargeRequest {
+	return domain.ChargeRequest{OrderID: "o1", Amount: money.New(10_00, "USD"), IdempotencyKey: key}
+}
+
+func TestCharge_Declines(t *testing.T) {
+	tests := []struct {
+		name     string
+		tune     func(*Config)
+		wantCode domain.DeclineCode
+	}{
+		{"approved", func(*Config) {}, ""},
+		{"forced code", func(c *Config) { c.ForceDeclineCode = domain.DeclineFraudSuspected }, domain.DeclineFraudSuspected},
+		{"forced code wins over the rate", func(c *Config) {
+			c.ForceDeclineCode = domain.DeclineExpiredCard
+			c.DeclineRate, c.DeclineCodes = 1, []domain.DeclineCode{domain.DeclineInsufficientFunds}
+		}, domain.DeclineExpiredCard},
+		{"declined at random", func(c *Config) {
+			c.DeclineRate, c.DeclineCodes = 1, []domain.DeclineCode{domain.DeclineInsufficientFunds}
+		}, domain.DeclineInsufficientFunds},
+		{"declined at random without codes", func(c *Config) { c.DeclineRate = 1 }, domain.DeclineGeneric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			tt.tune(&cfg)
+			sim := New(cfg, testRates(t))
+			hooks := webhooks(sim)
+
+			res, err := sim.Charge(context.Background(), chargeReq("k1"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Approved != (tt.wantCode == "") || res.DeclineCode != tt.wantCode {
+				t.Fatalf("Charge = %+v, want decline code %q", res, tt.wantCode)
+			}
+
+			w := receive(t, hooks)
+			wantType := domain.WebhookChargeSucceeded
+			if !res.Approved {
+				wantType = domain.WebhookChargeDeclined
+				if want := "card declined: " + string(tt.wantCode); res.Message != want {
+					t.Errorf("message %q, want %q", res.Message, want)
+				}
+			}
+			if w.Type != wantType || w.DeclineCode != tt.wantCode || w.TransactionID != res.TransactionID {
+				t.Errorf("webhook %+v, want %s for %s with code %q", w, wantType, res.TransactionID, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCharge_SeedIsReproducible(t *testing.T) {
+	cfg := testConfig()
+	cfg.Seed = 42
+	cfg.DeclineRate = 0.5
+	cfg.DeclineCodes = []domain.DeclineCode{domain.DeclineInsufficientFunds, domain.DeclineExpiredCard, domain.DeclineFraudSuspected}
+
+	outcomes := func() []domain.DeclineCode {
+		sim := New(cfg, testRates(t))
+		var out []domain.DeclineCode
+		for i := range 50 {
+			res, err := sim.Charge(context.Background(), chargeReq(fmt.Sprint(i)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			out = append(out, res.DeclineCode)
+		}
+		return out
+	}
+
+	first := outcomes()
+	if got := outcomes(); !slices.Equal(got, first) {
+		t.Fatalf("same seed gave\n%q\nthen\n%q", first, got)
+	}
+	declined := slices.IndexFunc(first, func(c domain.DeclineCode) bool { return c != "" })
+	if !slices.Contains(first, "") || declined < 0 {
+		t.Errorf("outcomes %q, want a mix of approvals and declines", first)
+	}
+}
+
+func TestCharge_Timeout(t *testing.T) {
+	cfg := testConfig()
+	cfg.TimeoutRate = 1
+	cfg.Timeout = time.Millisecond
+	sim := New(cfg, testRates(t))
+	hooks := webhooks(sim)
+
+	if _, err := sim.Charge(context.Background(), chargeReq("k1")); !errors.Is(err, domain.ErrProviderTimeout) {
+		t.Fatalf("Charge error = %v, want %v", err, domain.ErrProviderTimeout)
+	}
+	// The charge went through before the answer was lost.
+	w := receive(t, hooks)
+	if w.Type != domain.WebhookChargeSucceeded {
+		t.Errorf("webhook %s, want %s", w.Type, domain.WebhookChargeSucceeded)
+	}
+
+	res, err := sim.Charge(context.Background(), chargeReq("k1"))
+	if err != nil {
+		t.Fatalf("retry error = %v, want the original result", err)
+	}
+	if !res.Approved || res.TransactionID != w.TransactionID {
+		t.Errorf("retry = %+v, want the approved transaction %s", res, w.TransactionID)
+	}
+}
+
+func TestCharge_TimeoutEndsWithTheCaller(t *testing.T) {
+	cfg := testConfig()
+	cfg.TimeoutRate = 1
+	cfg.Timeout = time.Hour
+	sim := New(cfg, testRates(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := sim.Charge(ctx, chargeReq("k1"))
+	if !errors.Is(err, domain.ErrProviderTimeout) {
+		t.Fatalf("Charge error = %v, want %v", err, domain.ErrProviderTimeout)
+	}
+}
+
+func TestWebhook_Duplicates(t *testing.T) {
+	cfg := testConfig()
+	cfg.DuplicateWebhookRate = 1
+	sim := New(cfg, testRates(t))
+	hooks := webhooks(sim)
+
+	if _, err := sim.Charge(context.Background(), chargeReq("k1")); err != nil {
+		t.Fatal(err)
+	}
+	first, second := receive(t, hooks), receive(t, hooks)
+	if first != second {
+		t.Errorf("deliveries differ:\n%+v\n%+v", first, second)
+	}
+
+	// A repeated key answers from memory and sends nothing.
+	if _, err := sim.Charge(context.Background(), chargeReq("k1")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case w := <-hooks:
+		t.Errorf("retry delivered %+v", w)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestTransaction_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	usd := func(cents int64) money.Money { return money.New(cents, "USD") }
+
+	tests := []struct {
+		name     string
+		open     func(*Simulator) (*domain.ChargeResult, error)
+		act      func(s *Simulator, txID string) error
+		wantErr  error
+		wantHook domain.WebhookType
+	}{
+		{
+			name: "capture an authorization",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Authorize(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				return s.Capture(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id})
+			},
+			wantHook: domain.WebhookChargeCaptured,
+		},
+		{
+			name: "void an authorization",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Authorize(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				return s.Void(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id})
+			},
+			wantHook: domain.WebhookAuthorizationVoided,
+		},
+		{
+			name: "capture twice",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Authorize(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				if err := s.Capture(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id}); err != nil {
+					return err
+				}
+				return s.Capture(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id})
+			},
+			wantHook: domain.WebhookChargeCaptured,
+		},
+		{
+			name: "capture an unknown transaction",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Authorize(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, _ string) error {
+				return s.Capture(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: "sim_ch_999999"})
+			},
+			wantErr: domain.ErrInvalidTransactionState,
+		},
+		{
+			name: "void a charge",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Charge(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				return s.Void(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id})
+			},
+			wantErr: domain.ErrInvalidTransactionState,
+		},
+		{
+			name: "capture a voided authorization",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Authorize(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				if err := s.Void(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id}); err != nil {
+					return err
+				}
+				return s.Capture(ctx, domain.SettleRequest{OrderID: "o1", TransactionID: id})
+			},
+			wantErr: domain.ErrInvalidTransactionState,
+		},
+		{
+			name: "refund a charge",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Charge(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				_, err := s.Refund(ctx, domain.RefundRequest{OrderID: "o1", TransactionID: id, Amount: usd(4_00), IdempotencyKey: "r1"})
+				return err
+			},
+			wantHook: domain.WebhookRefundSucceeded,
+		},
+		{
+			name: "refund an authorization",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Authorize(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				_, err := s.Refund(ctx, domain.RefundRequest{OrderID: "o1", TransactionID: id, Amount: usd(4_00), IdempotencyKey: "r1"})
+				return err
+			},
+			wantErr: domain.ErrInvalidTransactionState,
+		},
+		{
+			name: "refund more than was captured",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Charge(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				_, err := s.Refund(ctx, domain.RefundRequest{OrderID: "o1", TransactionID: id, Amount: usd(10_01), IdempotencyKey: "r1"})
+				return err
+			},
+			wantErr: domain.ErrRefundExceedsCapture,
+		},
+		{
+			name: "refund a fully refunded charge",
+			open: func(s *Simulator) (*domain.ChargeResult, error) { return s.Charge(ctx, chargeReq("k1")) },
+			act: func(s *Simulator, id string) error {
+				if _, err := s.Refund(ctx, domain.RefundRequest{OrderID: "o1", TransactionID: id, Amount: usd(10_00), IdempotencyKey: "r1"}); err != nil {
+					return err
+				}
+				_, err := s.Refund(ctx, domain.RefundRequest{OrderID: "o1", TransactionID: id, Amount: usd(1), IdempotencyKey: "r2"})
+				return err
+			},
+			wantErr: domain.ErrInvalidTransactionState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := New(testConfig(), testRates(t))
+			res, err := tt.open(sim)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hooks := webhooks(sim)
+
+			if err := tt.act(sim, res.TransactionID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantHook == "" {
+				return
+			}
+			if w := receive(t, hooks); w.Type != tt.wantHook || w.TransactionID != res.TransactionID {
+				t.Errorf("webhook %s for %s, want %s for %s", w.Type, w.TransactionID, tt.wantHook, res.TransactionID)
+			}
+		})
+	}
+}
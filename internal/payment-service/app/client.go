@@ -2,98 +2,429 @@ package paymentservice
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
 	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
 )
 
 const idempotencyTTL = 60 * time.Second
 
+// payment is a charge or authorization the provider approved. Until
+// Captured it is only a hold. A voided hold is kept as a tombstone, so that
+// a late webhook cannot bring it back to be captured.
+type payment struct {
+	TransactionID string
+	Amount        money.Money
+	Captured      bool
+	Voided        bool
+	Refunds       []refund // oldest first
+}
+
 type paymentServer struct {
 	paymentv1.UnimplementedPaymentServer
-	mu       sync.Mutex
-	payments map[string]float64
+	provider domain.PaymentProvider
 	cache    cache.Cache
+
+	mu       sync.Mutex
+	payments map[string]payment  // by order ID
+	webhooks map[string]struct{} // IDs of webhooks already handled
 }
 
 var _ paymentv1.PaymentServer = (*paymentServer)(nil)
 
 // NewClient creates a new in-memory payment gRPC server backed by a cache for idempotency.
-func NewClient(c cache.Cache) *paymentServer {
+// Charges and refunds are forwarded to provider, which decides whether they go through.
+func NewClient(c cache.Cache, provider domain.PaymentProvider) *paymentServer {
 	return &paymentServer{
-		payments: make(map[string]float64),
+		provider: provider,
 		cache:    c,
+		payments: make(map[string]payment),
+		webhooks: make(map[string]struct{}),
 	}
 }
 
 func (s *paymentServer) Charge(ctx context.Context, req *paymentv1.ChargeRequest) (*paymentv1.ChargeResponse, error) {
+	if p, _ := s.payment(req.GetOrderId()); p.Voided {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment for order %s was voided", req.GetOrderId())
+	}
+
 	chargeCacheKey := s.cache.GenerateKey("charge", req.GetOrderId())
 	if val, _ := s.cache.Get(ctx, chargeCacheKey); val != "" {
 		slog.InfoContext(ctx, "charge: idempotent response from cache", "order_id", req.GetOrderId())
 		return &paymentv1.ChargeResponse{Success: true}, nil
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	amount, err := money.FromProtoOrMajor(req.GetAmountMoney(), req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %v", err)
+	}
+	if c := req.GetCurrencyCode(); c != "" && !strings.EqualFold(c, amount.CurrencyCode) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"mixed-currency order: order is in %s, amount is in %s", c, amount.CurrencyCode)
+	}
 
-	if _, alreadyCharged := s.payments[req.GetOrderId()]; alreadyCharged {
+	if p, ok := s.payment(req.GetOrderId()); ok {
+		// Only a captured payment answers for a charge; an authorization
+		// still on hold must be captured, not charged a second time.
+		if !p.Captured {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"order %s has an uncaptured authorization; capture it instead", req.GetOrderId())
+		}
 		slog.InfoContext(ctx, "charge: idempotent response from memory", "order_id", req.GetOrderId())
 		return &paymentv1.ChargeResponse{Success: true}, nil
 	}
 
-	slog.InfoContext(ctx, "processing charge", "order_id", req.GetOrderId(), "amount", req.GetAmount())
+	slog.InfoContext(ctx, "processing charge", "order_id", req.GetOrderId(), "amount", amount.String())
+
+	// The provider is called without holding the lock: it may be slow, and
+	// the order ID as idempotency key already stops double charges.
+	res, err := s.provider.Charge(ctx, domain.ChargeRequest{
+		OrderID:        req.GetOrderId(),
+		Amount:         amount,
+		IdempotencyKey: req.GetOrderId(),
+	})
+	if err != nil {
+		return nil, providerError(err)
+	}
 
-	if req.GetAmount() > 500.00 {
-		slog.WarnContext(ctx, "charge declined: amount exceeds limit",
+	if !res.Approved {
+		slog.WarnContext(ctx, "charge declined by provider",
 			"order_id", req.GetOrderId(),
-			"amount", req.GetAmount(),
+			"amount", amount.String(),
+			"decline_code", res.DeclineCode,
+			"message", res.Message,
 		)
-		return &paymentv1.ChargeResponse{Success: false}, nil
+		return &paymentv1.ChargeResponse{
+			Success:        false,
+			DeclineReason:  declineReason(res.DeclineCode),
+			DeclineMessage: res.Message,
+		}, nil
 	}
 
-	s.payments[req.GetOrderId()] = req.GetAmount()
+	s.recordPayment(ctx, req.GetOrderId(), payment{TransactionID: res.TransactionID, Amount: amount, Captured: true})
 
-	amountStr := fmt.Sprintf("%.2f", req.GetAmount())
-	if err := s.cache.Set(ctx, chargeCacheKey, amountStr, idempotencyTTL); err != nil {
+	if err := s.cache.Set(ctx, chargeCacheKey, amount.String(), idempotencyTTL); err != nil {
 		slog.WarnContext(ctx, "failed to persist charge idempotency key to cache",
 			"order_id", req.GetOrderId(),
 			"error", err,
 		)
 	}
 
-	slog.InfoContext(ctx, "charge successful", "order_id", req.GetOrderId(), "amount", req.GetAmount())
+	slog.InfoContext(ctx, "charge successful",
+		"order_id", req.GetOrderId(),
+		"amount", amount.String(),
+		"transaction_id", res.TransactionID,
+	)
 	return &paymentv1.ChargeResponse{Success: true}, nil
 }
 
-func (s *paymentServer) Refund(ctx context.Context, req *paymentv1.RefundRequest) (*paymentv1.RefundResponse, error) {
-	refundCacheKey := s.cache.GenerateKey("refund", req.GetOrderId())
-	if val, _ := s.cache.Get(ctx, refundCacheKey); val != "" {
-		slog.InfoContext(ctx, "refund: idempotent response from cache", "order_id", req.GetOrderId())
-		return &paymentv1.RefundResponse{Success: true}, nil
+func (s *paymentServer) Authorize(ctx context.Context, req *paymentv1.AuthorizeRequest) (*paymentv1.AuthorizeResponse, error) {
+	// Checked before the cache, which would still hand out the voided
+	// authorization.
+	if p, _ := s.payment(req.GetOrderId()); p.Voided {
+		return nil, status.Errorf(codes.FailedPrecondition, "authorization for order %s was voided", req.GetOrderId())
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	authCacheKey := s.cache.GenerateKey("authorize", req.GetOrderId())
+	if val, _ := s.cache.Get(ctx, authCacheKey); val != "" {
+		slog.InfoContext(ctx, "authorize: idempotent response from cache", "order_id", req.GetOrderId())
+		return &paymentv1.AuthorizeResponse{Success: true, AuthorizationId: val}, nil
+	}
 
-	amount, exists := s.payments[req.GetOrderId()]
-	if !exists {
-		slog.WarnContext(ctx, "no payment found to refund", "order_id", req.GetOrderId())
-		return &paymentv1.RefundResponse{Success: true}, nil
+	amount, err := money.FromProto(req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %v", err)
+	}
+	if c := req.GetCurrencyCode(); c != "" && !strings.EqualFold(c, amount.CurrencyCode) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"mixed-currency order: order is in %s, amount is in %s", c, amount.CurrencyCode)
+	}
+
+	if p, exists := s.payment(req.GetOrderId()); exists {
+		slog.InfoContext(ctx, "authorize: idempotent response from memory", "order_id", req.GetOrderId())
+		return &paymentv1.AuthorizeResponse{Success: true, AuthorizationId: p.TransactionID}, nil
+	}
+
+	slog.InfoContext(ctx, "processing authorization", "order_id", req.GetOrderId(), "amount", amount.String())
+
+	res, err := s.provider.Authorize(ctx, domain.ChargeRequest{
+		OrderID:        req.GetOrderId(),
+		Amount:         amount,
+		IdempotencyKey: "authorize:" + req.GetOrderId(),
+	})
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	if !res.Approved {
+		slog.WarnContext(ctx, "authorization declined by provider",
+			"order_id", req.GetOrderId(),
+			"amount", amount.String(),
+			"decline_code", res.DeclineCode,
+			"message", res.Message,
+		)
+		return &paymentv1.AuthorizeResponse{
+			Success:        false,
+			DeclineReason:  declineReason(res.DeclineCode),
+			DeclineMessage: res.Message,
+		}, nil
 	}
 
-	slog.InfoContext(ctx, "processing refund", "order_id", req.GetOrderId(), "amount", amount)
-	delete(s.payments, req.GetOrderId())
+	s.recordPayment(ctx, req.GetOrderId(), payment{TransactionID: res.TransactionID, Amount: amount})
 
-	if err := s.cache.Set(ctx, refundCacheKey, "ok", idempotencyTTL); err != nil {
-		slog.WarnContext(ctx, "failed to persist refund idempotency key to cache",
+	if err := s.cache.Set(ctx, authCacheKey, res.TransactionID, idempotencyTTL); err != nil {
+		slog.WarnContext(ctx, "failed to persist authorize idempotency key to cache",
 			"order_id", req.GetOrderId(),
 			"error", err,
 		)
 	}
 
-	return &paymentv1.RefundResponse{Success: true}, nil
+	slog.InfoContext(ctx, "authorization successful",
+		"order_id", req.GetOrderId(),
+		"amount", amount.String(),
+		"authorization_id", res.TransactionID,
+	)
+	return &paymentv1.AuthorizeResponse{Success: true, AuthorizationId: res.TransactionID}, nil
+}
+
+func (s *paymentServer) Capture(ctx context.Context, req *paymentv1.CaptureRequest) (*paymentv1.CaptureResponse, error) {
+	p, exists := s.payment(req.GetOrderId())
+	if !exists {
+		return nil, status.Errorf(codes.FailedPrecondition, "no authorization to capture for order %s", req.GetOrderId())
+	}
+	if p.Voided {
+		return nil, status.Errorf(codes.FailedPrecondition, "authorization for order %s was voided", req.GetOrderId())
+	}
+	if p.Captured {
+		slog.InfoContext(ctx, "capture: already captured", "order_id", req.GetOrderId())
+		return &paymentv1.CaptureResponse{Success: true}, nil
+	}
+
+	var amount *money.Money
+	if req.GetAmount() != nil {
+		m, err := money.FromProto(req.GetAmount())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %v", err)
+		}
+		if c, err := m.Cmp(p.Amount); err != nil || c > 0 || !m.IsPositive() {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"capture amount %s must be positive and at most the authorized %s", m, p.Amount)
+		}
+		amount = &m
+	}
+
+	captured := p.Amount
+	if amount != nil {
+		captured = *amount
+	}
+	slog.InfoContext(ctx, "processing capture",
+		"order_id", req.GetOrderId(),
+		"authorized", p.Amount.String(),
+		"amount", captured.String(),
+	)
+
+	err := s.provider.Capture(ctx, domain.SettleRequest{
+		OrderID:        req.GetOrderId(),
+		TransactionID:  p.TransactionID,
+		IdempotencyKey: "capture:" + req.GetOrderId(),
+		Amount:         amount,
+	})
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	// A charge.captured webhook may have got here first; either way the
+	// outcome is the same.
+	s.updatePayment(req.GetOrderId(), p.TransactionID, func(p *payment) {
+		p.Captured = true
+		p.Amount = captured
+	})
+
+	slog.InfoContext(ctx, "capture successful", "order_id", req.GetOrderId(), "amount", captured.String())
+	return &paymentv1.CaptureResponse{Success: true}, nil
+}
+
+func (s *paymentServer) Void(ctx context.Context, req *paymentv1.VoidRequest) (*paymentv1.VoidResponse, error) {
+	p, exists := s.payment(req.GetOrderId())
+	if !exists {
+		slog.WarnContext(ctx, "no authorization found to void", "order_id", req.GetOrderId())
+		return &paymentv1.VoidResponse{Success: true}, nil
+	}
+	if p.Captured {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"payment for order %s was already captured; refund it instead", req.GetOrderId())
+	}
+	if p.Voided {
+		slog.InfoContext(ctx, "void: already voided", "order_id", req.GetOrderId())
+		return &paymentv1.VoidResponse{Success: true}, nil
+	}
+
+	if err := s.void(ctx, req.GetOrderId(), p); err != nil {
+		return nil, err
+	}
+	return &paymentv1.VoidResponse{Success: true}, nil
+}
+
+// void releases the uncaptured payment p and marks it voided.
+func (s *paymentServer) void(ctx context.Context, orderID string, p payment) error {
+	slog.InfoContext(ctx, "processing void", "order_id", orderID, "amount", p.Amount.String())
+
+	err := s.provider.Void(ctx, domain.SettleRequest{
+		OrderID:        orderID,
+		TransactionID:  p.TransactionID,
+		IdempotencyKey: "void:" + orderID,
+	})
+	if err != nil {
+		return providerError(err)
+	}
+
+	s.updatePayment(orderID, p.TransactionID, func(p *payment) { p.Voided = true })
+
+	slog.InfoContext(ctx, "void successful", "order_id", orderID)
+	return nil
+}
+
+// HandleWebhook is a domain.WebhookHandler. Duplicate deliveries are
+// ignored. A charge.succeeded or charge.authorized for an order with no
+// recorded payment means the response was lost (e.g. a provider timeout),
+// so the payment is recorded from the webhook to keep Refund and Void
+// working. A charge.captured or authorization.voided likewise settles a
+// hold whose capture or void response was lost. Webhooks for a voided
+// hold are ignored: it stays voided even if its authorization is only
+// reported afterwards.
+func (s *paymentServer) HandleWebhook(ctx context.Context, w domain.Webhook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, dup := s.webhooks[w.ID]; dup {
+		slog.InfoContext(ctx, "webhook: duplicate delivery ignored", "webhook_id", w.ID, "type", w.Type)
+		return
+	}
+	s.webhooks[w.ID] = struct{}{}
+
+	slog.InfoContext(ctx, "webhook received",
+		"webhook_id", w.ID,
+		"type", w.Type,
+		"order_id", w.OrderID,
+		"transaction_id", w.TransactionID,
+	)
+
+	p, known := s.payments[w.OrderID]
+	if known && p.Voided {
+		slog.InfoContext(ctx, "webhook: ignored for voided authorization", "webhook_id", w.ID, "order_id", w.OrderID)
+		return
+	}
+	switch w.Type {
+	case domain.WebhookChargeSucceeded, domain.WebhookChargeAuthorized:
+		if !known {
+			slog.WarnContext(ctx, "webhook: reconciled payment missing from local state",
+				"order_id", w.OrderID,
+				"transaction_id", w.TransactionID,
+			)
+			s.payments[w.OrderID] = payment{
+				TransactionID: w.TransactionID,
+				Amount:        w.Amount,
+				Captured:      w.Type == domain.WebhookChargeSucceeded,
+			}
+		}
+	case domain.WebhookChargeCaptured:
+		if known && p.TransactionID == w.TransactionID && !p.Captured {
+			p.Captured = true
+			p.Amount = w.Amount // less than authorized after a partial capture
+			s.payments[w.OrderID] = p
+		}
+	case domain.WebhookAuthorizationVoided:
+		// Unknown means the authorization was never reported; its webhook
+		// may still come and must find the tombstone.
+		if !known || (p.TransactionID == w.TransactionID && !p.Captured) {
+			s.payments[w.OrderID] = payment{
+				TransactionID: w.TransactionID,
+				Amount:        w.Amount,
+				Voided:        true,
+			}
+		}
+	}
+}
+
+// payment returns a copy of the order's payment that is safe to read
+// without holding the lock.
+func (s *paymentServer) payment(orderID string) (payment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.payments[orderID]
+	p.Refunds = slices.Clone(p.Refunds)
+	return p, ok
+}
+
+// recordPayment stores the payment the provider just approved, unless a
+// webhook already recorded that transaction: then the stored one is
+// newer and stays.
+func (s *paymentServer) recordPayment(ctx context.Context, orderID string, p payment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cur, ok := s.payments[orderID]; ok && cur.TransactionID == p.TransactionID {
+		slog.InfoContext(ctx, "payment already recorded from webhook", "order_id", orderID, "transaction_id", p.TransactionID)
+		return
+	}
+	s.payments[orderID] = p
+}
+
+// updatePayment applies update to the order's payment as it is now, under
+// the lock, if it is still transaction txID. Payments are read without the
+// lock before a provider call, so writing such a copy back would lose what
+// webhooks changed in the meantime.
+func (s *paymentServer) updatePayment(orderID, txID string, update func(*payment)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.payments[orderID]; ok && p.TransactionID == txID {
+		update(&p)
+		s.payments[orderID] = p
+	}
+}
+
+// providerError maps a provider failure to a gRPC status. Timeouts and
+// outages are retryable by the saga; an unsupported currency or a
+// transaction in the wrong state is not.
+func providerError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidTransactionState), errors.Is(err, domain.ErrRefundExceedsCapture):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrProviderTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "payment provider: %v", err)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Errorf(codes.Unavailable, "payment provider: %v", err)
+	}
+}
+
+// declineReasons maps provider decline codes to their wire values.
+var declineReasons = map[domain.DeclineCode]paymentv1.DeclineReason{
+	domain.DeclineGeneric:           paymentv1.DeclineReason_GENERIC_DECLINE,
+	domain.DeclineInsufficientFunds: paymentv1.DeclineReason_INSUFFICIENT_FUNDS,
+	domain.DeclineExpiredCard:       paymentv1.DeclineReason_EXPIRED_CARD,
+	domain.DeclineFraudSuspected:    paymentv1.DeclineReason_FRAUD_SUSPECTED,
+	domain.DeclineAmountLimit:       paymentv1.DeclineReason_LIMIT_EXCEEDED,
+}
+
+// declineReason maps a provider decline code, treating unknown ones as a
+// generic decline.
+func declineReason(code domain.DeclineCode) paymentv1.DeclineReason {
+	if r, ok := declineReasons[code]; ok {
+		return r
+	}
+	return paymentv1.DeclineReason_GENERIC_DECLINE
 }
@@ -0,0 +1,275 @@
+package paymentservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache/cachetest"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// fakeProvider approves everything, unless decline is set. onCapture, if
+// set, runs in the middle of a capture, as a webhook or another request
+// racing it would. The next failRefunds refunds fail.
+type fakeProvider struct {
+	mu          sync.Mutex
+	refunds     int
+	failRefunds int
+	onCapture   func()
+	decline     domain.DeclineCode
+}
+
+func (p *fakeProvider) Charge(_ context.Context, req domain.ChargeRequest) (*domain.ChargeResult, error) {
+	return p.decide(req), nil
+}
+
+func (p *fakeProvider) Authorize(_ context.Context, req domain.ChargeRequest) (*domain.ChargeResult, error) {
+	return p.decide(req), nil
+}
+
+func (p *fakeProvider) decide(req domain.ChargeRequest) *domain.ChargeResult {
+	if p.decline != "" {
+		return &domain.ChargeResult{DeclineCode: p.decline, Message: "declined: " + string(p.decline)}
+	}
+	return &domain.ChargeResult{TransactionID: "tx_" + req.OrderID, Approved: true}
+}
+
+func (p *fakeProvider) Capture(context.Context, domain.SettleRequest) error {
+	if p.onCapture != nil {
+		p.onCapture()
+	}
+	return nil
+}
+
+func (p *fakeProvider) Void(context.Context, domain.SettleRequest) error { return nil }
+
+func (p *fakeProvider) Refund(context.Context, domain.RefundRequest) (*domain.RefundResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failRefunds > 0 {
+		p.failRefunds--
+		return nil, errors.New("provider unavailable")
+	}
+	p.refunds++
+	return &domain.RefundResult{RefundID: fmt.Sprintf("re_%d", p.refunds)}, nil
+}
+
+func usd(minor int64) money.Money { return money.New(minor, "USD") }
+
+func authorize(t *testing.T, srv *paymentServer, orderID string, amount money.Money) {
+	t.Helper()
+	res, err := srv.Authorize(context.Background(), &paymentv1.AuthorizeRequest{OrderId: orderID, Amount: amount.ToProto()})
+	if err != nil || !res.GetSuccess() {
+		t.Fatalf("authorize %s: %v %v", orderID, res, err)
+	}
+}
+
+func webhook(id string, typ domain.WebhookType, orderID string, amount money.Money) domain.Webhook {
+	return domain.Webhook{ID: id, Type: typ, OrderID: orderID, TransactionID: "tx_" + orderID, Amount: amount}
+}
+
+func wantCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("got %v (%v), want %v", got, err, want)
+	}
+}
+
+func TestHandleWebhook_VoidedAuthorizationStaysVoided(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup leaves the authorization of order o1 voided.
+		setup func(t *testing.T, srv *paymentServer)
+	}{
+		{"late authorized webhook after void", func(t *testing.T, srv *paymentServer) {
+			authorize(t, srv, "o1", usd(2000))
+			if _, err := srv.Void(context.Background(), &paymentv1.VoidRequest{OrderId: "o1"}); err != nil {
+				t.Fatal(err)
+			}
+			srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookChargeAuthorized, "o1", usd(2000)))
+		}},
+		{"late authorized webhook after full refund of a hold", func(t *testing.T, srv *paymentServer) {
+			authorize(t, srv, "o1", usd(2000))
+			if _, err := srv.Refund(context.Background(), &paymentv1.RefundRequest{OrderId: "o1"}); err != nil {
+				t.Fatal(err)
+			}
+			srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookChargeAuthorized, "o1", usd(2000)))
+		}},
+		{"void webhook for a lost void response", func(t *testing.T, srv *paymentServer) {
+			authorize(t, srv, "o1", usd(2000))
+			srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookAuthorizationVoided, "o1", usd(2000)))
+		}},
+		{"void webhook before the authorized webhook", func(t *testing.T, srv *paymentServer) {
+			srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookAuthorizationVoided, "o1", usd(2000)))
+			srv.HandleWebhook(context.Background(), webhook("wh_2", domain.WebhookChargeAuthorized, "o1", usd(2000)))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewClient(&cachetest.Map{}, &fakeProvider{})
+			tt.setup(t, srv)
+
+			_, err := srv.Capture(context.Background(), &paymentv1.CaptureRequest{OrderId: "o1"})
+			wantCode(t, err, codes.FailedPrecondition)
+			_, err = srv.Authorize(context.Background(), &paymentv1.AuthorizeRequest{OrderId: "o1", Amount: usd(2000).ToProto()})
+			wantCode(t, err, codes.FailedPrecondition)
+			if res, err := srv.Void(context.Background(), &paymentv1.VoidRequest{OrderId: "o1"}); err != nil || !res.GetSuccess() {
+				t.Fatalf("void again: %v %v, want success", res, err)
+			}
+		})
+	}
+}
+
+func TestHandleWebhook_CapturedSettlesLostCapture(t *testing.T) {
+	srv := NewClient(&cachetest.Map{}, &fakeProvider{})
+	authorize(t, srv, "o1", usd(2000))
+	srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookChargeCaptured, "o1", usd(1500)))
+	// A redelivery with another amount must not change anything.
+	srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookChargeCaptured, "o1", usd(1000)))
+
+	p, _ := srv.payment("o1")
+	if !p.Captured || p.Amount != usd(1500) {
+		t.Fatalf("payment = %+v, want captured 15.00", p)
+	}
+	_, err := srv.Void(context.Background(), &paymentv1.VoidRequest{OrderId: "o1"})
+	wantCode(t, err, codes.FailedPrecondition)
+}
+
+// TestCapture_KeepsChangesMadeDuringTheCall has the capture webhook and a
+// refund land while the provider is still answering the capture: the
+// capture must not write back the payment as it was before.
+func TestCapture_KeepsChangesMadeDuringTheCall(t *testing.T) {
+	provider := &fakeProvider{}
+	srv := NewClient(&cachetest.Map{}, provider)
+	authorize(t, srv, "o1", usd(2000))
+
+	provider.onCapture = func() {
+		srv.HandleWebhook(context.Background(), webhook("wh_1", domain.WebhookChargeCaptured, "o1", usd(2000)))
+		_, err := srv.Refund(context.Background(), &paymentv1.RefundRequest{
+			OrderId: "o1", IdempotencyKey: "return-1", Amount: usd(500).ToProto(),
+		})
+		if err != nil {
+			t.Errorf("refund during capture: %v", err)
+		}
+	}
+	if _, err := srv.Capture(context.Background(), &paymentv1.CaptureRequest{OrderId: "o1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := srv.ListRefunds(context.Background(), &paymentv1.ListRefundsRequest{OrderId: "o1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.GetRefunds()) != 1 || res.GetRefunded().GetMinorUnits() != 500 {
+		t.Fatalf("refunds after capture = %v, want the 5.00 refund", res)
+	}
+}
+
+func TestCharge_Currency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		wantCode codes.Code
+	}{
+		{"order in the same currency", "USD", codes.OK},
+		{"currency case does not matter", "usd", codes.OK},
+		{"currency not given", "", codes.OK},
+		{"mixed-currency order", "EUR", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewClient(&cachetest.Map{}, &fakeProvider{})
+			_, err := srv.Charge(context.Background(), &paymentv1.ChargeRequest{
+				OrderId: "o1", AmountMoney: usd(2000).ToProto(), CurrencyCode: tt.currency,
+			})
+			wantCode(t, err, tt.wantCode)
+			if _, charged := srv.payment("o1"); charged != (tt.wantCode == codes.OK) {
+				t.Errorf("payment recorded = %v, want %v", charged, tt.wantCode == codes.OK)
+			}
+		})
+	}
+}
+
+func TestCharge_ExistingPayment(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup leaves a payment recorded for order o1.
+		setup    func(t *testing.T, srv *paymentServer)
+		wantCode codes.Code
+	}{
+		{"captured charge is returned again", func(t *testing.T, srv *paymentServer) {
+			srv.recordPayment(context.Background(), "o1", payment{TransactionID: "tx_o1", Amount: usd(2000), Captured: true})
+		}, codes.OK},
+		{"authorization on hold", func(t *testing.T, srv *paymentServer) {
+			authorize(t, srv, "o1", usd(2000))
+		}, codes.FailedPrecondition},
+		{"voided authorization", func(t *testing.T, srv *paymentServer) {
+			authorize(t, srv, "o1", usd(2000))
+			if _, err := srv.Void(context.Background(), &paymentv1.VoidRequest{OrderId: "o1"}); err != nil {
+				t.Fatal(err)
+			}
+		}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewClient(&cachetest.Map{}, &fakeProvider{})
+			tt.setup(t, srv)
+
+			res, err := srv.Charge(context.Background(), &paymentv1.ChargeRequest{OrderId: "o1", AmountMoney: usd(2000).ToProto()})
+			wantCode(t, err, tt.wantCode)
+			if err == nil && !res.GetSuccess() {
+				t.Fatalf("charge = %v, want success", res)
+			}
+		})
+	}
+}
+
+func TestCharge_DeclineReason(t *testing.T) {
+	tests := []struct {
+		code domain.DeclineCode
+		want paymentv1.DeclineReason
+	}{
+		{domain.DeclineGeneric, paymentv1.DeclineReason_GENERIC_DECLINE},
+		{domain.DeclineInsufficientFunds, paymentv1.DeclineReason_INSUFFICIENT_FUNDS},
+		{domain.DeclineExpiredCard, paymentv1.DeclineReason_EXPIRED_CARD},
+		{domain.DeclineFraudSuspected, paymentv1.DeclineReason_FRAUD_SUSPECTED},
+		{domain.DeclineAmountLimit, paymentv1.DeclineReason_LIMIT_EXCEEDED},
+		{"do_not_honor", paymentv1.DeclineReason_GENERIC_DECLINE},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			srv := NewClient(&cachetest.Map{}, &fakeProvider{decline: tt.code})
+			ctx := context.Background()
+
+			charge, err := srv.Charge(ctx, &paymentv1.ChargeRequest{OrderId: "o1", AmountMoney: usd(2000).ToProto()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			hold, err := srv.Authorize(ctx, &paymentv1.AuthorizeRequest{OrderId: "o2", Amount: usd(2000).ToProto()})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantMessage := "declined: " + string(tt.code)
+			if charge.GetSuccess() || charge.GetDeclineReason() != tt.want || charge.GetDeclineMessage() != wantMessage {
+				t.Errorf("charge = %v, want declined with %s and %q", charge, tt.want, wantMessage)
+			}
+			if hold.GetSuccess() || hold.GetDeclineReason() != tt.want || hold.GetDeclineMessage() != wantMessage {
+				t.Errorf("authorization = %v, want declined with %s and %q", hold, tt.want, wantMessage)
+			}
+			for _, orderID := range []string{"o1", "o2"} {
+				if _, ok := srv.payment(orderID); ok {
+					t.Errorf("payment recorded for declined order %s", orderID)
+				}
+			}
+		})
+	}
+}
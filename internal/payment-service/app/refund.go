@@ -0,0 +1,259 @@
+package paymentservice
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/payment-service/domain"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// fullRefundKey is the idempotency key of refunds that ask for everything
+// left, such as saga compensations.
+const fullRefundKey = "full"
+
+// refund is one refund recorded against a payment. A refund is pending
+// while the provider call is in flight: it already counts against the
+// captured amount so that concurrent refunds cannot exceed it together.
+type refund struct {
+	ID      string
+	Key     string // idempotency key
+	Amount  money.Money
+	Reason  string
+	At      time.Time
+	pending bool
+}
+
+// refunded sums the refunds of p, pending ones included.
+func (p *payment) refunded() money.Money {
+	total := money.Zero(p.Amount.CurrencyCode)
+	for _, r := range p.Refunds {
+		total, _ = total.Add(r.Amount) // all in the payment's currency
+	}
+	return total
+}
+
+// remaining is what can still be refunded.
+func (p *payment) remaining() money.Money {
+	left, _ := p.Amount.Sub(p.refunded())
+	return left
+}
+
+func (s *paymentServer) Refund(ctx context.Context, req *paymentv1.RefundRequest) (*paymentv1.RefundResponse, error) {
+	key := req.GetIdempotencyKey()
+	if key == "" {
+		if req.GetAmount() != nil {
+			return nil, status.Error(codes.InvalidArgument, "idempotency_key is required for partial refunds")
+		}
+		key = fullRefundKey
+	}
+
+	refundCacheKey := s.cache.GenerateKey("refund", req.GetOrderId()+":"+key)
+	if val, _ := s.cache.Get(ctx, refundCacheKey); val != "" {
+		slog.InfoContext(ctx, "refund: idempotent response from cache", "order_id", req.GetOrderId())
+		if p, ok := s.payment(req.GetOrderId()); ok {
+			for _, r := range p.Refunds {
+				if r.ID == val {
+					return refundResponse(r, p), nil
+				}
+			}
+		}
+		return &paymentv1.RefundResponse{Success: true, RefundId: val}, nil
+	}
+
+	p, exists := s.payment(req.GetOrderId())
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "no payment found for order %s", req.GetOrderId())
+	}
+	if c := req.GetCurrencyCode(); c != "" && !strings.EqualFold(c, p.Amount.CurrencyCode) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"refund currency %s does not match charge currency %s", c, p.Amount.CurrencyCode)
+	}
+	if !p.Captured {
+		if req.GetAmount() != nil {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"payment for order %s is not captured; void it instead", req.GetOrderId())
+		}
+		// Nothing was taken yet: releasing the hold is free, a refund is not.
+		if p.Voided {
+			slog.InfoContext(ctx, "refund: authorization already voided", "order_id", req.GetOrderId())
+			return &paymentv1.RefundResponse{Success: true}, nil
+		}
+		if err := s.void(ctx, req.GetOrderId(), p); err != nil {
+			return nil, err
+		}
+		return &paymentv1.RefundResponse{Success: true}, nil
+	}
+
+	var amount *money.Money
+	if req.GetAmount() != nil {
+		m, err := money.FromProto(req.GetAmount())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %v", err)
+		}
+		amount = &m
+	}
+
+	r, p, done, err := s.reserveRefund(req.GetOrderId(), key, amount, req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		slog.InfoContext(ctx, "refund: idempotent response from memory", "order_id", req.GetOrderId(), "refund_id", r.ID)
+		return refundResponse(r, p), nil
+	}
+	if r.Amount.IsZero() {
+		slog.InfoContext(ctx, "refund: nothing left to refund", "order_id", req.GetOrderId())
+		return refundResponse(r, p), nil
+	}
+
+	slog.InfoContext(ctx, "processing refund",
+		"order_id", req.GetOrderId(),
+		"amount", r.Amount.String(),
+		"reason", r.Reason,
+	)
+
+	res, err := s.provider.Refund(ctx, domain.RefundRequest{
+		OrderID:        req.GetOrderId(),
+		TransactionID:  p.TransactionID,
+		Amount:         r.Amount,
+		Reason:         r.Reason,
+		IdempotencyKey: "refund:" + req.GetOrderId() + ":" + key,
+	})
+	if err != nil {
+		s.settleRefund(req.GetOrderId(), key, "")
+		return nil, providerError(err)
+	}
+	r, p = s.settleRefund(req.GetOrderId(), key, res.RefundID)
+
+	if err := s.cache.Set(ctx, refundCacheKey, res.RefundID, idempotencyTTL); err != nil {
+		slog.WarnContext(ctx, "failed to persist refund idempotency key to cache",
+			"order_id", req.GetOrderId(),
+			"error", err,
+		)
+	}
+
+	slog.InfoContext(ctx, "refund successful",
+		"order_id", req.GetOrderId(),
+		"refund_id", res.RefundID,
+		"amount", r.Amount.String(),
+		"remaining", p.remaining().String(),
+	)
+	return refundResponse(r, p), nil
+}
+
+func (s *paymentServer) ListRefunds(ctx context.Context, req *paymentv1.ListRefundsRequest) (*paymentv1.ListRefundsResponse, error) {
+	p, exists := s.payment(req.GetOrderId())
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "no payment found for order %s", req.GetOrderId())
+	}
+
+	captured := money.Zero(p.Amount.CurrencyCode)
+	if p.Captured {
+		captured = p.Amount
+	}
+
+	res := &paymentv1.ListRefundsResponse{
+		Captured: captured.ToProto(),
+		Refunds:  []*paymentv1.RefundRecord{},
+	}
+	refunded := money.Zero(p.Amount.CurrencyCode)
+	for _, r := range p.Refunds {
+		if r.pending {
+			continue
+		}
+		refunded, _ = refunded.Add(r.Amount)
+		res.Refunds = append(res.Refunds, &paymentv1.RefundRecord{
+			RefundId:  r.ID,
+			Amount:    r.Amount.ToProto(),
+			Reason:    r.Reason,
+			CreatedAt: timestamppb.New(r.At),
+		})
+	}
+	res.Refunded = refunded.ToProto()
+	return res, nil
+}
+
+// reserveRefund records a pending refund of amount, or of everything left
+// if amount is nil, after checking it fits in what was captured. If key was
+// used before, it returns that refund with done set instead.
+func (s *paymentServer) reserveRefund(orderID, key string, amount *money.Money, reason string) (refund, payment, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.payments[orderID]
+	for _, r := range p.Refunds {
+		if r.Key != key {
+			continue
+		}
+		if r.pending {
+			return refund{}, payment{}, false, status.Errorf(codes.Aborted,
+				"refund %q of order %s is already in progress", key, orderID)
+		}
+		return r, p, true, nil
+	}
+
+	remaining := p.remaining()
+	r := refund{Key: key, Amount: remaining, Reason: reason, At: time.Now().UTC(), pending: true}
+	if amount != nil {
+		if amount.CurrencyCode != p.Amount.CurrencyCode {
+			return refund{}, payment{}, false, status.Errorf(codes.InvalidArgument,
+				"refund currency %s does not match charge currency %s", amount.CurrencyCode, p.Amount.CurrencyCode)
+		}
+		if !amount.IsPositive() {
+			return refund{}, payment{}, false, status.Error(codes.InvalidArgument, "refund amount must be positive")
+		}
+		if over, _ := amount.Cmp(remaining); over > 0 {
+			return refund{}, payment{}, false, status.Errorf(codes.FailedPrecondition,
+				"refund of %s exceeds the %s left to refund", amount, remaining)
+		}
+		r.Amount = *amount
+	}
+	if r.Amount.IsZero() {
+		return r, p, false, nil
+	}
+
+	p.Refunds = append(p.Refunds, r)
+	s.payments[orderID] = p
+	return r, p, false, nil
+}
+
+// settleRefund completes the pending refund with key using the provider's
+// refundID, or drops it if refundID is empty because the provider failed.
+func (s *paymentServer) settleRefund(orderID, key, refundID string) (refund, payment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.payments[orderID]
+	refunds := make([]refund, 0, len(p.Refunds))
+	var settled refund
+	for _, r := range p.Refunds {
+		if r.Key == key && r.pending {
+			if refundID == "" {
+				continue
+			}
+			r.ID, r.pending = refundID, false
+			settled = r
+		}
+		refunds = append(refunds, r)
+	}
+	p.Refunds = refunds
+	s.payments[orderID] = p
+	return settled, p
+}
+
+func refundResponse(r refund, p payment) *paymentv1.RefundResponse {
+	return &paymentv1.RefundResponse{
+		Success:   true,
+		RefundId:  r.ID,
+		Amount:    r.Amount.ToProto(),
+		Remaining: p.remaining().ToProto(),
+	}
+}
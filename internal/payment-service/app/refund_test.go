@@ -0,0 +1,202 @@
+package paymentservice
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/payment/v1"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache/cachetest"
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+// refundCall is one Refund request and what it must return. A nil amount
+// asks for everything left.
+type refundCall struct {
+	key           string
+	amount        *money.Money
+	wantCode      codes.Code
+	wantAmount    int64
+	wantRemaining int64
+}
+
+func amt(m money.Money) *money.Money { return &m }
+
+func captured(t *testing.T, provider *fakeProvider, amount money.Money) *paymentServer {
+	t.Helper()
+	srv := NewClient(&cachetest.Map{}, provider)
+	authorize(t, srv, "o1", amount)
+	if _, err := srv.Capture(context.Background(), &paymentv1.CaptureRequest{OrderId: "o1"}); err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+func TestRefund(t *testing.T) {
+	tests := []struct {
+		name          string
+		calls         []refundCall
+		failRefunds   int
+		wantRefunded  int64
+		wantProviderN int
+	}{
+		{
+			name: "partial refunds then the rest",
+			calls: []refundCall{
+				{"r1", amt(usd(500)), codes.OK, 500, 1500},
+				{"r2", amt(usd(700)), codes.OK, 700, 800},
+				{"", nil, codes.OK, 800, 0},
+			},
+			wantRefunded:  2000,
+			wantProviderN: 3,
+		},
+		{
+			name: "retried key returns the first refund",
+			calls: []refundCall{
+				{"r1", amt(usd(500)), codes.OK, 500, 1500},
+				{"r1", amt(usd(900)), codes.OK, 500, 1500},
+			},
+			wantRefunded:  500,
+			wantProviderN: 1,
+		},
+		{
+			name: "refund above what is left",
+			calls: []refundCall{
+				{"r1", amt(usd(1500)), codes.OK, 1500, 500},
+				{"r2", amt(usd(501)), codes.FailedPrecondition, 0, 0},
+				{"r3", amt(usd(500)), codes.OK, 500, 0},
+			},
+			wantRefunded:  2000,
+			wantProviderN: 2,
+		},
+		{
+			name: "full refund after everything was refunded",
+			calls: []refundCall{
+				{"r1", amt(usd(2000)), codes.OK, 2000, 0},
+				{"", nil, codes.OK, 0, 0},
+			},
+			wantRefunded:  2000,
+			wantProviderN: 1,
+		},
+		{
+			name: "invalid requests",
+			calls: []refundCall{
+				{"", amt(usd(500)), codes.InvalidArgument, 0, 0},
+				{"r1", amt(usd(0)), codes.InvalidArgument, 0, 0},
+				{"r2", amt(usd(-100)), codes.InvalidArgument, 0, 0},
+				{"r3", amt(money.New(500, "EUR")), codes.InvalidArgument, 0, 0},
+			},
+			wantRefunded: 0,
+		},
+		{
+			name:        "failed provider call can be retried",
+			failRefunds: 1,
+			calls: []refundCall{
+				{"r1", amt(usd(500)), codes.Unavailable, 0, 0},
+				{"r1", amt(usd(500)), codes.OK, 500, 1500},
+			},
+			wantRefunded:  500,
+			wantProviderN: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{failRefunds: tt.failRefunds}
+			srv := captured(t, provider, usd(2000))
+
+			for i, c := range tt.calls {
+				req := &paymentv1.RefundRequest{OrderId: "o1", IdempotencyKey: c.key, Reason: "return"}
+				if c.amount != nil {
+					req.Amount = c.amount.ToProto()
+				}
+				res, err := srv.Refund(context.Background(), req)
+				if got := status.Code(err); got != c.wantCode {
+					t.Fatalf("call %d: code %v (%v), want %v", i, got, err, c.wantCode)
+				}
+				if err != nil {
+					continue
+				}
+				if got := res.GetAmount().GetMinorUnits(); got != c.wantAmount {
+					t.Errorf("call %d: refunded %d, want %d", i, got, c.wantAmount)
+				}
+				if got := res.GetRemaining().GetMinorUnits(); got != c.wantRemaining {
+					t.Errorf("call %d: remaining %d, want %d", i, got, c.wantRemaining)
+				}
+			}
+
+			list, err := srv.ListRefunds(context.Background(), &paymentv1.ListRefundsRequest{OrderId: "o1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := list.GetRefunded().GetMinorUnits(); got != tt.wantRefunded {
+				t.Errorf("ListRefunds refunded %d, want %d", got, tt.wantRefunded)
+			}
+			if got := list.GetCaptured().GetMinorUnits(); got != 2000 {
+				t.Errorf("ListRefunds captured %d, want 2000", got)
+			}
+			if provider.refunds != tt.wantProviderN {
+				t.Errorf("%d provider refunds, want %d", provider.refunds, tt.wantProviderN)
+			}
+		})
+	}
+}
+
+func TestRefund_UncapturedPayment(t *testing.T) {
+	srv := NewClient(&cachetest.Map{}, &fakeProvider{})
+	authorize(t, srv, "o1", usd(2000))
+
+	_, err := srv.Refund(context.Background(), &paymentv1.RefundRequest{
+		OrderId: "o1", IdempotencyKey: "r1", Amount: usd(500).ToProto(),
+	})
+	wantCode(t, err, codes.FailedPrecondition)
+
+	// A full refund of a hold releases it instead.
+	if res, err := srv.Refund(context.Background(), &paymentv1.RefundRequest{OrderId: "o1"}); err != nil || !res.GetSuccess() {
+		t.Fatalf("full refund of a hold: %v %v", res, err)
+	}
+	if p, _ := srv.payment("o1"); !p.Voided {
+		t.Errorf("payment %+v, want voided", p)
+	}
+}
+
+// TestRefund_ConcurrentPartialRefunds fires more partial refunds than the
+// capture covers at once: their sum must never exceed it.
+func TestRefund_ConcurrentPartialRefunds(t *testing.T) {
+	srv := captured(t, &fakeProvider{}, usd(2000))
+
+	var wg sync.WaitGroup
+	codesSeen := make(chan codes.Code, 10)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := srv.Refund(context.Background(), &paymentv1.RefundRequest{
+				OrderId: "o1", IdempotencyKey: string(rune('a' + i)), Amount: usd(300).ToProto(),
+			})
+			codesSeen <- status.Code(err)
+		}()
+	}
+	wg.Wait()
+	close(codesSeen)
+
+	ok := 0
+	for c := range codesSeen {
+		switch c {
+		case codes.OK:
+			ok++
+		case codes.FailedPrecondition:
+		default:
+			t.Errorf("unexpected code %v", c)
+		}
+	}
+	if ok != 6 {
+		t.Errorf("%d refunds succeeded, want 6 of 3.00 out of 20.00", ok)
+	}
+	p, _ := srv.payment("o1")
+	if left := p.remaining(); left != usd(200) {
+		t.Errorf("remaining %v, want 2.00 USD", left)
+	}
+}
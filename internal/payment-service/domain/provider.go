@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/money"
+)
+
+var (
+	// ErrProviderTimeout is returned when the provider did not answer in
+	// time. The operation may or may not have been applied: retry it with
+	// the same idempotency key to find out.
+	ErrProviderTimeout = errors.New("payment provider timed out")
+
+	// ErrUnsupportedCurrency is returned for charges in a currency the
+	// provider cannot process.
+	ErrUnsupportedCurrency = errors.New("currency not supported by payment provider")
+
+	// ErrInvalidTransactionState is returned when an operation does not
+	// apply to the transaction as it stands, e.g. voiding a captured one.
+	ErrInvalidTransactionState = errors.New("operation not allowed in the transaction's current state")
+
+	// ErrRefundExceedsCapture is returned when a refund would give back
+	// more than the transaction captured in total.
+	ErrRefundExceedsCapture = errors.New("refunds would exceed the captured amount")
+)
+
+// DeclineCode is the machine-readable reason a provider declined a charge.
+type DeclineCode string
+
+const (
+	DeclineGeneric           DeclineCode = "generic_decline"
+	DeclineInsufficientFunds DeclineCode = "insufficient_funds"
+	DeclineExpiredCard       DeclineCode = "expired_card"
+	DeclineFraudSuspected    DeclineCode = "fraud_suspected"
+	DeclineAmountLimit       DeclineCode = "amount_limit_exceeded"
+)
+
+// ChargeRequest asks the provider to take Amount from the customer, or to
+// hold it when passed to Authorize.
+type ChargeRequest struct {
+	OrderID string
+	Amount  money.Money
+
+	// IdempotencyKey makes retries safe: the provider answers a repeated key
+	// with the original result instead of charging twice.
+	IdempotencyKey string
+}
+
+// ChargeResult is the provider's answer to a charge or an authorization,
+// in which case TransactionID identifies the hold. A decline is a business
+// outcome, not an error.
+type ChargeResult struct {
+	TransactionID string
+	Approved      bool
+	DeclineCode   DeclineCode // set when !Approved
+	Message       string      // human-readable detail from the provider
+}
+
+// RefundRequest asks the provider to give back all or part of a captured
+// charge. A transaction may be refunded several times, up to its amount.
+type RefundRequest struct {
+	OrderID        string
+	TransactionID  string
+	Amount         money.Money
+	Reason         string
+	IdempotencyKey string
+}
+
+// RefundResult is the provider's answer to a refund.
+type RefundResult struct {
+	RefundID string
+}
+
+// SettleRequest captures or voids the authorization TransactionID.
+type SettleRequest struct {
+	OrderID        string
+	TransactionID  string
+	IdempotencyKey string
+
+	// Amount captures less than was authorized and releases the rest; nil
+	// captures all of it. Ignored by Void.
+	Amount *money.Money
+}
+
+// PaymentProvider is the port to the company that actually moves the money.
+// The gRPC server owns idempotency and bookkeeping; whether a charge is
+// approved is the provider's decision.
+type PaymentProvider interface {
+	// Charge authorizes and captures in one go.
+	Charge(ctx context.Context, req ChargeRequest) (*ChargeResult, error)
+	Refund(ctx context.Context, req RefundRequest) (*RefundResult, error)
+
+	// Authorize holds the amount without taking it. The hold is later
+	// either captured or voided.
+	Authorize(ctx context.Context, req ChargeRequest) (*ChargeResult, error)
+
+	// Capture takes the money held by an authorization, or req.Amount of
+	// it. Capturing twice is a no-op; capturing a voided one is
+	// ErrInvalidTransactionState.
+	Capture(ctx context.Context, req SettleRequest) error
+
+	// Void releases an authorization. Voiding twice is a no-op; voiding a
+	// captured one is ErrInvalidTransactionState.
+	Void(ctx context.Context, req SettleRequest) error
+}
+
+// WebhookType names the event a provider notifies asynchronously.
+type WebhookType string
+
+const (
+	WebhookChargeSucceeded     WebhookType = "charge.succeeded"
+	WebhookChargeDeclined      WebhookType = "charge.declined"
+	WebhookChargeAuthorized    WebhookType = "charge.authorized"
+	WebhookChargeCaptured      WebhookType = "charge.captured"
+	WebhookAuthorizationVoided WebhookType = "authorization.voided"
+	WebhookRefundSucceeded     WebhookType = "refund.succeeded"
+)
+
+// Webhook is an asynchronous notification from the provider.
+type Webhook struct {
+	ID            string // unique per event; redeliveries reuse it
+	Type          WebhookType
+	OrderID       string
+	TransactionID string
+	Amount        money.Money
+	DeclineCode   DeclineCode
+	At            time.Time
+}
+
+// WebhookHandler receives provider notifications. Providers deliver at
+// least once, so handlers must tolerate duplicates.
+type WebhookHandler func(ctx context.Context, w Webhook)
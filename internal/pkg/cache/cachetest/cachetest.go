@@ -0,0 +1,40 @@
+// Package cachetest provides a cache.Cache for tests.
+package cachetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jcmexdev/ecommerce-sagas/internal/pkg/cache"
+)
+
+var _ cache.Cache = (*Map)(nil)
+
+// Map is an in-memory cache.Cache without expiry. The zero value is an
+// empty cache.
+type Map struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (c *Map) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil {
+		c.values = make(map[string]string)
+	}
+	c.values[key] = fmt.Sprint(value)
+	return nil
+}
+
+func (c *Map) Get(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key], nil
+}
+
+func (c *Map) GenerateKey(operation, key string) string {
+	return operation + ":" + key
+}
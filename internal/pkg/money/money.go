@@ -0,0 +1,207 @@
+// Package money provides an exact monetary amount: an integer number of
+// minor units (e.g. cents) plus an ISO 4217 currency code. It replaces the
+// float64 amounts that drifted when prices were summed and multiplied.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	moneyv1 "github.com/jcmexdev/ecommerce-sagas/internal/genproto/money/v1"
+)
+
+// DefaultCurrency is assumed for legacy float amounts that carry no currency.
+const DefaultCurrency = "USD"
+
+var (
+	// ErrCurrencyMismatch is returned when combining amounts in different currencies.
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+	// ErrInvalidCurrency is returned for codes that are not three ASCII letters.
+	ErrInvalidCurrency = errors.New("money: invalid currency code")
+
+	// ErrOverflow is returned when a result does not fit in int64 minor units.
+	ErrOverflow = errors.New("money: amount overflows")
+)
+
+// exponents lists currencies whose minor unit is not 1/100 of the major one.
+var exponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"CLP": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"JOD": 3,
+}
+
+// Money is an exact amount in a single currency. The zero value is an
+// invalid amount with no currency; use New or Zero.
+type Money struct {
+	MinorUnits   int64  `json:"minor_units"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+// New returns minor units of currency, e.g. New(1234, "USD") is 12.34 USD.
+func New(minorUnits int64, currency string) Money {
+	return Money{MinorUnits: minorUnits, CurrencyCode: strings.ToUpper(currency)}
+}
+
+// Zero returns a zero amount in currency.
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// FromMajor converts a legacy float amount in major units (e.g. 12.34) to
+// Money, rounding half away from zero to the nearest minor unit. Only use
+// it at the edges that still accept the deprecated double fields.
+func FromMajor(amount float64, currency string) Money {
+	scale := math.Pow10(Exponent(currency))
+	return New(int64(math.Round(amount*scale)), currency)
+}
+
+// Exponent returns the number of decimal places of currency's minor unit.
+func Exponent(currency string) int {
+	if e, ok := exponents[strings.ToUpper(currency)]; ok {
+		return e
+	}
+	return 2
+}
+
+// ValidateCurrency checks that code looks like an ISO 4217 code.
+func ValidateCurrency(code string) error {
+	if len(code) != 3 {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+		}
+	}
+	return nil
+}
+
+// Validate reports whether m has a well-formed currency code.
+func (m Money) Validate() error {
+	return ValidateCurrency(m.CurrencyCode)
+}
+
+// Major returns m in major units as a float64. Only use it to fill the
+// deprecated double fields; never compute with the result.
+func (m Money) Major() float64 {
+	return float64(m.MinorUnits) / math.Pow10(Exponent(m.CurrencyCode))
+}
+
+// IsZero reports whether the amount is zero, regardless of currency.
+func (m Money) IsZero() bool {
+	return m.MinorUnits == 0
+}
+
+// IsPositive reports whether the amount is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.MinorUnits > 0
+}
+
+// Add returns m + o. Both must be in the same currency, and the sum must
+// fit in int64 minor units.
+func (m Money) Add(o Money) (Money, error) {
+	if m.CurrencyCode != o.CurrencyCode {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.CurrencyCode, o.CurrencyCode)
+	}
+	a, b := m.MinorUnits, o.MinorUnits
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrOverflow, m, o)
+	}
+	return New(a+b, m.CurrencyCode), nil
+}
+
+// Sub returns m - o. Both must be in the same currency, and the difference
+// must fit in int64 minor units.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.CurrencyCode != o.CurrencyCode {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrCurrencyMismatch, m.CurrencyCode, o.CurrencyCode)
+	}
+	a, b := m.MinorUnits, o.MinorUnits
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrOverflow, m, o)
+	}
+	return New(a-b, m.CurrencyCode), nil
+}
+
+// Mul returns m multiplied by an integer quantity. The product must fit in
+// int64 minor units.
+func (m Money) Mul(quantity int64) (Money, error) {
+	a := m.MinorUnits
+	p := a * quantity
+	if a != 0 && (p/a != quantity || (a == -1 && quantity == math.MinInt64)) {
+		return Money{}, fmt.Errorf("%w: %s * %d", ErrOverflow, m, quantity)
+	}
+	return New(p, m.CurrencyCode), nil
+}
+
+// Cmp compares m and o: -1 if m < o, 0 if equal, +1 if m > o. Both must be
+// in the same currency.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.CurrencyCode != o.CurrencyCode {
+		return 0, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.CurrencyCode, o.CurrencyCode)
+	}
+	switch {
+	case m.MinorUnits < o.MinorUnits:
+		return -1, nil
+	case m.MinorUnits > o.MinorUnits:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// String formats m as "12.34 USD".
+func (m Money) String() string {
+	exp := Exponent(m.CurrencyCode)
+	if exp == 0 {
+		return fmt.Sprintf("%d %s", m.MinorUnits, m.CurrencyCode)
+	}
+
+	sign, units := "", m.MinorUnits
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	scale := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, units/scale, exp, units%scale, m.CurrencyCode)
+}
+
+// Sum adds amounts that must all be in currency.
+func Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// ToProto converts m to its wire representation.
+func (m Money) ToProto() *moneyv1.Money {
+	return &moneyv1.Money{CurrencyCode: m.CurrencyCode, MinorUnits: m.MinorUnits}
+}
+
+// FromProto converts a wire amount, validating its currency code.
+func FromProto(p *moneyv1.Money) (Money, error) {
+	m := New(p.GetMinorUnits(), p.GetCurrencyCode())
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// FromProtoOrMajor reads a Money field that replaced a deprecated double
+// field: p wins when set, otherwise legacy is converted in DefaultCurrency.
+func FromProtoOrMajor(p *moneyv1.Money, legacy float64) (Money, error) {
+	if p != nil {
+		return FromProto(p)
+	}
+	return FromMajor(legacy, DefaultCurrency), nil
+}
@@ -0,0 +1,115 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFromMajor(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     Money
+	}{
+		{12.34, "USD", New(1234, "USD")},
+		{0.1 + 0.2, "usd", New(30, "USD")},
+		{0.005, "USD", New(1, "USD")},
+		{-0.005, "USD", New(-1, "USD")},
+		{1.4999, "EUR", New(150, "EUR")},
+		{151.5, "JPY", New(152, "JPY")},
+		{1.2345, "KWD", New(1235, "KWD")},
+	}
+	for _, tt := range tests {
+		if got := FromMajor(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("FromMajor(%v, %s) = %v, want %v", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(1234, "USD"), "12.34 USD"},
+		{New(5, "USD"), "0.05 USD"},
+		{New(-1234, "EUR"), "-12.34 EUR"},
+		{New(1500, "JPY"), "1500 JPY"},
+		{New(1234, "KWD"), "1.234 KWD"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		want    Money
+		wantErr error
+	}{
+		{"add", func() (Money, error) { return New(150, "USD").Add(New(275, "USD")) }, New(425, "USD"), nil},
+		{"add currency mismatch", func() (Money, error) { return New(150, "USD").Add(New(1, "EUR")) }, Money{}, ErrCurrencyMismatch},
+		{"add overflow", func() (Money, error) { return New(math.MaxInt64, "USD").Add(New(1, "USD")) }, Money{}, ErrOverflow},
+		{"add negative overflow", func() (Money, error) { return New(math.MinInt64, "USD").Add(New(-1, "USD")) }, Money{}, ErrOverflow},
+		{"add up to the limit", func() (Money, error) { return New(math.MaxInt64-1, "USD").Add(New(1, "USD")) }, New(math.MaxInt64, "USD"), nil},
+		{"sub", func() (Money, error) { return New(150, "USD").Sub(New(275, "USD")) }, New(-125, "USD"), nil},
+		{"sub currency mismatch", func() (Money, error) { return New(150, "USD").Sub(New(1, "JPY")) }, Money{}, ErrCurrencyMismatch},
+		{"sub overflow", func() (Money, error) { return New(math.MinInt64, "USD").Sub(New(1, "USD")) }, Money{}, ErrOverflow},
+		{"sub negative overflow", func() (Money, error) { return New(0, "USD").Sub(New(math.MinInt64, "USD")) }, Money{}, ErrOverflow},
+		{"mul", func() (Money, error) { return New(1999, "USD").Mul(3) }, New(5997, "USD"), nil},
+		{"mul by zero", func() (Money, error) { return New(math.MaxInt64, "USD").Mul(0) }, New(0, "USD"), nil},
+		{"mul overflow", func() (Money, error) { return New(math.MaxInt64/2+1, "USD").Mul(2) }, Money{}, ErrOverflow},
+		{"mul large quantity", func() (Money, error) { return New(100, "USD").Mul(math.MaxInt64 / 10) }, Money{}, ErrOverflow},
+		{"mul min by -1", func() (Money, error) { return New(math.MinInt64, "USD").Mul(-1) }, Money{}, ErrOverflow},
+		{"mul -1 by min", func() (Money, error) { return New(-1, "USD").Mul(math.MinInt64) }, Money{}, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []Money
+		want    Money
+		wantErr error
+	}{
+		{"empty", nil, Zero("USD"), nil},
+		{"same currency", []Money{New(100, "USD"), New(250, "USD")}, New(350, "USD"), nil},
+		{"other currency", []Money{New(100, "USD"), New(250, "EUR")}, Money{}, ErrCurrencyMismatch},
+		{"overflow", []Money{New(math.MaxInt64, "USD"), New(1, "USD")}, Money{}, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sum("USD", tt.amounts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCurrency(t *testing.T) {
+	for code, valid := range map[string]bool{"USD": true, "usd": false, "US": false, "USDT": false, "U$D": false, "": false} {
+		if err := ValidateCurrency(code); (err == nil) != valid {
+			t.Errorf("ValidateCurrency(%q) = %v, want valid %v", code, err, valid)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrNoRate is returned when a RatesProvider cannot price a currency pair.
+var ErrNoRate = errors.New("money: no exchange rate")
+
+// RatesProvider is the port for exchange rates. Production deployments plug
+// in a market data feed; StaticRates serves a fixed table for local runs.
+type RatesProvider interface {
+	// Rate returns how many major units of to one major unit of from is
+	// worth, or an error wrapping ErrNoRate.
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+// DefaultRates are indicative rates against USD for local runs and demos.
+// They are not market data.
+var DefaultRates = map[string]string{
+	"USD": "1",
+	"EUR": "0.92",
+	"GBP": "0.79",
+	"CAD": "1.37",
+	"MXN": "17.10",
+	"JPY": "151.50",
+	"CLP": "940",
+	"KWD": "0.307",
+}
+
+var _ RatesProvider = (*StaticRates)(nil)
+
+// StaticRates is a RatesProvider backed by a fixed table of rates against a
+// base currency. Cross rates are derived through the base.
+type StaticRates struct {
+	rates map[string]*big.Rat // units of currency per unit of base
+}
+
+// NewStaticRates parses table, which maps a currency code to its rate
+// against base written as a decimal string (e.g. "0.92"). The base itself
+// is always priced at 1.
+//
+//	rates, err := money.NewStaticRates("USD", money.DefaultRates)
+func NewStaticRates(base string, table map[string]string) (*StaticRates, error) {
+	base = strings.ToUpper(base)
+	if err := ValidateCurrency(base); err != nil {
+		return nil, err
+	}
+
+	rates := map[string]*big.Rat{base: big.NewRat(1, 1)}
+	for code, s := range table {
+		code = strings.ToUpper(code)
+		if err := ValidateCurrency(code); err != nil {
+			return nil, err
+		}
+		r, ok := new(big.Rat).SetString(s)
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("money: invalid rate %q for %s", s, code)
+		}
+		rates[code] = r
+	}
+	return &StaticRates{rates: rates}, nil
+}
+
+func (s *StaticRates) Rate(_ context.Context, from, to string) (*big.Rat, error) {
+	f, ok := s.rates[strings.ToUpper(from)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+	}
+	t, ok := s.rates[strings.ToUpper(to)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s to %s", ErrNoRate, from, to)
+	}
+	return new(big.Rat).Quo(t, f), nil
+}
+
+// Convert returns m expressed in currency to, rounding half away from zero
+// to the nearest minor unit of to. Amounts already in to are returned as is.
+func Convert(ctx context.Context, rates RatesProvider, m Money, to string) (Money, error) {
+	to = strings.ToUpper(to)
+	if m.CurrencyCode == to {
+		return m, nil
+	}
+
+	rate, err := rates.Rate(ctx, m.CurrencyCode, to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// minor(to) = minor(from) * rate * 10^(exp(to) - exp(from))
+	v := new(big.Rat).SetInt64(m.MinorUnits)
+	v.Mul(v, rate)
+	v.Mul(v, new(big.Rat).SetFrac(pow10(Exponent(to)), pow10(Exponent(m.CurrencyCode))))
+
+	units, ok := roundHalfAwayFromZero(v)
+	if !ok {
+		return Money{}, fmt.Errorf("money: %s overflows when converted to %s", m, to)
+	}
+	return New(units, to), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundHalfAwayFromZero rounds v to an integer; ok is false if the result
+// does not fit in an int64.
+func roundHalfAwayFromZero(v *big.Rat) (n int64, ok bool) {
+	num := new(big.Int).Abs(v.Num())
+	q, r := new(big.Int).QuoRem(num, v.Denom(), new(big.Int))
+	if r.Lsh(r, 1).Cmp(v.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if v.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return 0, false
+	}
+	return q.Int64(), true
+}
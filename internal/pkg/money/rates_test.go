@@ -0,0 +1,87 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	defaults, err := NewStaticRates("USD", DefaultRates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	halves, err := NewStaticRates("USD", map[string]string{"EUR": "0.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		rates   RatesProvider
+		m       Money
+		to      string
+		want    Money
+		wantErr bool
+	}{
+		{"same currency", defaults, New(1234, "USD"), "usd", New(1234, "USD"), false},
+		{"against the base", defaults, New(1000, "USD"), "EUR", New(920, "EUR"), false},
+		{"to the base", defaults, New(920, "EUR"), "USD", New(1000, "USD"), false},
+		{"cross rate", defaults, New(7900, "GBP"), "EUR", New(9200, "EUR"), false},
+		{"to a currency without minor units", defaults, New(100, "USD"), "JPY", New(152, "JPY"), false},
+		{"from a currency without minor units", defaults, New(15150, "JPY"), "USD", New(10000, "USD"), false},
+		{"to a three-decimal currency", defaults, New(100, "USD"), "KWD", New(307, "KWD"), false},
+		{"half rounds up", halves, New(1, "USD"), "EUR", New(1, "EUR"), false},
+		{"half rounds away from zero", halves, New(-1, "USD"), "EUR", New(-1, "EUR"), false},
+		{"below half rounds down", defaults, New(1, "USD"), "MXN", New(17, "MXN"), false},
+		{"unknown currency", defaults, New(100, "USD"), "XYZ", Money{}, true},
+		{"overflow", defaults, New(math.MaxInt64, "USD"), "JPY", Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(context.Background(), tt.rates, tt.m, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate_UnknownCurrency(t *testing.T) {
+	rates, err := NewStaticRates("USD", DefaultRates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pair := range [][2]string{{"XYZ", "USD"}, {"USD", "XYZ"}} {
+		if _, err := rates.Rate(context.Background(), pair[0], pair[1]); !errors.Is(err, ErrNoRate) {
+			t.Errorf("Rate(%s, %s) error = %v, want ErrNoRate", pair[0], pair[1], err)
+		}
+	}
+}
+
+func TestNewStaticRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		table   map[string]string
+		wantErr bool
+	}{
+		{"defaults", "usd", DefaultRates, false},
+		{"invalid base", "US", nil, true},
+		{"invalid code", "USD", map[string]string{"EURO": "0.92"}, true},
+		{"not a number", "USD", map[string]string{"EUR": "abc"}, true},
+		{"zero rate", "USD", map[string]string{"EUR": "0"}, true},
+		{"negative rate", "USD", map[string]string{"EUR": "-1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStaticRates(tt.base, tt.table); (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package pubsub is a small in-process publish/subscribe broker. It fans
+// messages for a topic out to every current subscriber of that topic.
+package pubsub
+
+import "sync"
+
+// Broker delivers messages of type T to subscribers grouped by topic.
+// Publishing never blocks: a subscriber whose buffer is full misses the
+// message instead of stalling the publisher.
+type Broker[T any] struct {
+	mu     sync.RWMutex
+	subs   map[string]map[chan T]struct{}
+	buffer int
+}
+
+// NewBroker creates a Broker whose subscriptions buffer up to buffer messages.
+func NewBroker[T any](buffer int) *Broker[T] {
+	return &Broker[T]{
+		subs:   make(map[string]map[chan T]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe registers interest in topic. The returned cancel function
+// unsubscribes and closes the channel; it is safe to call more than once.
+func (b *Broker[T]) Subscribe(topic string) (<-chan T, func()) {
+	ch := make(chan T, b.buffer)
+
+	b.mu.Lock()
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[chan T]struct{})
+	}
+	b.subs[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subs[topic], ch)
+			if len(b.subs[topic]) == 0 {
+				delete(b.subs, topic)
+			}
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Publish sends msg to every subscriber of topic and reports how many
+// subscribers received it.
+func (b *Broker[T]) Publish(topic string, msg T) int {
+	// The read lock is held while sending so that cancel cannot close a
+	// channel underneath us.
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	delivered := 0
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- msg:
+			delivered++
+		default:
+		}
+	}
+	return delivered
+}
@@ -0,0 +1,87 @@
+package pubsub
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		name string
+		// subscribe lists the topic of every subscription.
+		subscribe     []string
+		publish       []string
+		wantDelivered []int
+		// wantReceived is how many messages each subscription holds.
+		wantReceived []int
+	}{
+		{"no subscriber", nil, []string{"o1"}, []int{0}, nil},
+		{"fans out to the topic", []string{"o1", "o1", "o2"}, []string{"o1"}, []int{2}, []int{1, 1, 0}},
+		{"full buffer drops instead of blocking", []string{"o1"}, []string{"o1", "o1", "o1"}, []int{1, 1, 0}, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBroker[int](2)
+			subs := make([]<-chan int, len(tt.subscribe))
+			for i, topic := range tt.subscribe {
+				ch, cancel := b.Subscribe(topic)
+				defer cancel()
+				subs[i] = ch
+			}
+			for i, topic := range tt.publish {
+				if got := b.Publish(topic, i); got != tt.wantDelivered[i] {
+					t.Errorf("Publish #%d delivered to %d, want %d", i, got, tt.wantDelivered[i])
+				}
+			}
+			for i, ch := range subs {
+				if got := len(ch); got != tt.wantReceived[i] {
+					t.Errorf("subscriber %d holds %d messages, want %d", i, got, tt.wantReceived[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribe_Cancel(t *testing.T) {
+	b := NewBroker[string](1)
+	ch, cancel := b.Subscribe("o1")
+	other, cancelOther := b.Subscribe("o1")
+	defer cancelOther()
+
+	cancel()
+	cancel() // safe to call twice
+	if _, ok := <-ch; ok {
+		t.Fatal("channel still open after cancel")
+	}
+	if got := b.Publish("o1", "x"); got != 1 {
+		t.Errorf("delivered to %d, want only the remaining subscriber", got)
+	}
+	if msg := <-other; msg != "x" {
+		t.Errorf("remaining subscriber got %q, want x", msg)
+	}
+
+	cancelOther()
+	if _, ok := b.subs["o1"]; ok {
+		t.Error("topic kept after its last subscriber left")
+	}
+}
+
+// TestPublish_ConcurrentCancel runs under -race: cancelling while messages
+// are published must neither panic on a closed channel nor race.
+func TestPublish_ConcurrentCancel(t *testing.T) {
+	b := NewBroker[int](1)
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(2)
+		_, cancel := b.Subscribe("o1")
+		go func() {
+			defer wg.Done()
+			cancel()
+		}()
+		go func() {
+			defer wg.Done()
+			b.Publish("o1", 1)
+		}()
+	}
+	wg.Wait()
+}
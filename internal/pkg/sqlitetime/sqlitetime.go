@@ -0,0 +1,27 @@
+// Package sqlitetime stores timestamps in SQLite, which has no datetime
+// type, as RFC3339 TEXT.
+package sqlitetime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layout is a fixed-width RFC3339 layout. Keeping every timestamp the same
+// width makes lexical comparison in SQL match chronological order.
+const Layout = "2006-01-02T15:04:05.000000000Z"
+
+// Format renders t in UTC using Layout.
+func Format(t time.Time) string {
+	return t.UTC().Format(Layout)
+}
+
+// Parse parses a stored timestamp. Any RFC3339 text is accepted, so rows
+// written before Layout was adopted still read.
+func Parse(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("sqlite: parse time %q: %w", s, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,46 @@
+package sqlitetime
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFormat_SortsLikeTime(t *testing.T) {
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	times := []time.Time{
+		base,
+		base.Add(time.Nanosecond),
+		base.Add(100 * time.Millisecond),
+		base.Add(time.Second),
+		base.Add(time.Second).In(time.FixedZone("CET", 3600)),
+	}
+	var formatted []string
+	for _, tm := range times {
+		s := Format(tm)
+		if len(s) != len(Layout) {
+			t.Errorf("Format(%v) = %q, want %d characters", tm, s, len(Layout))
+		}
+		formatted = append(formatted, s)
+	}
+	if !slices.IsSorted(formatted) {
+		t.Errorf("formatted times are out of order: %q", formatted)
+	}
+}
+
+func TestParse(t *testing.T) {
+	want := time.Date(2026, 1, 2, 3, 4, 5, 100_000_000, time.UTC)
+	for _, s := range []string{
+		"2026-01-02T03:04:05.100000000Z",
+		"2026-01-02T03:04:05.1Z", // written before Layout
+		"2026-01-02T04:04:05.1+01:00",
+	} {
+		got, err := Parse(s)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Parse(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	if _, err := Parse("yesterday"); err == nil {
+		t.Error("Parse(yesterday) succeeded, want an error")
+	}
+}